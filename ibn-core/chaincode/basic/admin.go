@@ -0,0 +1,513 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// AdminContract provides administrative functions under the "admin" namespace.
+// Every function requires the caller to hold the admin=true attribute.
+type AdminContract struct {
+	contractapi.Contract
+	assets AssetContract
+}
+
+// GetName returns the namespace of the admin contract
+func (a *AdminContract) GetName() string {
+	return "admin"
+}
+
+// PurgeDeletedAssets permanently removes soft-deleted assets whose retention
+// window has elapsed and returns the purged IDs. Only admins may purge. Like
+// DeleteAssetsByOwner it removes at most maxBatchSize assets per call; the
+// AssetsPurged event reports how many candidates remain for the next call.
+func (a *AdminContract) PurgeDeletedAssets(ctx contractapi.TransactionContextInterface, retentionSeconds int64) ([]string, error) {
+	logf(ctx, "===== START: PurgeDeletedAssets - Retention: %ds =====", retentionSeconds)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	candidates, err := a.assets.findDeletionCandidates(ctx, retentionSeconds)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+	remaining := 0
+	if len(candidates) > maxBatchSize {
+		remaining = len(candidates) - maxBatchSize
+		candidates = candidates[:maxBatchSize]
+		logf(ctx, "INFO: %d more deleted assets than the batch limit", remaining)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	purged := []string{}
+	for _, asset := range candidates {
+		if err := removeAsset(ctx, asset, clientID, "purge"); err != nil {
+			logf(ctx, "ERROR: %v", err)
+			return nil, err
+		}
+		purged = append(purged, asset.ID)
+	}
+
+	err = emitEvent(ctx, "AssetsPurged", map[string]interface{}{
+		"assetIDs":         purged,
+		"count":            len(purged),
+		"remaining":        remaining,
+		"retentionSeconds": retentionSeconds,
+		"purgedBy":         clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Purged %d deleted assets", len(purged))
+	logln(ctx, "===== END: PurgeDeletedAssets =====")
+	return purged, nil
+}
+
+// SetMaintenanceMode turns maintenance mode on or off. While it is on every
+// mutating function is rejected and reads keep working. It is the only setter
+// that does not call requireWritable, so that maintenance mode can be left.
+func (a *AdminContract) SetMaintenanceMode(ctx contractapi.TransactionContextInterface, on bool) error {
+	logf(ctx, "===== START: SetMaintenanceMode - On: %t =====", on)
+
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	config, err := getConfig(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	config.MaintenanceMode = on
+
+	if err := putConfig(ctx, config); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	err = emitEvent(ctx, "MaintenanceModeChanged", map[string]interface{}{
+		"maintenanceMode": on,
+		"changedBy":       clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Maintenance mode set to %t", on)
+	logln(ctx, "===== END: SetMaintenanceMode =====")
+	return nil
+}
+
+// SetImmutableFields sets the comma-separated asset fields that may not change
+// once an asset has been created. An empty list makes every field mutable.
+func (a *AdminContract) SetImmutableFields(ctx contractapi.TransactionContextInterface, fieldsCSV string) error {
+	logf(ctx, "===== START: SetImmutableFields - Fields: %s =====", fieldsCSV)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	var fields []string
+	if strings.TrimSpace(fieldsCSV) != "" {
+		var err error
+		fields, err = parseFieldList(fieldsCSV)
+		if err != nil {
+			logf(ctx, "ERROR: Invalid field list: %v", err)
+			return err
+		}
+	}
+
+	config, err := getConfig(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	config.ImmutableFields = fields
+
+	if err := putConfig(ctx, config); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Immutable fields set to %v", fields)
+	logln(ctx, "===== END: SetImmutableFields =====")
+	return nil
+}
+
+// SetMethodMetrics turns the MethodMetric event emitted after each transaction
+// function on or off
+func (a *AdminContract) SetMethodMetrics(ctx contractapi.TransactionContextInterface, on bool) error {
+	logf(ctx, "===== START: SetMethodMetrics - On: %t =====", on)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	config, err := getConfig(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	config.MethodMetrics = on
+
+	if err := putConfig(ctx, config); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Method metrics set to %t", on)
+	logln(ctx, "===== END: SetMethodMetrics =====")
+	return nil
+}
+
+// SetEvents turns chaincode events on or off. While they are off no function
+// emits an event, e.g. to keep blocks small during a bulk load.
+func (a *AdminContract) SetEvents(ctx contractapi.TransactionContextInterface, on bool) error {
+	logf(ctx, "===== START: SetEvents - On: %t =====", on)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	config, err := getConfig(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	config.Events = on
+
+	if err := putConfig(ctx, config); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Events set to %t", on)
+	logln(ctx, "===== END: SetEvents =====")
+	return nil
+}
+
+// SetMaxEventPayloadSize sets the largest event payload, in bytes, that
+// functions may emit
+func (a *AdminContract) SetMaxEventPayloadSize(ctx contractapi.TransactionContextInterface, maxBytes int) error {
+	logf(ctx, "===== START: SetMaxEventPayloadSize - Max Bytes: %d =====", maxBytes)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if maxBytes <= 0 {
+		logf(ctx, "ERROR: Invalid event payload size %d", maxBytes)
+		return fmt.Errorf("event payload size must be positive: %w", ErrInvalidInput)
+	}
+
+	config, err := getConfig(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	config.MaxEventPayloadBytes = maxBytes
+
+	if err := putConfig(ctx, config); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Max event payload size set to %d bytes", maxBytes)
+	logln(ctx, "===== END: SetMaxEventPayloadSize =====")
+	return nil
+}
+
+// SetRequireRegisteredOwners turns the check that transfers only go to owners
+// in the owner registry on or off
+func (a *AdminContract) SetRequireRegisteredOwners(ctx contractapi.TransactionContextInterface, on bool) error {
+	logf(ctx, "===== START: SetRequireRegisteredOwners - On: %t =====", on)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	config, err := getConfig(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	config.RequireRegisteredOwners = on
+
+	if err := putConfig(ctx, config); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Require registered owners set to %t", on)
+	logln(ctx, "===== END: SetRequireRegisteredOwners =====")
+	return nil
+}
+
+// SetCategoryMinimum sets the smallest appraised value accepted for assets in
+// a category. A minimum of 0 removes the category's minimum. Existing assets
+// below a new minimum are left as they are until their value next changes.
+func (a *AdminContract) SetCategoryMinimum(ctx contractapi.TransactionContextInterface, category string, minimum int) error {
+	logf(ctx, "===== START: SetCategoryMinimum - Category: %s, Minimum: %d =====", category, minimum)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if category == "" {
+		logln(ctx, "ERROR: Category cannot be empty")
+		return fmt.Errorf("category cannot be empty: %w", ErrInvalidInput)
+	}
+	if err := validateCategory(category); err != nil {
+		logf(ctx, "ERROR: Invalid category: %v", err)
+		return fmt.Errorf("%v: %w", err, ErrInvalidInput)
+	}
+	if minimum < 0 {
+		logf(ctx, "ERROR: Invalid minimum %d", minimum)
+		return fmt.Errorf("category minimum cannot be negative: %w", ErrInvalidInput)
+	}
+
+	config, err := getConfig(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if minimum == 0 {
+		delete(config.CategoryMinimumValues, category)
+	} else {
+		if config.CategoryMinimumValues == nil {
+			config.CategoryMinimumValues = map[string]int{}
+		}
+		config.CategoryMinimumValues[category] = minimum
+	}
+
+	if err := putConfig(ctx, config); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Minimum value of category %s set to %d", category, minimum)
+	logln(ctx, "===== END: SetCategoryMinimum =====")
+	return nil
+}
+
+// InitConfig sets the validation limits from a JSON object such as
+// {"MaxIDLength":32,"MaxSize":5000,"MaxAppraisedValue":1000000}. Limits left
+// out of the object take their defaults.
+func (a *AdminContract) InitConfig(ctx contractapi.TransactionContextInterface, configJSON string) error {
+	logf(ctx, "===== START: InitConfig - Config: %s =====", configJSON)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	limits := defaultValidationLimits
+	decoder := json.NewDecoder(strings.NewReader(configJSON))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&limits); err != nil {
+		logf(ctx, "ERROR: Invalid config: %v", err)
+		return fmt.Errorf("config must be a JSON object of validation limits: %v: %w", err, ErrInvalidInput)
+	}
+	if err := limits.validate(); err != nil {
+		logf(ctx, "ERROR: Invalid config: %v", err)
+		return err
+	}
+
+	config, err := getConfig(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	config.Limits = limits
+
+	if err := putConfig(ctx, config); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Validation limits set to %+v", limits)
+	logln(ctx, "===== END: InitConfig =====")
+	return nil
+}
+
+// OwnerIndexRebuild reports what RebuildOwnerIndex changed
+type OwnerIndexRebuild struct {
+	Removed int `json:"Removed"`
+	Created int `json:"Created"`
+	Indexed int `json:"Indexed"`
+}
+
+// RebuildOwnerIndex brings the owner index back in line with the assets after
+// it has drifted, e.g. through a partial write of an earlier chaincode version.
+// Entries for assets that no longer exist or have another owner are removed,
+// entries missing for existing assets are created, and correct entries are
+// left as they are. Only admins may rebuild the index.
+func (a *AdminContract) RebuildOwnerIndex(ctx contractapi.TransactionContextInterface) (*OwnerIndexRebuild, error) {
+	logln(ctx, "===== START: RebuildOwnerIndex =====")
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	assetsIterator, err := ctx.GetStub().GetStateByRange("", "")
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get state by range: %v", err)
+		return nil, fmt.Errorf("failed to get state by range: %w", err)
+	}
+	defer assetsIterator.Close()
+
+	// expected maps the index key of every asset to the asset, in scan order
+	expected := make(map[string]*Asset)
+	var expectedKeys []string
+	for assetsIterator.HasNext() {
+		queryResponse, err := assetsIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate results: %v", err)
+			return nil, fmt.Errorf("failed to iterate results: %w", err)
+		}
+
+		var asset Asset
+		if err := json.Unmarshal(queryResponse.Value, &asset); err != nil {
+			logf(ctx, "WARNING: Failed to unmarshal asset, skipping: %v", err)
+			continue
+		}
+		key, err := ctx.GetStub().CreateCompositeKey(ownerIndexObjectType, []string{asset.Owner, asset.ID})
+		if err != nil {
+			logf(ctx, "ERROR: Failed to create owner index key: %v", err)
+			return nil, fmt.Errorf("failed to create owner index key: %w", err)
+		}
+		expected[key] = &asset
+		expectedKeys = append(expectedKeys, key)
+	}
+
+	indexIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(ownerIndexObjectType, []string{})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read owner index: %v", err)
+		return nil, fmt.Errorf("failed to read owner index: %w", err)
+	}
+	defer indexIterator.Close()
+
+	result := &OwnerIndexRebuild{Indexed: len(expected)}
+	present := make(map[string]bool)
+	for indexIterator.HasNext() {
+		queryResponse, err := indexIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate owner index: %v", err)
+			return nil, fmt.Errorf("failed to iterate owner index: %w", err)
+		}
+
+		if _, ok := expected[queryResponse.Key]; ok {
+			present[queryResponse.Key] = true
+			continue
+		}
+		if err := ctx.GetStub().DelState(queryResponse.Key); err != nil {
+			logf(ctx, "ERROR: Failed to delete owner index entry: %v", err)
+			return nil, fmt.Errorf("failed to delete owner index entry: %w", err)
+		}
+		result.Removed++
+	}
+
+	for _, key := range expectedKeys {
+		if present[key] {
+			continue
+		}
+		asset := expected[key]
+		if err := putOwnerIndex(ctx, asset.Owner, asset.ID); err != nil {
+			logf(ctx, "ERROR: %v", err)
+			return nil, err
+		}
+		result.Created++
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	err = emitEvent(ctx, "OwnerIndexRebuilt", map[string]interface{}{
+		"removed":   result.Removed,
+		"created":   result.Created,
+		"indexed":   result.Indexed,
+		"rebuiltBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Owner index rebuilt, %d entries removed and %d created", result.Removed, result.Created)
+	logln(ctx, "===== END: RebuildOwnerIndex =====")
+	return result, nil
+}
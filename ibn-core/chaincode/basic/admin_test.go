@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-contract-api-go/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test that both contracts are registered and routed by name
+func TestContractRouting(t *testing.T) {
+	cc, err := contractapi.NewChaincode(&AssetContract{}, &AdminContract{})
+	require.NoError(t, err)
+	assert.Equal(t, "asset", cc.DefaultContract)
+
+	stub := shimtest.NewMockStub("basic", cc)
+
+	t.Run("Metadata Lists Both Contracts", func(t *testing.T) {
+		response := stub.MockInvoke("tx1", [][]byte{[]byte("org.hyperledger.fabric:GetMetadata")})
+		require.Equal(t, int32(200), response.Status, response.Message)
+
+		var ccMetadata metadata.ContractChaincodeMetadata
+		require.NoError(t, json.Unmarshal(response.Payload, &ccMetadata))
+
+		transactions := func(contract string) []string {
+			var names []string
+			for _, tx := range ccMetadata.Contracts[contract].Transactions {
+				names = append(names, tx.Name)
+			}
+			return names
+		}
+		assert.Contains(t, transactions("asset"), "CreateAsset")
+		assert.NotContains(t, transactions("asset"), "PurgeDeletedAssets")
+		assert.NotContains(t, transactions("asset"), "TryReadAsset")
+		assert.Contains(t, transactions("admin"), "PurgeDeletedAssets")
+		assert.NotContains(t, transactions("asset"), "RenameOwner")
+		assert.Contains(t, transactions("admin"), "RenameOwner")
+		assert.NotContains(t, transactions("asset"), "AdjustValueBySize")
+		assert.Contains(t, transactions("admin"), "AdjustValueBySize")
+		assert.NotContains(t, transactions("asset"), "DeleteAssetsByOwner")
+		assert.Contains(t, transactions("admin"), "DeleteAssetsByOwner")
+		assert.NotContains(t, transactions("admin"), "CreateAsset")
+	})
+
+	t.Run("Asset Functions Are Not Routed To Admin", func(t *testing.T) {
+		response := stub.MockInvoke("tx2", [][]byte{[]byte("admin:CreateAsset")})
+		assert.Contains(t, response.Message, "Function CreateAsset not found in contract admin")
+	})
+
+	t.Run("Admin Functions Are Not Routed To Default Contract", func(t *testing.T) {
+		response := stub.MockInvoke("tx3", [][]byte{[]byte("PurgeDeletedAssets"), []byte("0")})
+		assert.Contains(t, response.Message, "Function PurgeDeletedAssets not found in contract asset")
+	})
+
+	t.Run("Unknown Contract", func(t *testing.T) {
+		response := stub.MockInvoke("tx4", [][]byte{[]byte("billing:Charge")})
+		assert.Contains(t, response.Message, "Contract not found with name billing")
+	})
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+const (
+	// maxAuditNotes bounds the number of audit notes of an asset
+	maxAuditNotes = 100
+	// maxAuditNoteLength bounds the length of one audit note
+	maxAuditNoteLength = 1024
+)
+
+// AuditNote is a free-text compliance note attached to an asset
+type AuditNote struct {
+	Text      string    `json:"Text"`
+	Author    string    `json:"Author"`
+	Timestamp time.Time `json:"Timestamp"`
+}
+
+// AppendAuditNote adds a note to the audit trail of an asset. Notes are
+// append-only: no function edits or removes them. They may also be added to a
+// locked asset, since they do not change the asset itself.
+func (s *AssetContract) AppendAuditNote(ctx contractapi.TransactionContextInterface, id string, text string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: AppendAuditNote - ID: %s =====", id)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+	if strings.TrimSpace(text) == "" {
+		logln(ctx, "ERROR: Audit note is empty")
+		return fmt.Errorf("audit note cannot be empty: %w", ErrInvalidInput)
+	}
+	if len(text) > maxAuditNoteLength {
+		logf(ctx, "ERROR: Audit note of %d characters is too long", len(text))
+		return fmt.Errorf("audit note cannot exceed %d characters: %w", maxAuditNoteLength, ErrInvalidInput)
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if len(asset.AuditNotes) >= maxAuditNotes {
+		logf(ctx, "ERROR: Asset %s already has %d audit notes", id, maxAuditNotes)
+		return fmt.Errorf("asset %s cannot have more than %d audit notes", id, maxAuditNotes)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	asset.AuditNotes = append(asset.AuditNotes, AuditNote{Text: text, Author: clientID, Timestamp: now})
+	stampUpdate(ctx, asset, now, clientID)
+
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+		return fmt.Errorf("failed to marshal asset: %w", err)
+	}
+
+	err = ctx.GetStub().PutState(id, assetJSON)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to append audit note: %v", err)
+		return fmt.Errorf("failed to append audit note: %w", err)
+	}
+	if err := recordChange(ctx, id, "auditNote"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "AuditNoteAppended", map[string]interface{}{
+		"assetID": id,
+		"author":  clientID,
+		"count":   len(asset.AuditNotes),
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Appended audit note %d to asset %s", len(asset.AuditNotes), id)
+	logln(ctx, "===== END: AppendAuditNote =====")
+	return nil
+}
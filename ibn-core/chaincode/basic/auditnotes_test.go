@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test appending audit notes
+func TestAppendAuditNote(t *testing.T) {
+	contract := AssetContract{}
+
+	appendNote := func(ledger *Ledger, identity *MockClientIdentity, text string) error {
+		_, err := ledger.Invoke(identity, func(ctx *MockTransactionContext) error {
+			return contract.AppendAuditNote(ctx, "asset1", text)
+		})
+		return err
+	}
+
+	t.Run("Two Notes", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+
+		require.NoError(t, appendNote(ledger, ownerIdentity("John"), "valuation checked"))
+		require.NoError(t, appendNote(ledger, adminIdentity, "sanctions screening passed"))
+
+		asset := readCommitted(t, ledger, "asset1")
+		require.Len(t, asset.AuditNotes, 2)
+		assert.Equal(t, "valuation checked", asset.AuditNotes[0].Text)
+		assert.Equal(t, ownerIdentity("John").ID, asset.AuditNotes[0].Author)
+		assert.Equal(t, "sanctions screening passed", asset.AuditNotes[1].Text)
+		assert.Equal(t, adminIdentity.ID, asset.AuditNotes[1].Author)
+		assert.True(t, asset.AuditNotes[0].Timestamp.Before(asset.AuditNotes[1].Timestamp))
+	})
+
+	t.Run("Note Count Cap", func(t *testing.T) {
+		notes := make([]AuditNote, maxAuditNotes)
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300, AuditNotes: notes})
+
+		err := appendNote(ledger, ownerIdentity("John"), "one too many")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot have more than")
+		assert.Len(t, readCommitted(t, ledger, "asset1").AuditNotes, maxAuditNotes)
+	})
+
+	t.Run("Note Length Cap", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+
+		err := appendNote(ledger, ownerIdentity("John"), strings.Repeat("x", maxAuditNoteLength+1))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+
+		err = appendNote(ledger, ownerIdentity("John"), "  ")
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Empty(t, readCommitted(t, ledger, "asset1").AuditNotes)
+	})
+}
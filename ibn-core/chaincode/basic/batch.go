@@ -0,0 +1,688 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// maxBatchSize bounds the number of items a batch function accepts
+const maxBatchSize = 100
+
+// CreateAssetsBatch creates all assets of a JSON array in one transaction. Each
+// asset is validated like in CreateAsset; if any of them is invalid, already
+// exists or repeats an ID of the batch, the whole batch fails and nothing is
+// written. A single AssetsBatchCreated event replaces the per-asset events.
+func (s *AssetContract) CreateAssetsBatch(ctx contractapi.TransactionContextInterface, assetsJSON string) error {
+	logln(ctx, "===== START: CreateAssetsBatch =====")
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(assetsJSON), &items); err != nil {
+		logf(ctx, "ERROR: Invalid batch: %v", err)
+		return fmt.Errorf("assets must be a JSON array of assets: %w", err)
+	}
+	if len(items) == 0 {
+		logln(ctx, "ERROR: Empty batch")
+		return fmt.Errorf("a batch must contain at least one asset")
+	}
+	if len(items) > maxBatchSize {
+		logf(ctx, "ERROR: Batch of %d items exceeds the limit", len(items))
+		return fmt.Errorf("a batch cannot contain more than %d assets", maxBatchSize)
+	}
+
+	seen := make(map[string]bool, len(items))
+	silent := withoutEvents(ctx)
+	created := make([]string, 0, len(items))
+	for i, raw := range items {
+		if err := validateAssetJSON(raw); err != nil {
+			logf(ctx, "ERROR: Asset at index %d is invalid: %v", i, err)
+			return fmt.Errorf("asset at index %d: %w", i, err)
+		}
+		var item Asset
+		if err := json.Unmarshal(raw, &item); err != nil {
+			logf(ctx, "ERROR: Asset at index %d is invalid: %v", i, err)
+			return fmt.Errorf("asset at index %d: %w", i, err)
+		}
+
+		id := normalizeAssetID(item.ID)
+		if seen[id] {
+			logf(ctx, "ERROR: Duplicate ID %s at index %d", id, i)
+			return fmt.Errorf("asset at index %d (%s): duplicate ID within batch", i, id)
+		}
+		seen[id] = true
+
+		// An error aborts the transaction, discarding the writes of earlier items
+		if err := s.CreateAsset(silent, id, item.Color, item.Size, item.Owner, item.AppraisedValue); err != nil {
+			logf(ctx, "ERROR: Asset at index %d (%s) failed: %v", i, id, err)
+			return fmt.Errorf("asset at index %d (%s): %w", i, id, err)
+		}
+		created = append(created, id)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	err = emitEvent(ctx, "AssetsBatchCreated", map[string]interface{}{
+		"assetIDs":  created,
+		"created":   len(created),
+		"failed":    0,
+		"createdBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Created batch of %d assets", len(created))
+	logln(ctx, "===== END: CreateAssetsBatch =====")
+	return nil
+}
+
+// BatchFailure records why one item of a batch was not applied
+type BatchFailure struct {
+	ID     string `json:"ID"`
+	Reason string `json:"Reason"`
+}
+
+// BatchReport lists the items of a best-effort batch that were and were not applied
+type BatchReport struct {
+	Created []string        `json:"Created"`
+	Failed  []*BatchFailure `json:"Failed"`
+}
+
+// BatchCreateAssetsBestEffort creates each asset of a JSON array independently
+// and reports per item whether it was created. Unlike an all-or-nothing batch a
+// failing item does not abort the transaction, so the successful creates are
+// committed. A single AssetsBatchCreated event replaces the per-asset events.
+func (s *AssetContract) BatchCreateAssetsBestEffort(ctx contractapi.TransactionContextInterface, assetsJSON string) (*BatchReport, error) {
+	logln(ctx, "===== START: BatchCreateAssetsBestEffort =====")
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(assetsJSON), &items); err != nil {
+		logf(ctx, "ERROR: Invalid batch: %v", err)
+		return nil, fmt.Errorf("assets must be a JSON array of assets: %w", err)
+	}
+	if len(items) > maxBatchSize {
+		logf(ctx, "ERROR: Batch of %d items exceeds the limit", len(items))
+		return nil, fmt.Errorf("a batch cannot contain more than %d assets", maxBatchSize)
+	}
+
+	// Writes of this transaction are not visible to its own reads, so IDs that
+	// repeat within the batch have to be caught here
+	seen := make(map[string]bool, len(items))
+	silent := withoutEvents(ctx)
+	report := &BatchReport{Created: []string{}, Failed: []*BatchFailure{}}
+	for i, raw := range items {
+		if err := validateAssetJSON(raw); err != nil {
+			// The ID is reported if there is one at all, to tell the failures apart
+			var probe struct{ ID string }
+			_ = json.Unmarshal(raw, &probe)
+			id := normalizeAssetID(probe.ID)
+			if id == "" {
+				id = fmt.Sprintf("#%d", i)
+			}
+			report.Failed = append(report.Failed, &BatchFailure{ID: id, Reason: err.Error()})
+			continue
+		}
+		var item Asset
+		if err := json.Unmarshal(raw, &item); err != nil {
+			report.Failed = append(report.Failed, &BatchFailure{ID: fmt.Sprintf("#%d", i), Reason: err.Error()})
+			continue
+		}
+
+		id := normalizeAssetID(item.ID)
+		if seen[id] {
+			report.Failed = append(report.Failed, &BatchFailure{ID: id, Reason: "duplicate ID within batch"})
+			continue
+		}
+		seen[id] = true
+
+		err := s.CreateAsset(silent, id, item.Color, item.Size, item.Owner, item.AppraisedValue)
+		if err != nil {
+			report.Failed = append(report.Failed, &BatchFailure{ID: id, Reason: err.Error()})
+			continue
+		}
+		report.Created = append(report.Created, id)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	err = emitEvent(ctx, "AssetsBatchCreated", map[string]interface{}{
+		"assetIDs":  report.Created,
+		"created":   len(report.Created),
+		"failed":    len(report.Failed),
+		"createdBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Created %d of %d assets, %d failed", len(report.Created), len(items), len(report.Failed))
+	logln(ctx, "===== END: BatchCreateAssetsBestEffort =====")
+	return report, nil
+}
+
+// TransferAssetsBatch transfers every asset of a JSON array of IDs to newOwner
+// in one transaction. Each transfer is checked like in TransferAsset; if any
+// asset is missing, already owned by newOwner or otherwise not transferable,
+// the whole batch fails and no ownership changes.
+func (s *AssetContract) TransferAssetsBatch(ctx contractapi.TransactionContextInterface, idsJSON string, newOwner string) error {
+	logf(ctx, "===== START: TransferAssetsBatch - New Owner: %s =====", newOwner)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := validateOwner(newOwner); err != nil {
+		logf(ctx, "ERROR: Invalid new owner: %v", err)
+		return err
+	}
+
+	var ids []string
+	if err := json.Unmarshal([]byte(idsJSON), &ids); err != nil {
+		logf(ctx, "ERROR: Invalid ID list: %v", err)
+		return fmt.Errorf("ids must be a JSON array of strings: %w", err)
+	}
+	if len(ids) == 0 {
+		logln(ctx, "ERROR: Empty batch")
+		return fmt.Errorf("a batch must contain at least one asset ID")
+	}
+	if len(ids) > maxBatchSize {
+		logf(ctx, "ERROR: Batch of %d items exceeds the limit", len(ids))
+		return fmt.Errorf("a batch cannot contain more than %d assets", maxBatchSize)
+	}
+
+	seen := make(map[string]bool, len(ids))
+	silent := withoutEvents(ctx)
+	transferred := make([]string, 0, len(ids))
+	oldOwners := make(map[string]string, len(ids))
+	for i, id := range ids {
+		id = normalizeAssetID(id)
+		if seen[id] {
+			logf(ctx, "ERROR: Duplicate ID %s at index %d", id, i)
+			return fmt.Errorf("asset at index %d (%s): duplicate ID within batch", i, id)
+		}
+		seen[id] = true
+
+		asset, err := s.ReadAsset(ctx, id)
+		if err != nil {
+			logf(ctx, "ERROR: Asset at index %d (%s) failed: %v", i, id, err)
+			return fmt.Errorf("asset at index %d (%s): %w", i, id, err)
+		}
+
+		// An error aborts the transaction, discarding the writes of earlier items
+		if err := s.transferAsset(silent, id, newOwner, false, noFloor); err != nil {
+			logf(ctx, "ERROR: Asset at index %d (%s) failed: %v", i, id, err)
+			return fmt.Errorf("asset at index %d (%s): %w", i, id, err)
+		}
+		transferred = append(transferred, id)
+		oldOwners[id] = asset.Owner
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	err = emitEvent(ctx, "AssetsBatchTransferred", map[string]interface{}{
+		"assetIDs":      transferred,
+		"oldOwners":     oldOwners,
+		"newOwner":      newOwner,
+		"transferredBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Transferred batch of %d assets to %s", len(transferred), newOwner)
+	logln(ctx, "===== END: TransferAssetsBatch =====")
+	return nil
+}
+
+// DeleteAssetsByOwner deletes the assets of owner in one transaction and
+// returns their IDs, with a single AssetsBulkDeleted event in place of the
+// per-asset events. Each deletion writes several keys, so to stay well within
+// the orderer's maximum block size at most maxBatchSize assets are deleted per
+// call; an owner with more assets is cleared by calling it until it reports
+// that no assets are left. Offboarding an owner is an admin operation, and a
+// locked asset fails the whole call.
+func (a *AdminContract) DeleteAssetsByOwner(ctx contractapi.TransactionContextInterface, owner string) ([]string, error) {
+	logf(ctx, "===== START: DeleteAssetsByOwner - Owner: %s =====", owner)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	assets, err := a.assets.QueryAssetsByOwner(ctx, owner)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+	if len(assets) == 0 {
+		logf(ctx, "ERROR: No assets found for owner %s", owner)
+		return nil, fmt.Errorf("no assets found for owner %s: %w", owner, ErrAssetNotFound)
+	}
+	remaining := 0
+	if len(assets) > maxBatchSize {
+		remaining = len(assets) - maxBatchSize
+		assets = assets[:maxBatchSize]
+		logf(ctx, "INFO: Owner %s has %d more assets than the batch limit", owner, remaining)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	deleted := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		// An error aborts the transaction, discarding the earlier deletions
+		if err := checkNotLocked(asset); err != nil {
+			logf(ctx, "ERROR: %v", err)
+			return nil, err
+		}
+		if err := removeAsset(ctx, asset, clientID, "delete"); err != nil {
+			logf(ctx, "ERROR: Asset %s failed: %v", asset.ID, err)
+			return nil, fmt.Errorf("asset %s: %w", asset.ID, err)
+		}
+		deleted = append(deleted, asset.ID)
+	}
+
+	err = emitEvent(ctx, "AssetsBulkDeleted", map[string]interface{}{
+		"owner":     owner,
+		"assetIDs":  deleted,
+		"count":     len(deleted),
+		"remaining": remaining,
+		"deletedBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Deleted %d assets of owner %s", len(deleted), owner)
+	logln(ctx, "===== END: DeleteAssetsByOwner =====")
+	return deleted, nil
+}
+
+// ReassignOwner transfers every asset of fromOwner to toOwner in one
+// transaction and returns how many were moved. Each transfer is checked like in
+// TransferAsset, so the caller must act for fromOwner or be an admin; if any
+// asset cannot be transferred, nothing is. A single OwnerReassigned event
+// replaces the per-asset events.
+func (s *AssetContract) ReassignOwner(ctx contractapi.TransactionContextInterface, fromOwner string, toOwner string) (int, error) {
+	logf(ctx, "===== START: ReassignOwner - From: %s, To: %s =====", fromOwner, toOwner)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+	if err := validateOwner(toOwner); err != nil {
+		logf(ctx, "ERROR: Invalid new owner: %v", err)
+		return 0, err
+	}
+	if fromOwner == toOwner {
+		logf(ctx, "ERROR: Cannot reassign assets of %s to the same owner", fromOwner)
+		return 0, fmt.Errorf("from and to owner must differ: %w", ErrInvalidInput)
+	}
+
+	assets, err := s.QueryAssetsByOwner(ctx, fromOwner)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+	if len(assets) == 0 {
+		logf(ctx, "ERROR: No assets found for owner %s", fromOwner)
+		return 0, fmt.Errorf("no assets found for owner %s: %w", fromOwner, ErrAssetNotFound)
+	}
+	if len(assets) > maxBatchSize {
+		logf(ctx, "ERROR: Owner %s has %d assets, more than the batch limit", fromOwner, len(assets))
+		return 0, fmt.Errorf("owner %s has %d assets, more than the %d that can be reassigned in one transaction; move them with TransferAssetsBatch", fromOwner, len(assets), maxBatchSize)
+	}
+
+	silent := withoutEvents(ctx)
+	moved := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		// An error aborts the transaction, discarding the earlier transfers
+		if err := s.transferAsset(silent, asset.ID, toOwner, false, noFloor); err != nil {
+			logf(ctx, "ERROR: Asset %s failed: %v", asset.ID, err)
+			return 0, fmt.Errorf("asset %s: %w", asset.ID, err)
+		}
+		moved = append(moved, asset.ID)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	err = emitEvent(ctx, "OwnerReassigned", map[string]interface{}{
+		"fromOwner":    fromOwner,
+		"toOwner":      toOwner,
+		"assetIDs":     moved,
+		"count":        len(moved),
+		"reassignedBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return 0, err
+	}
+
+	logf(ctx, "INFO: Reassigned %d assets from %s to %s", len(moved), fromOwner, toOwner)
+	logln(ctx, "===== END: ReassignOwner =====")
+	return len(moved), nil
+}
+
+// AssetLookup is the result of looking up one ID with ReadAssets. Asset is
+// only set when Found is true.
+type AssetLookup struct {
+	ID    string `json:"ID"`
+	Found bool   `json:"Found"`
+	Asset *Asset `json:"Asset,omitempty" metadata:",optional"`
+}
+
+// ReadAssets looks up every asset of a JSON array of IDs in one call and
+// returns one result per ID, in the given order. A missing ID does not fail
+// the call; it comes back with Found set to false.
+func (s *AssetContract) ReadAssets(ctx contractapi.TransactionContextInterface, idsJSON string) ([]*AssetLookup, error) {
+	logln(ctx, "===== START: ReadAssets =====")
+
+	var ids []string
+	if err := json.Unmarshal([]byte(idsJSON), &ids); err != nil {
+		logf(ctx, "ERROR: Invalid ID list: %v", err)
+		return nil, fmt.Errorf("ids must be a JSON array of strings: %v: %w", err, ErrInvalidInput)
+	}
+	if len(ids) > maxBatchSize {
+		logf(ctx, "ERROR: Lookup of %d items exceeds the limit", len(ids))
+		return nil, fmt.Errorf("cannot read more than %d assets at once: %w", maxBatchSize, ErrInvalidInput)
+	}
+
+	results := make([]*AssetLookup, 0, len(ids))
+	found := 0
+	for _, id := range ids {
+		id = normalizeAssetID(id)
+		asset, err := s.ReadAsset(ctx, id)
+		if errors.Is(err, ErrAssetNotFound) {
+			results = append(results, &AssetLookup{ID: id})
+			continue
+		}
+		if err != nil {
+			logf(ctx, "ERROR: Failed to read asset %s: %v", id, err)
+			return nil, err
+		}
+		results = append(results, &AssetLookup{ID: id, Found: true, Asset: asset})
+		found++
+	}
+
+	logf(ctx, "INFO: Found %d of %d assets", found, len(ids))
+	logln(ctx, "===== END: ReadAssets =====")
+	return results, nil
+}
+
+// RenameOwner changes the Owner of every asset of oldName to newName in one
+// transaction and returns how many assets were renamed. Unlike ReassignOwner
+// this records that an owner's name changed rather than a change of hands, so
+// the transfer rules do not apply and an OwnerRenamed event is emitted. Only
+// admins may rename, and not onto a name that already holds assets.
+func (a *AdminContract) RenameOwner(ctx contractapi.TransactionContextInterface, oldName string, newName string) (int, error) {
+	logf(ctx, "===== START: RenameOwner - Old: %s, New: %s =====", oldName, newName)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+	if err := validateOwner(oldName); err != nil {
+		logf(ctx, "ERROR: Invalid old name: %v", err)
+		return 0, err
+	}
+	if err := validateOwner(newName); err != nil {
+		logf(ctx, "ERROR: Invalid new name: %v", err)
+		return 0, err
+	}
+	if oldName == newName {
+		logf(ctx, "ERROR: Cannot rename %s to the same name", oldName)
+		return 0, fmt.Errorf("old and new name must differ: %w", ErrInvalidInput)
+	}
+
+	existing, err := a.assets.QueryAssetsByOwner(ctx, newName)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+	if len(existing) > 0 {
+		logf(ctx, "ERROR: Owner %s already holds %d assets", newName, len(existing))
+		return 0, fmt.Errorf("owner %s already holds assets, renaming onto it would merge two owners: %w", newName, ErrInvalidInput)
+	}
+
+	assets, err := a.assets.QueryAssetsByOwner(ctx, oldName)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+	if len(assets) == 0 {
+		logf(ctx, "ERROR: No assets found for owner %s", oldName)
+		return 0, fmt.Errorf("no assets found for owner %s: %w", oldName, ErrAssetNotFound)
+	}
+	if len(assets) > maxBatchSize {
+		logf(ctx, "ERROR: Owner %s has %d assets, more than the batch limit", oldName, len(assets))
+		return 0, fmt.Errorf("owner %s has %d assets, more than the %d that can be renamed in one transaction", oldName, len(assets), maxBatchSize)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+
+	renamed := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		asset.Owner = newName
+		stampUpdate(ctx, asset, now, clientID)
+
+		assetJSON, err := json.Marshal(asset)
+		if err != nil {
+			logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+			return 0, fmt.Errorf("failed to marshal asset: %w", err)
+		}
+		// An error aborts the transaction, discarding the earlier renames
+		if err := ctx.GetStub().PutState(asset.ID, assetJSON); err != nil {
+			logf(ctx, "ERROR: Failed to rename owner of asset %s: %v", asset.ID, err)
+			return 0, fmt.Errorf("failed to rename owner of asset %s: %w", asset.ID, err)
+		}
+		if err := moveOwnerIndex(ctx, oldName, newName, asset.ID); err != nil {
+			logf(ctx, "ERROR: %v", err)
+			return 0, err
+		}
+		if err := recordChange(ctx, asset.ID, "rename"); err != nil {
+			logf(ctx, "ERROR: %v", err)
+			return 0, err
+		}
+		renamed = append(renamed, asset.ID)
+	}
+
+	err = emitEvent(ctx, "OwnerRenamed", map[string]interface{}{
+		"oldName":   oldName,
+		"newName":   newName,
+		"assetIDs":  renamed,
+		"count":     len(renamed),
+		"renamedBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return 0, err
+	}
+
+	logf(ctx, "INFO: Renamed owner %s to %s on %d assets", oldName, newName, len(renamed))
+	logln(ctx, "===== END: RenameOwner =====")
+	return len(renamed), nil
+}
+
+// AdjustValueBySize scales the appraised value of every asset with a Size of
+// at least minSize by (100+percentDelta)/100, rounded to the nearest integer
+// and clamped to the configured value limits, and returns how many assets
+// changed value. Locked assets are left out. The adjustment is all or nothing:
+// if more than maxBatchSize assets would change, or one would fall below the
+// minimum of its category, no value changes. A single AssetValuesAdjusted
+// event replaces the per-asset events. Only admins may adjust values.
+func (a *AdminContract) AdjustValueBySize(ctx contractapi.TransactionContextInterface, minSize int, percentDelta int) (int, error) {
+	logf(ctx, "===== START: AdjustValueBySize - Min Size: %d, Delta: %d%% =====", minSize, percentDelta)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+	if percentDelta < -100 {
+		logf(ctx, "ERROR: Invalid percent delta %d", percentDelta)
+		return 0, fmt.Errorf("percent delta cannot be below -100: %w", ErrInvalidInput)
+	}
+
+	limits, err := getValidationLimits(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByRange("", "")
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get state by range: %v", err)
+		return 0, fmt.Errorf("failed to get state by range: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	adjusted := []*Asset{}
+	skipped := []string{}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate results: %v", err)
+			return 0, fmt.Errorf("failed to iterate results: %w", err)
+		}
+
+		var asset Asset
+		err = json.Unmarshal(queryResponse.Value, &asset)
+		if err != nil {
+			logf(ctx, "WARNING: Failed to unmarshal asset, skipping: %v", err)
+			continue
+		}
+		if asset.Size < minSize {
+			continue
+		}
+		if asset.Locked {
+			skipped = append(skipped, asset.ID)
+			continue
+		}
+
+		// Clamp before converting, as a float beyond the int range has no
+		// defined int value
+		scaled := math.Round(float64(asset.AppraisedValue) * (100 + float64(percentDelta)) / 100)
+		scaled = math.Min(scaled, float64(limits.MaxAppraisedValue))
+		scaled = math.Max(scaled, float64(limits.MinAppraisedValue))
+		value := int(scaled)
+		if value == asset.AppraisedValue {
+			continue
+		}
+		if err := validateCategoryMinimum(ctx, asset.Category, value); err != nil {
+			logf(ctx, "ERROR: Asset %s: %v", asset.ID, err)
+			return 0, fmt.Errorf("asset %s: %w", asset.ID, err)
+		}
+		if len(adjusted) == maxBatchSize {
+			logf(ctx, "ERROR: More than %d assets to adjust", maxBatchSize)
+			return 0, fmt.Errorf("more than %d assets would change value, too many for one transaction; raise minSize", maxBatchSize)
+		}
+		asset.AppraisedValue = value
+		adjusted = append(adjusted, &asset)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+
+	ids := make([]string, 0, len(adjusted))
+	for _, asset := range adjusted {
+		stampUpdate(ctx, asset, now, clientID)
+
+		assetJSON, err := json.Marshal(asset)
+		if err != nil {
+			logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+			return 0, fmt.Errorf("failed to marshal asset: %w", err)
+		}
+		if err := ctx.GetStub().PutState(asset.ID, assetJSON); err != nil {
+			logf(ctx, "ERROR: Failed to adjust value of asset %s: %v", asset.ID, err)
+			return 0, fmt.Errorf("failed to adjust value of asset %s: %w", asset.ID, err)
+		}
+		if err := recordChange(ctx, asset.ID, "valueAdjustment"); err != nil {
+			logf(ctx, "ERROR: %v", err)
+			return 0, err
+		}
+		ids = append(ids, asset.ID)
+	}
+
+	err = emitEvent(ctx, "AssetValuesAdjusted", map[string]interface{}{
+		"minSize":       minSize,
+		"percentDelta":  percentDelta,
+		"assetIDs":      ids,
+		"count":         len(ids),
+		"skippedLocked": skipped,
+		"adjustedBy":    clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return 0, err
+	}
+
+	logf(ctx, "INFO: Adjusted the value of %d assets, skipped %d locked", len(ids), len(skipped))
+	logln(ctx, "===== END: AdjustValueBySize =====")
+	return len(ids), nil
+}
@@ -0,0 +1,506 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test BatchCreateAssetsBestEffort with valid and invalid items
+func TestBatchCreateAssetsBestEffort(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(Asset{ID: "existing", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+
+	var report *BatchReport
+	stub, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+		report, err = contract.BatchCreateAssetsBestEffort(ctx, `[
+			{"ID": "asset1", "Color": "blue", "Size": 5, "Owner": "John", "AppraisedValue": 300},
+			{"ID": "asset2", "Color": "", "Size": 5, "Owner": "John", "AppraisedValue": 300},
+			{"ID": "existing", "Color": "red", "Size": 5, "Owner": "Jane", "AppraisedValue": 300},
+			{"ID": "asset3", "Color": "green", "Size": 10, "Owner": "Jane", "AppraisedValue": 500},
+			{"ID": " asset1", "Color": "white", "Size": 5, "Owner": "Max", "AppraisedValue": 300},
+			{"ID": "asset4", "Color": "black", "Size": -1, "Owner": "Max", "AppraisedValue": 300}
+		]`)
+		return err
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"asset1", "asset3"}, report.Created)
+	failed := map[string]string{}
+	for _, failure := range report.Failed {
+		failed[failure.ID] = failure.Reason
+	}
+	assert.Len(t, failed, 4)
+	assert.Contains(t, failed["asset2"], "color")
+	assert.Contains(t, failed["existing"], "already exists")
+	assert.Contains(t, failed["asset1"], "duplicate")
+	assert.Contains(t, failed["asset4"], "size")
+
+	// Successful items are committed while failed ones leave no trace
+	assert.Equal(t, "John", readCommitted(t, ledger, "asset1").Owner)
+	assert.Equal(t, "Jane", readCommitted(t, ledger, "asset3").Owner)
+	assert.Equal(t, "blue", readCommitted(t, ledger, "existing").Color)
+	assert.Nil(t, ledger.Get("asset2"))
+	assert.Nil(t, ledger.Get("asset4"))
+
+	require.Len(t, stub.Events, 1)
+	assert.Equal(t, "AssetsBatchCreated", stub.LastEvent().EventName)
+
+	t.Run("Invalid Batch", func(t *testing.T) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			_, err := contract.BatchCreateAssetsBestEffort(ctx, `{"ID": "asset5"}`)
+			return err
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Batch Too Large", func(t *testing.T) {
+		items := strings.Repeat(`{"ID": "x"},`, maxBatchSize)
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			_, err := contract.BatchCreateAssetsBestEffort(ctx, "["+items+`{"ID": "x"}]`)
+			return err
+		})
+		assert.Error(t, err)
+	})
+}
+
+// Test CreateAssetsBatch atomicity
+func TestCreateAssetsBatch(t *testing.T) {
+	contract := AssetContract{}
+
+	create := func(ledger *Ledger, assetsJSON string) (*LedgerStub, error) {
+		return ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.CreateAssetsBatch(ctx, assetsJSON)
+		})
+	}
+
+	t.Run("Valid Batch", func(t *testing.T) {
+		ledger := NewLedger()
+		stub, err := create(ledger, `[
+			{"ID": "asset1", "Color": "blue", "Size": 5, "Owner": "John", "AppraisedValue": 300},
+			{"ID": "asset2", "Color": "red", "Size": 10, "Owner": "Jane", "AppraisedValue": 400},
+			{"ID": "asset3", "Color": "green", "Size": 15, "Owner": "Max", "AppraisedValue": 500}
+		]`)
+		require.NoError(t, err)
+		for _, id := range []string{"asset1", "asset2", "asset3"} {
+			assert.Equal(t, id, readCommitted(t, ledger, id).ID)
+		}
+
+		require.Len(t, stub.Events, 1)
+		event := stub.LastEvent()
+		assert.Equal(t, "AssetsBatchCreated", event.EventName)
+		payload := eventData(t, event)
+		assert.Equal(t, []interface{}{"asset1", "asset2", "asset3"}, payload["assetIDs"])
+	})
+
+	t.Run("Third Entry Invalid", func(t *testing.T) {
+		ledger := NewLedger()
+		_, err := create(ledger, `[
+			{"ID": "asset1", "Color": "blue", "Size": 5, "Owner": "John", "AppraisedValue": 300},
+			{"ID": "asset2", "Color": "red", "Size": 10, "Owner": "Jane", "AppraisedValue": 400},
+			{"ID": "asset3", "Color": "green", "Size": 0, "Owner": "Max", "AppraisedValue": 500}
+		]`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "index 2 (asset3)")
+		assert.Nil(t, ledger.Get("asset1"))
+		assert.Nil(t, ledger.Get("asset2"))
+	})
+
+	t.Run("Existing And Duplicate IDs", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+
+		_, err := create(ledger, `[{"ID": "asset1", "Color": "red", "Size": 5, "Owner": "Jane", "AppraisedValue": 300}]`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+
+		_, err = create(ledger, `[
+			{"ID": "asset2", "Color": "red", "Size": 5, "Owner": "Jane", "AppraisedValue": 300},
+			{"ID": "asset2", "Color": "blue", "Size": 5, "Owner": "Jane", "AppraisedValue": 300}
+		]`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "index 1 (asset2)")
+		assert.Nil(t, ledger.Get("asset2"))
+	})
+
+	t.Run("Empty Batch", func(t *testing.T) {
+		_, err := create(NewLedger(), `[]`)
+		assert.Error(t, err)
+	})
+}
+
+// Test TransferAssetsBatch atomicity
+func TestTransferAssetsBatch(t *testing.T) {
+	contract := AssetContract{}
+
+	seed := func() *Ledger {
+		ledger := NewLedger()
+		ledger.Seed(
+			Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300},
+			Asset{ID: "asset2", Color: "red", Size: 10, Owner: "Jane", AppraisedValue: 400},
+			Asset{ID: "asset3", Color: "green", Size: 15, Owner: "Bob", AppraisedValue: 500},
+		)
+		return ledger
+	}
+	transfer := func(ledger *Ledger, idsJSON string, newOwner string) (*LedgerStub, error) {
+		// The assets have different owners, so only an admin may move them together
+		return ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return contract.TransferAssetsBatch(ctx, idsJSON, newOwner)
+		})
+	}
+
+	t.Run("All Transferred", func(t *testing.T) {
+		ledger := seed()
+		stub, err := transfer(ledger, `["asset1", "asset2"]`, "Max")
+		require.NoError(t, err)
+		assert.Equal(t, "Max", readCommitted(t, ledger, "asset1").Owner)
+		assert.Equal(t, "Max", readCommitted(t, ledger, "asset2").Owner)
+		assert.Equal(t, "Bob", readCommitted(t, ledger, "asset3").Owner)
+
+		require.Len(t, stub.Events, 1)
+		event := stub.LastEvent()
+		assert.Equal(t, "AssetsBatchTransferred", event.EventName)
+		payload := eventData(t, event)
+		assert.Equal(t, []interface{}{"asset1", "asset2"}, payload["assetIDs"])
+		assert.Equal(t, map[string]interface{}{"asset1": "John", "asset2": "Jane"}, payload["oldOwners"])
+		assert.Equal(t, "Max", payload["newOwner"])
+	})
+
+	t.Run("Missing Asset Aborts Batch", func(t *testing.T) {
+		ledger := seed()
+		_, err := transfer(ledger, `["asset1", "asset2", "missing"]`, "Max")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "index 2 (missing)")
+		assert.Equal(t, "John", readCommitted(t, ledger, "asset1").Owner)
+		assert.Equal(t, "Jane", readCommitted(t, ledger, "asset2").Owner)
+	})
+
+	t.Run("Already Owned Aborts Batch", func(t *testing.T) {
+		ledger := seed()
+		_, err := transfer(ledger, `["asset1", "asset3"]`, "Bob")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already owned")
+		assert.Equal(t, "John", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		ledger := seed()
+		_, err := transfer(ledger, `[]`, "Max")
+		assert.Error(t, err)
+		_, err = transfer(ledger, `["asset1", "asset1"]`, "Max")
+		assert.Error(t, err)
+		_, err = transfer(ledger, `["asset1"]`, "")
+		assert.Error(t, err)
+	})
+}
+
+// Test deleting every asset of an owner in one transaction
+func TestDeleteAssetsByOwner(t *testing.T) {
+	admin := AdminContract{}
+
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300},
+		Asset{ID: "asset2", Color: "red", Size: 10, Owner: "John", AppraisedValue: 400},
+		Asset{ID: "asset3", Color: "green", Size: 15, Owner: "Bob", AppraisedValue: 500},
+	)
+	deleteByOwner := func(owner string) ([]string, *LedgerStub, error) {
+		var deleted []string
+		stub, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) (err error) {
+			deleted, err = admin.DeleteAssetsByOwner(ctx, owner)
+			return err
+		})
+		return deleted, stub, err
+	}
+
+	t.Run("Requires Admin", func(t *testing.T) {
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			_, err := admin.DeleteAssetsByOwner(ctx, "John")
+			return err
+		})
+		require.Error(t, err)
+		assert.NotNil(t, ledger.Get("asset1"))
+	})
+
+	t.Run("Owner With Two Assets", func(t *testing.T) {
+		deleted, stub, err := deleteByOwner("John")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"asset1", "asset2"}, deleted)
+		assert.Nil(t, ledger.Get("asset1"))
+		assert.Nil(t, ledger.Get("asset2"))
+		assert.NotNil(t, ledger.Get("asset3"))
+
+		require.Len(t, stub.Events, 1)
+		event := stub.LastEvent()
+		assert.Equal(t, "AssetsBulkDeleted", event.EventName)
+		payload := eventData(t, event)
+		assert.Equal(t, float64(2), payload["count"])
+		assert.Equal(t, []interface{}{"asset1", "asset2"}, payload["assetIDs"])
+	})
+
+	t.Run("Owner With No Assets", func(t *testing.T) {
+		_, stub, err := deleteByOwner("John")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrAssetNotFound))
+		assert.Contains(t, err.Error(), "no assets found for owner John")
+		assert.Empty(t, stub.Events)
+		assert.NotNil(t, ledger.Get("asset3"))
+	})
+}
+
+// Test moving every asset of one owner to another
+func TestReassignOwner(t *testing.T) {
+	contract := AssetContract{}
+
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300},
+		Asset{ID: "asset2", Color: "red", Size: 10, Owner: "John", AppraisedValue: 400},
+		Asset{ID: "asset3", Color: "green", Size: 15, Owner: "Bob", AppraisedValue: 500},
+	)
+	reassign := func(identity *MockClientIdentity, fromOwner, toOwner string) (int, *LedgerStub, error) {
+		var moved int
+		stub, err := ledger.Invoke(identity, func(ctx *MockTransactionContext) (err error) {
+			moved, err = contract.ReassignOwner(ctx, fromOwner, toOwner)
+			return err
+		})
+		return moved, stub, err
+	}
+
+	t.Run("Same Owner Rejected", func(t *testing.T) {
+		_, _, err := reassign(ownerIdentity("John"), "John", "John")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+	})
+
+	t.Run("Only Owner Or Admin", func(t *testing.T) {
+		_, _, err := reassign(ownerIdentity("Bob"), "John", "Max")
+		require.Error(t, err)
+		assert.Equal(t, "John", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("All Assets Moved", func(t *testing.T) {
+		moved, stub, err := reassign(ownerIdentity("John"), "John", "Max")
+		require.NoError(t, err)
+		assert.Equal(t, 2, moved)
+
+		for _, id := range []string{"asset1", "asset2"} {
+			asset := readCommitted(t, ledger, id)
+			assert.Equal(t, "Max", asset.Owner)
+			assert.Equal(t, ownerIdentity("John").ID, asset.UpdatedBy)
+			assert.NotNil(t, ledger.Get(createCompositeKey(ownerIndexObjectType, []string{"Max", id})))
+		}
+		assert.Equal(t, "Bob", readCommitted(t, ledger, "asset3").Owner)
+
+		require.Len(t, stub.Events, 1)
+		event := stub.LastEvent()
+		assert.Equal(t, "OwnerReassigned", event.EventName)
+		payload := eventData(t, event)
+		assert.Equal(t, float64(2), payload["count"])
+		assert.Equal(t, "Max", payload["toOwner"])
+	})
+
+	t.Run("Owner Without Assets", func(t *testing.T) {
+		_, _, err := reassign(ownerIdentity("John"), "John", "Max")
+		assert.True(t, errors.Is(err, ErrAssetNotFound))
+	})
+}
+
+// Test looking up a mix of existing and missing IDs in one call
+func TestReadAssets(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300},
+		Asset{ID: "asset2", Color: "red", Size: 10, Owner: "Jane", AppraisedValue: 400},
+	)
+
+	read := func(idsJSON string) ([]*AssetLookup, error) {
+		var results []*AssetLookup
+		_, err := ledger.Invoke(defaultIdentity, func(ctx *MockTransactionContext) error {
+			var err error
+			results, err = contract.ReadAssets(ctx, idsJSON)
+			return err
+		})
+		return results, err
+	}
+
+	t.Run("Mixed IDs", func(t *testing.T) {
+		results, err := read(`["asset2", "missing", " asset1 "]`)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		assert.Equal(t, "asset2", results[0].ID)
+		assert.True(t, results[0].Found)
+		assert.Equal(t, "Jane", results[0].Asset.Owner)
+
+		assert.Equal(t, "missing", results[1].ID)
+		assert.False(t, results[1].Found)
+		assert.Nil(t, results[1].Asset)
+
+		assert.Equal(t, "asset1", results[2].ID)
+		assert.True(t, results[2].Found)
+		assert.Equal(t, 300, results[2].Asset.AppraisedValue)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := read(`asset1`)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+	})
+}
+
+// Test RenameOwner on an owner holding two assets
+func TestRenameOwner(t *testing.T) {
+	contract := AssetContract{}
+	admin := AdminContract{}
+	ledger := NewLedger()
+	for _, asset := range []Asset{
+		{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300},
+		{ID: "asset2", Color: "red", Size: 10, Owner: "John", AppraisedValue: 400},
+		{ID: "asset3", Color: "green", Size: 15, Owner: "Bob", AppraisedValue: 500},
+	} {
+		asset := asset
+		_, err := ledger.Invoke(defaultIdentity, func(ctx *MockTransactionContext) error {
+			return contract.CreateAsset(ctx, asset.ID, asset.Color, asset.Size, asset.Owner, asset.AppraisedValue)
+		})
+		require.NoError(t, err)
+	}
+
+	rename := func(identity *MockClientIdentity, oldName string, newName string) (int, *LedgerStub, error) {
+		var renamed int
+		stub, err := ledger.Invoke(identity, func(ctx *MockTransactionContext) (err error) {
+			renamed, err = admin.RenameOwner(ctx, oldName, newName)
+			return err
+		})
+		return renamed, stub, err
+	}
+
+	t.Run("Requires Admin", func(t *testing.T) {
+		_, _, err := rename(ownerIdentity("John"), "John", "Johnny")
+		require.Error(t, err)
+		assert.Equal(t, "John", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Onto Existing Owner", func(t *testing.T) {
+		_, _, err := rename(adminIdentity, "John", "Bob")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Equal(t, "John", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Invalid Name", func(t *testing.T) {
+		_, _, err := rename(adminIdentity, "John", "")
+		require.Error(t, err)
+	})
+
+	t.Run("Both Assets Renamed", func(t *testing.T) {
+		renamed, stub, err := rename(adminIdentity, "John", "Johnny")
+		require.NoError(t, err)
+		assert.Equal(t, 2, renamed)
+
+		for _, id := range []string{"asset1", "asset2"} {
+			asset := readCommitted(t, ledger, id)
+			assert.Equal(t, "Johnny", asset.Owner)
+			assert.Equal(t, 2, asset.Version)
+			assert.Equal(t, adminIdentity.ID, asset.UpdatedBy)
+			assert.NotNil(t, ledger.Get(createCompositeKey(ownerIndexObjectType, []string{"Johnny", id})))
+			assert.Nil(t, ledger.Get(createCompositeKey(ownerIndexObjectType, []string{"John", id})))
+		}
+		assert.Equal(t, "Bob", readCommitted(t, ledger, "asset3").Owner)
+
+		require.Len(t, stub.Events, 1)
+		event := stub.LastEvent()
+		assert.Equal(t, "OwnerRenamed", event.EventName)
+		payload := eventData(t, event)
+		assert.Equal(t, "John", payload["oldName"])
+		assert.Equal(t, "Johnny", payload["newName"])
+		assert.Equal(t, float64(2), payload["count"])
+	})
+
+	t.Run("Owner Without Assets", func(t *testing.T) {
+		_, _, err := rename(adminIdentity, "John", "Jack")
+		assert.True(t, errors.Is(err, ErrAssetNotFound))
+	})
+}
+
+// Test AdjustValueBySize with positive and negative deltas
+func TestAdjustValueBySize(t *testing.T) {
+	admin := AdminContract{}
+
+	setup := func() *Ledger {
+		ledger := NewLedger()
+		ledger.Seed(
+			Asset{ID: "small", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 1000},
+			Asset{ID: "large", Color: "red", Size: 50, Owner: "John", AppraisedValue: 1005},
+			Asset{ID: "huge", Color: "green", Size: 500, Owner: "Jane", AppraisedValue: maxAppraisedValue - 10},
+			Asset{ID: "locked", Color: "white", Size: 500, Owner: "Jane", AppraisedValue: 1000, Locked: true},
+		)
+		return ledger
+	}
+	adjust := func(ledger *Ledger, identity *MockClientIdentity, minSize int, percentDelta int) (int, *LedgerStub, error) {
+		var count int
+		stub, err := ledger.Invoke(identity, func(ctx *MockTransactionContext) (err error) {
+			count, err = admin.AdjustValueBySize(ctx, minSize, percentDelta)
+			return err
+		})
+		return count, stub, err
+	}
+
+	t.Run("Positive Delta Clamped", func(t *testing.T) {
+		ledger := setup()
+		count, stub, err := adjust(ledger, adminIdentity, 10, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		assert.Equal(t, 1000, readCommitted(t, ledger, "small").AppraisedValue)
+		assert.Equal(t, 1106, readCommitted(t, ledger, "large").AppraisedValue)
+		assert.Equal(t, maxAppraisedValue, readCommitted(t, ledger, "huge").AppraisedValue)
+		assert.Equal(t, 1000, readCommitted(t, ledger, "locked").AppraisedValue)
+
+		require.Len(t, stub.Events, 1)
+		event := stub.LastEvent()
+		assert.Equal(t, "AssetValuesAdjusted", event.EventName)
+		payload := eventData(t, event)
+		assert.Equal(t, float64(2), payload["count"])
+		assert.Equal(t, []interface{}{"locked"}, payload["skippedLocked"])
+	})
+
+	t.Run("Negative Delta", func(t *testing.T) {
+		ledger := setup()
+		count, _, err := adjust(ledger, adminIdentity, 1, -50)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		assert.Equal(t, 500, readCommitted(t, ledger, "small").AppraisedValue)
+		assert.Equal(t, 503, readCommitted(t, ledger, "large").AppraisedValue)
+		assert.Equal(t, (maxAppraisedValue-10)/2, readCommitted(t, ledger, "huge").AppraisedValue)
+	})
+
+	t.Run("Requires Admin", func(t *testing.T) {
+		ledger := setup()
+		_, _, err := adjust(ledger, ownerIdentity("John"), 1, 10)
+		require.Error(t, err)
+		assert.Equal(t, 1000, readCommitted(t, ledger, "small").AppraisedValue)
+	})
+
+	t.Run("Huge Delta Clamped To Maximum", func(t *testing.T) {
+		ledger := setup()
+		count, _, err := adjust(ledger, adminIdentity, 1, math.MaxInt)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		assert.Equal(t, maxAppraisedValue, readCommitted(t, ledger, "small").AppraisedValue)
+		assert.Equal(t, maxAppraisedValue, readCommitted(t, ledger, "large").AppraisedValue)
+		assert.Equal(t, maxAppraisedValue, readCommitted(t, ledger, "huge").AppraisedValue)
+	})
+
+	t.Run("Delta Below -100", func(t *testing.T) {
+		_, _, err := adjust(setup(), adminIdentity, 1, -101)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+	})
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// validateCategoryMinimum rejects an appraised value below the minimum an admin
+// set for the category with SetCategoryMinimum. Categories without a minimum
+// accept any value, and a value of 0 is always accepted as "pending appraisal"
+// so that an asset can be categorized before it is appraised.
+func validateCategoryMinimum(ctx contractapi.TransactionContextInterface, category string, appraisedValue int) error {
+	config, err := getConfig(ctx)
+	if err != nil {
+		return err
+	}
+	minimum, ok := config.CategoryMinimumValues[category]
+	if !ok || appraisedValue == 0 {
+		return nil
+	}
+	if appraisedValue < minimum {
+		return fmt.Errorf("appraised value %d is below the minimum of %d for category %s: %w", appraisedValue, minimum, category, ErrInvalidInput)
+	}
+	return nil
+}
+
+func validateCategory(category string) error {
+	if len(category) > 32 {
+		return fmt.Errorf("category cannot exceed 32 characters")
+	}
+	return nil
+}
+
+// SetAssetCategory assigns a category to an asset. An empty category removes it.
+// The asset's current appraised value must satisfy the category minimum.
+func (s *AssetContract) SetAssetCategory(ctx contractapi.TransactionContextInterface, id string, category string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: SetAssetCategory - ID: %s, Category: %s =====", id, category)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+	if err := validateCategory(category); err != nil {
+		logf(ctx, "ERROR: Invalid category: %v", err)
+		return err
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if !clientActsAs(ctx, asset.Owner) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not change the category of asset %s owned by %s", id, asset.Owner)
+			return fmt.Errorf("only the owner or an admin may change the category of asset %s: %w", id, ErrNotOwner)
+		}
+	}
+	if err := checkNotLocked(asset); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := validateCategoryMinimum(ctx, category, asset.AppraisedValue); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	oldCategory := asset.Category
+	asset.Category = category
+	stampUpdate(ctx, asset, now, clientID)
+
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+		return fmt.Errorf("failed to marshal asset: %w", err)
+	}
+
+	err = ctx.GetStub().PutState(id, assetJSON)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to update asset category: %v", err)
+		return fmt.Errorf("failed to update asset category: %w", err)
+	}
+	if err := recordChange(ctx, id, "categoryChange"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "AssetCategoryChanged", map[string]interface{}{
+		"assetID":     id,
+		"oldCategory": oldCategory,
+		"newCategory": category,
+		"updatedBy":   clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Category of asset %s changed from %q to %q", id, oldCategory, category)
+	logln(ctx, "===== END: SetAssetCategory =====")
+	return nil
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setCategoryMinimum sets a category minimum on the ledger as an admin
+func setCategoryMinimum(t *testing.T, ledger *Ledger, category string, minimum int) {
+	admin := AdminContract{}
+	_, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+		return admin.SetCategoryMinimum(ctx, category, minimum)
+	})
+	require.NoError(t, err)
+}
+
+func TestValidateCategoryMinimum(t *testing.T) {
+	ledger := NewLedger()
+	setCategoryMinimum(t, ledger, "real-estate", 10000)
+
+	tests := []struct {
+		name     string
+		category string
+		value    int
+		wantErr  bool
+	}{
+		{"At Minimum", "real-estate", 10000, false},
+		{"Above Minimum", "real-estate", 25000, false},
+		{"Below Minimum", "real-estate", 500, true},
+		{"Pending Appraisal", "real-estate", 0, false},
+		{"Category Without Minimum", "vehicle", 1, false},
+		{"Uncategorized", "", 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+				return validateCategoryMinimum(ctx, tt.category, tt.value)
+			})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidInput)
+				assert.Contains(t, err.Error(), "real-estate")
+				assert.Contains(t, err.Error(), "10000")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// Test category minimums enforced through the contract
+func TestCategoryMinimumEnforcement(t *testing.T) {
+	contract := AssetContract{}
+
+	newLedger := func() *Ledger {
+		ledger := NewLedger()
+		setCategoryMinimum(t, ledger, "real-estate", 10000)
+		ledger.Seed(
+			Asset{ID: "house1", Color: "white", Size: 100, Owner: "John", AppraisedValue: 50000, Category: "real-estate"},
+			Asset{ID: "shed1", Color: "brown", Size: 5, Owner: "John", AppraisedValue: 300},
+		)
+		return ledger
+	}
+
+	t.Run("Update Above Minimum", func(t *testing.T) {
+		ledger := newLedger()
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "house1", "white", 100, "John", 12000)
+		})
+		require.NoError(t, err)
+		asset := readCommitted(t, ledger, "house1")
+		assert.Equal(t, 12000, asset.AppraisedValue)
+		assert.Equal(t, "real-estate", asset.Category)
+	})
+
+	t.Run("Update Below Minimum", func(t *testing.T) {
+		ledger := newLedger()
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "house1", "white", 100, "John", 100)
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "minimum of 10000 for category real-estate")
+	})
+
+	t.Run("Categorize Below Minimum", func(t *testing.T) {
+		ledger := newLedger()
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SetAssetCategory(ctx, "shed1", "real-estate")
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Categorize Without Minimum", func(t *testing.T) {
+		ledger := newLedger()
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SetAssetCategory(ctx, "shed1", "outbuilding")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "outbuilding", readCommitted(t, ledger, "shed1").Category)
+	})
+
+	t.Run("Categorize Requires Owner Or Admin", func(t *testing.T) {
+		ledger := newLedger()
+		_, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.SetAssetCategory(ctx, "shed1", "outbuilding")
+		})
+		assert.ErrorIs(t, err, ErrNotOwner)
+
+		_, err = ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return contract.SetAssetCategory(ctx, "shed1", "outbuilding")
+		})
+		require.NoError(t, err)
+	})
+}
+
+// Test SetCategoryMinimum
+func TestSetCategoryMinimum(t *testing.T) {
+	admin := AdminContract{}
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(Asset{ID: "plot1", Color: "green", Size: 5, Owner: "John", AppraisedValue: 0})
+
+	set := func(identity *MockClientIdentity, category string, minimum int) error {
+		_, err := ledger.Invoke(identity, func(ctx *MockTransactionContext) error {
+			return admin.SetCategoryMinimum(ctx, category, minimum)
+		})
+		return err
+	}
+	categorize := func(id string, category string) error {
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SetAssetCategory(ctx, id, category)
+		})
+		return err
+	}
+
+	t.Run("Admin Only", func(t *testing.T) {
+		assert.Error(t, set(ownerIdentity("John"), "real-estate", 10000))
+	})
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		assert.ErrorIs(t, set(adminIdentity, "", 10000), ErrInvalidInput)
+		assert.ErrorIs(t, set(adminIdentity, "real-estate", -1), ErrInvalidInput)
+	})
+
+	t.Run("Pending Appraisal Accepted", func(t *testing.T) {
+		require.NoError(t, set(adminIdentity, "real-estate", 10000))
+		require.NoError(t, categorize("plot1", "real-estate"))
+		assert.Equal(t, "real-estate", readCommitted(t, ledger, "plot1").Category)
+	})
+
+	t.Run("Zero Removes Minimum", func(t *testing.T) {
+		ledger.Seed(Asset{ID: "plot2", Color: "green", Size: 5, Owner: "John", AppraisedValue: 10})
+		assert.ErrorIs(t, categorize("plot2", "real-estate"), ErrInvalidInput)
+
+		require.NoError(t, set(adminIdentity, "real-estate", 0))
+		require.NoError(t, categorize("plot2", "real-estate"))
+	})
+}
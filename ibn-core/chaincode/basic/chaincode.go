@@ -2,29 +2,66 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
 
-// SmartContract provides functions for managing an Asset
-type SmartContract struct {
+// AssetContract provides functions for managing an Asset
+type AssetContract struct {
 	contractapi.Contract
 }
 
+// GetName returns the namespace of the asset contract. As the first contract
+// registered it is also the default, so its functions need no prefix.
+func (s *AssetContract) GetName() string {
+	return "asset"
+}
+
+// GetIgnoredFunctions lists the exported methods that are meant for other Go
+// code only and must not be registered as transactions. The contract API
+// cannot route methods with three return values, such as TryReadAsset.
+func (s *AssetContract) GetIgnoredFunctions() []string {
+	return []string{"TryReadAsset"}
+}
+
 // Asset describes basic details of what makes up a simple asset
 type Asset struct {
-	ID             string    `json:"ID"`
-	Color          string    `json:"Color"`
-	Size           int       `json:"Size"`
-	Owner          string    `json:"Owner"`
-	AppraisedValue int       `json:"AppraisedValue"`
-	CreatedAt      time.Time `json:"CreatedAt"`
-	UpdatedAt      time.Time `json:"UpdatedAt"`
-	CreatedBy      string    `json:"CreatedBy"`
-	UpdatedBy      string    `json:"UpdatedBy"`
+	ID                 string            `json:"ID"`
+	Color              string            `json:"Color"`
+	Size               int               `json:"Size"`
+	Owner              string            `json:"Owner"`
+	AppraisedValue     int               `json:"AppraisedValue"`
+	CreatedAt          time.Time         `json:"CreatedAt"`
+	UpdatedAt          time.Time         `json:"UpdatedAt"`
+	CreatedBy          string            `json:"CreatedBy"`
+	UpdatedBy          string            `json:"UpdatedBy"`
+	UpdatedByMSP       string            `json:"UpdatedByMSP"`
+	Version            int               `json:"Version"`
+	Deleted            bool              `json:"Deleted"`
+	DeletedAt          time.Time         `json:"DeletedAt"`
+	ParentID           string            `json:"ParentID"`
+	Category           string            `json:"Category"`
+	Locked             bool              `json:"Locked"`
+	LockReason         string            `json:"LockReason"`
+	LockedBy           string            `json:"LockedBy"`
+	EscrowOwner        string            `json:"EscrowOwner"`
+	EscrowDeadline     time.Time         `json:"EscrowDeadline"`
+	PendingOwner       string            `json:"PendingOwner"`
+	TransferProposedAt time.Time         `json:"TransferProposedAt"`
+	ExpiresAt          time.Time         `json:"ExpiresAt"`
+	CreatorOrg         string            `json:"CreatorOrg"`
+	Owners             []string          `json:"Owners,omitempty" metadata:",optional"`
+	AllowedRecipients  []string          `json:"AllowedRecipients,omitempty" metadata:",optional"`
+	Metadata           map[string]string `json:"Metadata,omitempty" metadata:",optional"`
+	Tags               []string          `json:"Tags,omitempty" metadata:",optional"`
+	AuditNotes         []AuditNote       `json:"AuditNotes,omitempty" metadata:",optional"`
+	MergedFrom         []string          `json:"MergedFrom,omitempty" metadata:",optional"`
 }
 
 // AssetHistory represents historical changes to an asset
@@ -36,17 +73,26 @@ type AssetHistory struct {
 }
 
 // InitLedger adds a base set of assets to the ledger
-func (s *SmartContract) InitLedger(ctx contractapi.TransactionContextInterface) error {
-	log.Println("===== START: InitLedger =====")
+func (s *AssetContract) InitLedger(ctx contractapi.TransactionContextInterface) error {
+	logln(ctx, "===== START: InitLedger =====")
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
 	
 	// Get client identity for tracking
 	clientID, err := ctx.GetClientIdentity().GetID()
 	if err != nil {
-		log.Printf("WARNING: Could not get client identity: %v", err)
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
 		clientID = "system"
 	}
 
-	now := time.Now()
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
 	assets := []Asset{
 		{ID: "asset1", Color: "blue", Size: 5, Owner: "Tomoko", AppraisedValue: 300, CreatedAt: now, UpdatedAt: now, CreatedBy: clientID, UpdatedBy: clientID},
 		{ID: "asset2", Color: "red", Size: 5, Owner: "Brad", AppraisedValue: 400, CreatedAt: now, UpdatedAt: now, CreatedBy: clientID, UpdatedBy: clientID},
@@ -57,66 +103,112 @@ func (s *SmartContract) InitLedger(ctx contractapi.TransactionContextInterface)
 	}
 
 	for _, asset := range assets {
+		asset.Version = 1
 		assetJSON, err := json.Marshal(asset)
 		if err != nil {
-			log.Printf("ERROR: Failed to marshal asset %s: %v", asset.ID, err)
-			return fmt.Errorf("failed to marshal asset %s: %v", asset.ID, err)
+			logf(ctx, "ERROR: Failed to marshal asset %s: %v", asset.ID, err)
+			return fmt.Errorf("failed to marshal asset %s: %w", asset.ID, err)
 		}
 
 		err = ctx.GetStub().PutState(asset.ID, assetJSON)
 		if err != nil {
-			log.Printf("ERROR: Failed to put asset %s to world state: %v", asset.ID, err)
-			return fmt.Errorf("failed to put asset %s to world state: %v", asset.ID, err)
+			logf(ctx, "ERROR: Failed to put asset %s to world state: %v", asset.ID, err)
+			return fmt.Errorf("failed to put asset %s to world state: %w", asset.ID, err)
+		}
+		if err := putOwnerIndex(ctx, asset.Owner, asset.ID); err != nil {
+			logf(ctx, "ERROR: %v", err)
+			return err
+		}
+		if err := recordChange(ctx, asset.ID, "create"); err != nil {
+			logf(ctx, "ERROR: %v", err)
+			return err
 		}
 
 		// Emit event for asset creation
-		eventPayload, _ := json.Marshal(map[string]interface{}{
-			"type":   "AssetCreated",
+		emitEvent(ctx, "AssetCreated", map[string]interface{}{
 			"assetID": asset.ID,
-			"owner":  asset.Owner,
+			"owner":   asset.Owner,
 		})
-		ctx.GetStub().SetEvent("AssetCreated", eventPayload)
 		
-		log.Printf("INFO: Initialized asset %s", asset.ID)
+		logf(ctx, "INFO: Initialized asset %s", asset.ID)
 	}
 
-	log.Println("===== END: InitLedger =====")
+	logln(ctx, "===== END: InitLedger =====")
 	return nil
 }
 
 // CreateAsset issues a new asset to the world state with given details.
-func (s *SmartContract) CreateAsset(ctx contractapi.TransactionContextInterface, id string, color string, size int, owner string, appraisedValue int) error {
-	log.Printf("===== START: CreateAsset - ID: %s =====", id)
+func (s *AssetContract) CreateAsset(ctx contractapi.TransactionContextInterface, id string, color string, size int, owner string, appraisedValue int) error {
+	return s.createAsset(ctx, id, color, size, owner, appraisedValue, time.Time{}, false)
+}
+
+// createAsset implements CreateAsset. A zero expiresAt creates an asset that
+// never expires. With privateValue the appraised value is validated like any
+// other but kept out of the public record and the event, for
+// createAssetPrivate which stores it in the appraisal collection.
+func (s *AssetContract) createAsset(ctx contractapi.TransactionContextInterface, id string, color string, size int, owner string, appraisedValue int, expiresAt time.Time, privateValue bool) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: CreateAsset - ID: %s =====", id)
+
+	if err := requireAttribute(ctx, writerAttribute, "true"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	limits, err := getValidationLimits(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
 
 	// Validate inputs
-	if err := validateAssetID(id); err != nil {
-		log.Printf("ERROR: Invalid asset ID: %v", err)
+	if err := validateNewAssetID(limits, id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
 		return err
 	}
-	if err := validateAssetData(color, size, owner, appraisedValue); err != nil {
-		log.Printf("ERROR: Invalid asset data: %v", err)
+	if err := validateAssetData(limits, color, size, owner, appraisedValue); err != nil {
+		logf(ctx, "ERROR: Invalid asset data: %v", err)
 		return err
 	}
 
 	// Check if asset already exists
 	exists, err := s.AssetExists(ctx, id)
 	if err != nil {
-		log.Printf("ERROR: Failed to check asset existence: %v", err)
-		return fmt.Errorf("failed to check asset existence: %v", err)
+		logf(ctx, "ERROR: Failed to check asset existence: %v", err)
+		return fmt.Errorf("failed to check asset existence: %w", err)
 	}
 	if exists {
-		log.Printf("ERROR: Asset %s already exists", id)
-		return fmt.Errorf("the asset %s already exists", id)
+		logf(ctx, "ERROR: Asset %s already exists", id)
+		return fmt.Errorf("the asset %s already exists: %w", id, ErrAssetExists)
 	}
 
 	// Get client identity
 	clientID, err := ctx.GetClientIdentity().GetID()
 	if err != nil {
-		log.Printf("WARNING: Could not get client identity: %v", err)
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
 		clientID = "unknown"
 	}
 
-	now := time.Now()
+	mspID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client MSP ID: %v", err)
+		mspID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if privateValue {
+		appraisedValue = 0
+	}
 	asset := Asset{
 		ID:             id,
 		Color:          color,
@@ -127,47 +219,141 @@ func (s *SmartContract) CreateAsset(ctx contractapi.TransactionContextInterface,
 		UpdatedAt:      now,
 		CreatedBy:      clientID,
 		UpdatedBy:      clientID,
+		UpdatedByMSP:   mspID,
+		CreatorOrg:     mspID,
+		ExpiresAt:      expiresAt,
+		Version:        1,
 	}
 
 	assetJSON, err := json.Marshal(asset)
 	if err != nil {
-		log.Printf("ERROR: Failed to marshal asset: %v", err)
-		return fmt.Errorf("failed to marshal asset: %v", err)
+		logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+		return fmt.Errorf("failed to marshal asset: %w", err)
 	}
 
 	err = ctx.GetStub().PutState(id, assetJSON)
 	if err != nil {
-		log.Printf("ERROR: Failed to put asset to world state: %v", err)
-		return fmt.Errorf("failed to put asset to world state: %v", err)
+		logf(ctx, "ERROR: Failed to put asset to world state: %v", err)
+		return fmt.Errorf("failed to put asset to world state: %w", err)
+	}
+	if err := putOwnerIndex(ctx, owner, id); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := recordChange(ctx, id, "create"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
 	}
 
 	// Emit event
-	eventPayload, _ := json.Marshal(map[string]interface{}{
-		"type":           "AssetCreated",
+	eventData := map[string]interface{}{
 		"assetID":        id,
 		"owner":          owner,
 		"appraisedValue": appraisedValue,
 		"createdBy":      clientID,
-		"timestamp":      now.Unix(),
-	})
-	err = ctx.GetStub().SetEvent("AssetCreated", eventPayload)
+	}
+	if !expiresAt.IsZero() {
+		eventData["expiresAt"] = expiresAt.Unix()
+	}
+	err = emitEvent(ctx, "AssetCreated", eventData)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Successfully created asset %s", id)
+	logf(ctx, "===== END: CreateAsset =====")
+	return nil
+}
+
+// CreateAssetIdempotent creates an asset like CreateAsset, but succeeds without
+// writing or emitting an event when the asset already exists with exactly the
+// given values, so that clients can safely retry a create. An existing asset
+// with different values is still an ErrAssetExists error.
+func (s *AssetContract) CreateAssetIdempotent(ctx contractapi.TransactionContextInterface, id string, color string, size int, owner string, appraisedValue int) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: CreateAssetIdempotent - ID: %s =====", id)
+
+	if err := requireAttribute(ctx, writerAttribute, "true"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+
+	existing, err := s.ReadAsset(ctx, id)
+	if err == nil {
+		if existing.Color != color || existing.Size != size || existing.Owner != owner || existing.AppraisedValue != appraisedValue {
+			logf(ctx, "ERROR: Asset %s already exists with different values", id)
+			return fmt.Errorf("the asset %s already exists with different values: %w", id, ErrAssetExists)
+		}
+		logf(ctx, "INFO: Asset %s already exists with identical values, nothing to do", id)
+		logln(ctx, "===== END: CreateAssetIdempotent =====")
+		return nil
+	}
+	if !errors.Is(err, ErrAssetNotFound) {
+		logf(ctx, "ERROR: Failed to read asset %s: %v", id, err)
+		return err
+	}
+
+	if err := s.CreateAsset(ctx, id, color, size, owner, appraisedValue); err != nil {
+		return err
+	}
+
+	logln(ctx, "===== END: CreateAssetIdempotent =====")
+	return nil
+}
+
+// UpsertAsset creates the asset if it does not exist yet and updates it
+// otherwise, so that sync jobs need not check for existence first. The create
+// path emits AssetCreated and the update path keeps the creation metadata and
+// emits AssetUpdated; both validate the data as CreateAsset and UpdateAsset do.
+func (s *AssetContract) UpsertAsset(ctx contractapi.TransactionContextInterface, id string, color string, size int, owner string, appraisedValue int) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: UpsertAsset - ID: %s =====", id)
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+
+	exists, err := s.AssetExists(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to check asset existence: %v", err)
+		return fmt.Errorf("failed to check asset existence: %w", err)
+	}
+
+	if exists {
+		err = s.UpdateAsset(ctx, id, color, size, owner, appraisedValue)
+	} else {
+		err = s.CreateAsset(ctx, id, color, size, owner, appraisedValue)
+	}
 	if err != nil {
-		log.Printf("WARNING: Failed to emit event: %v", err)
+		return err
 	}
 
-	log.Printf("INFO: Successfully created asset %s", id)
-	log.Printf("===== END: CreateAsset =====")
+	logf(ctx, "INFO: Upserted asset %s, existed before: %t", id, exists)
+	logln(ctx, "===== END: UpsertAsset =====")
 	return nil
 }
 
 // ReadAsset returns the asset stored in the world state with given id.
-func (s *SmartContract) ReadAsset(ctx contractapi.TransactionContextInterface, id string) (*Asset, error) {
+func (s *AssetContract) ReadAsset(ctx contractapi.TransactionContextInterface, id string) (*Asset, error) {
+	id = normalizeAssetID(id)
 	assetJSON, err := ctx.GetStub().GetState(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read from world state: %v", err)
+		return nil, fmt.Errorf("failed to read from world state: %w", err)
 	}
 	if assetJSON == nil {
-		return nil, fmt.Errorf("the asset %s does not exist", id)
+		return nil, fmt.Errorf("the asset %s does not exist: %w", id, ErrAssetNotFound)
 	}
 
 	var asset Asset
@@ -179,157 +365,390 @@ func (s *SmartContract) ReadAsset(ctx contractapi.TransactionContextInterface, i
 	return &asset, nil
 }
 
+// TryReadAsset reads an asset like ReadAsset, but reports a missing asset
+// through the bool instead of an error, so that callers can tell "not found"
+// apart from a failing ledger read. It is a Go-level helper for the gateway
+// and other contracts, not a transaction; see GetIgnoredFunctions.
+func (s *AssetContract) TryReadAsset(ctx contractapi.TransactionContextInterface, id string) (*Asset, bool, error) {
+	asset, err := s.ReadAsset(ctx, id)
+	if errors.Is(err, ErrAssetNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return asset, true, nil
+}
+
+// anyVersion disables the version check of updateAsset
+const anyVersion = -1
+
 // UpdateAsset updates an existing asset in the world state with provided parameters.
-func (s *SmartContract) UpdateAsset(ctx contractapi.TransactionContextInterface, id string, color string, size int, owner string, appraisedValue int) error {
-	log.Printf("===== START: UpdateAsset - ID: %s =====", id)
+// owner must be the current owner; ownership changes go through TransferAsset.
+func (s *AssetContract) UpdateAsset(ctx contractapi.TransactionContextInterface, id string, color string, size int, owner string, appraisedValue int) error {
+	return s.updateAsset(ctx, id, color, size, owner, appraisedValue, anyVersion)
+}
+
+// UpdateAssetWithVersion updates an asset like UpdateAsset, but only if its
+// stored Version still equals expectedVersion. This gives concurrent editors
+// compare-and-set semantics: an editor working from a stale read gets a
+// version conflict instead of overwriting the newer state.
+func (s *AssetContract) UpdateAssetWithVersion(ctx contractapi.TransactionContextInterface, id string, color string, size int, owner string, appraisedValue int, expectedVersion int) error {
+	if expectedVersion < 0 {
+		logf(ctx, "ERROR: Invalid expected version %d", expectedVersion)
+		return fmt.Errorf("expected version cannot be negative")
+	}
+	return s.updateAsset(ctx, id, color, size, owner, appraisedValue, expectedVersion)
+}
+
+// assetFieldUpdates lists the fields UpdateAssetFields may change; nil
+// fields keep their stored value
+type assetFieldUpdates struct {
+	Color          *string `json:"Color"`
+	Size           *int    `json:"Size"`
+	AppraisedValue *int    `json:"AppraisedValue"`
+}
+
+// UpdateAssetFields changes only the fields present in updatesJSON, a JSON
+// object with any of Color, Size and AppraisedValue. Other keys, including
+// Owner, are rejected; ownership changes go through TransferAsset.
+func (s *AssetContract) UpdateAssetFields(ctx contractapi.TransactionContextInterface, id string, updatesJSON string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: UpdateAssetFields - ID: %s =====", id)
+
+	var updates assetFieldUpdates
+	decoder := json.NewDecoder(strings.NewReader(updatesJSON))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&updates); err != nil {
+		logf(ctx, "ERROR: Invalid updates: %v", err)
+		return fmt.Errorf("updates must be a JSON object of Color, Size and AppraisedValue: %w", err)
+	}
+	if updates.Color == nil && updates.Size == nil && updates.AppraisedValue == nil {
+		logln(ctx, "ERROR: No fields to update")
+		return fmt.Errorf("updates must change at least one field")
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if updates.Color != nil {
+		asset.Color = *updates.Color
+	}
+	if updates.Size != nil {
+		asset.Size = *updates.Size
+	}
+	if updates.AppraisedValue != nil {
+		asset.AppraisedValue = *updates.AppraisedValue
+	}
+
+	// Validation, the write and the event are shared with UpdateAsset
+	if err := s.updateAsset(ctx, id, asset.Color, asset.Size, asset.Owner, asset.AppraisedValue, anyVersion); err != nil {
+		return err
+	}
+
+	logln(ctx, "===== END: UpdateAssetFields =====")
+	return nil
+}
+
+func (s *AssetContract) updateAsset(ctx contractapi.TransactionContextInterface, id string, color string, size int, owner string, appraisedValue int, expectedVersion int) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: UpdateAsset - ID: %s, Expected Version: %d =====", id, expectedVersion)
+
+	if err := requireAttribute(ctx, writerAttribute, "true"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	limits, err := getValidationLimits(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
 
 	// Validate inputs
 	if err := validateAssetID(id); err != nil {
-		log.Printf("ERROR: Invalid asset ID: %v", err)
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
 		return err
 	}
-	if err := validateAssetData(color, size, owner, appraisedValue); err != nil {
-		log.Printf("ERROR: Invalid asset data: %v", err)
+	if err := validateAssetData(limits, color, size, owner, appraisedValue); err != nil {
+		logf(ctx, "ERROR: Invalid asset data: %v", err)
 		return err
 	}
 
 	// Check if asset exists
 	oldAsset, err := s.ReadAsset(ctx, id)
 	if err != nil {
-		log.Printf("ERROR: Asset %s does not exist: %v", id, err)
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if owner != oldAsset.Owner {
+		logf(ctx, "ERROR: Update may not change the owner of asset %s from %s to %s", id, oldAsset.Owner, owner)
+		return fmt.Errorf("cannot change the owner of asset %s with an update, use TransferAsset: %w", id, ErrInvalidInput)
+	}
+	if err := checkNotLocked(oldAsset); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if expectedVersion != anyVersion && oldAsset.Version != expectedVersion {
+		logf(ctx, "ERROR: Asset %s is at version %d, expected %d", id, oldAsset.Version, expectedVersion)
+		return fmt.Errorf("version conflict: asset %s is at version %d, expected %d", id, oldAsset.Version, expectedVersion)
+	}
+	if err := validateCategoryMinimum(ctx, oldAsset.Category, appraisedValue); err != nil {
+		logf(ctx, "ERROR: Invalid asset data: %v", err)
 		return err
 	}
 
 	// Get client identity
 	clientID, err := ctx.GetClientIdentity().GetID()
 	if err != nil {
-		log.Printf("WARNING: Could not get client identity: %v", err)
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
 		clientID = "unknown"
 	}
 
-	// Create updated asset - preserve creation metadata
-	asset := Asset{
-		ID:             id,
-		Color:          color,
-		Size:           size,
-		Owner:          owner,
-		AppraisedValue: appraisedValue,
-		CreatedAt:      oldAsset.CreatedAt,
-		UpdatedAt:      time.Now(),
-		CreatedBy:      oldAsset.CreatedBy,
-		UpdatedBy:      clientID,
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	// Create updated asset - preserve creation metadata and fields not covered by the update
+	asset := *oldAsset
+	asset.Color = color
+	asset.Size = size
+	asset.AppraisedValue = appraisedValue
+	stampUpdate(ctx, &asset, now, clientID)
+
+	config, err := getConfig(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := checkImmutableFields(config, oldAsset, &asset); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
 	}
 
 	assetJSON, err := json.Marshal(asset)
 	if err != nil {
-		log.Printf("ERROR: Failed to marshal asset: %v", err)
-		return fmt.Errorf("failed to marshal asset: %v", err)
+		logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+		return fmt.Errorf("failed to marshal asset: %w", err)
 	}
 
 	err = ctx.GetStub().PutState(id, assetJSON)
 	if err != nil {
-		log.Printf("ERROR: Failed to update asset: %v", err)
-		return fmt.Errorf("failed to update asset: %v", err)
+		logf(ctx, "ERROR: Failed to update asset: %v", err)
+		return fmt.Errorf("failed to update asset: %w", err)
+	}
+	if err := recordChange(ctx, id, "update"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
 	}
 
 	// Emit event
-	eventPayload, _ := json.Marshal(map[string]interface{}{
-		"type":           "AssetUpdated",
-		"assetID":        id,
-		"oldOwner":       oldAsset.Owner,
-		"newOwner":       owner,
-		"oldValue":       oldAsset.AppraisedValue,
-		"newValue":       appraisedValue,
-		"updatedBy":      clientID,
-		"timestamp":      time.Now().Unix(),
+	err = emitEvent(ctx, "AssetUpdated", map[string]interface{}{
+		"assetID":   id,
+		"oldOwner":  oldAsset.Owner,
+		"newOwner":  owner,
+		"oldValue":  oldAsset.AppraisedValue,
+		"newValue":  appraisedValue,
+		"updatedBy": clientID,
 	})
-	err = ctx.GetStub().SetEvent("AssetUpdated", eventPayload)
 	if err != nil {
-		log.Printf("WARNING: Failed to emit event: %v", err)
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
 	}
 
-	log.Printf("INFO: Successfully updated asset %s", id)
-	log.Printf("===== END: UpdateAsset =====")
+	logf(ctx, "INFO: Successfully updated asset %s", id)
+	logf(ctx, "===== END: UpdateAsset =====")
 	return nil
 }
 
 // DeleteAsset deletes a given asset from the world state.
-func (s *SmartContract) DeleteAsset(ctx contractapi.TransactionContextInterface, id string) error {
-	log.Printf("===== START: DeleteAsset - ID: %s =====", id)
+func (s *AssetContract) DeleteAsset(ctx contractapi.TransactionContextInterface, id string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: DeleteAsset - ID: %s =====", id)
+
+	if err := requireAttribute(ctx, writerAttribute, "true"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
 
 	// Validate input
 	if err := validateAssetID(id); err != nil {
-		log.Printf("ERROR: Invalid asset ID: %v", err)
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
 		return err
 	}
 
 	// Get asset before deletion for event
 	asset, err := s.ReadAsset(ctx, id)
 	if err != nil {
-		log.Printf("ERROR: Asset %s does not exist: %v", id, err)
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if !clientActsAs(ctx, asset.Owner) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not delete asset %s owned by %s", id, asset.Owner)
+			return fmt.Errorf("only the owner or an admin may delete asset %s: %w", id, ErrNotOwner)
+		}
+	}
+	if err := checkNotLocked(asset); err != nil {
+		logf(ctx, "ERROR: %v", err)
 		return err
 	}
 
 	// Get client identity
 	clientID, err := ctx.GetClientIdentity().GetID()
 	if err != nil {
-		log.Printf("WARNING: Could not get client identity: %v", err)
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
 		clientID = "unknown"
 	}
 
 	// Delete asset
-	err = ctx.GetStub().DelState(id)
-	if err != nil {
-		log.Printf("ERROR: Failed to delete asset %s: %v", id, err)
-		return fmt.Errorf("failed to delete asset %s: %v", id, err)
+	if err := removeAsset(ctx, asset, clientID, "delete"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
 	}
 
 	// Emit event
-	eventPayload, _ := json.Marshal(map[string]interface{}{
-		"type":      "AssetDeleted",
+	err = emitEvent(ctx, "AssetDeleted", map[string]interface{}{
 		"assetID":   id,
 		"owner":     asset.Owner,
 		"deletedBy": clientID,
-		"timestamp": time.Now().Unix(),
 	})
-	err = ctx.GetStub().SetEvent("AssetDeleted", eventPayload)
 	if err != nil {
-		log.Printf("WARNING: Failed to emit event: %v", err)
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
 	}
 
-	log.Printf("INFO: Successfully deleted asset %s", id)
-	log.Printf("===== END: DeleteAsset =====")
+	logf(ctx, "INFO: Successfully deleted asset %s", id)
+	logf(ctx, "===== END: DeleteAsset =====")
 	return nil
 }
 
+// removeAsset hard deletes an asset read in the current transaction: it writes
+// the deletion receipt, drops the asset from the tag and owner indexes and
+// records the change under operation
+func removeAsset(ctx contractapi.TransactionContextInterface, asset *Asset, clientID string, operation string) error {
+	if err := writeDeletionReceipt(ctx, asset.ID, clientID); err != nil {
+		return err
+	}
+	if err := deleteTagIndex(ctx, asset); err != nil {
+		return err
+	}
+	if err := ctx.GetStub().DelState(asset.ID); err != nil {
+		return fmt.Errorf("failed to delete asset %s: %w", asset.ID, err)
+	}
+	if err := deleteOwnerIndex(ctx, asset.Owner, asset.ID); err != nil {
+		return err
+	}
+	return recordChange(ctx, asset.ID, operation)
+}
+
 // AssetExists returns true when asset with given ID exists in world state
-func (s *SmartContract) AssetExists(ctx contractapi.TransactionContextInterface, id string) (bool, error) {
+func (s *AssetContract) AssetExists(ctx contractapi.TransactionContextInterface, id string) (bool, error) {
+	id = normalizeAssetID(id)
 	assetJSON, err := ctx.GetStub().GetState(id)
 	if err != nil {
-		return false, fmt.Errorf("failed to read from world state: %v", err)
+		return false, fmt.Errorf("failed to read from world state: %w", err)
 	}
 
 	return assetJSON != nil, nil
 }
 
+// noFloor disables the floor value check of transferAsset
+const noFloor = 0
+
 // TransferAsset updates the owner field of asset with given id in world state.
-func (s *SmartContract) TransferAsset(ctx contractapi.TransactionContextInterface, id string, newOwner string) error {
-	log.Printf("===== START: TransferAsset - ID: %s, New Owner: %s =====", id, newOwner)
+func (s *AssetContract) TransferAsset(ctx contractapi.TransactionContextInterface, id string, newOwner string) error {
+	return s.transferAsset(ctx, id, newOwner, false, noFloor)
+}
+
+// TransferAssetWithValueOption transfers an asset and either keeps its appraised value
+// or resets it to 0 so that the new owner has to re-appraise it.
+func (s *AssetContract) TransferAssetWithValueOption(ctx contractapi.TransactionContextInterface, id string, newOwner string, resetValue bool) error {
+	return s.transferAsset(ctx, id, newOwner, resetValue, noFloor)
+}
+
+// TransferAssetWithFloor transfers an asset like TransferAsset, but only if its
+// appraised value is at least floorValue, so that settlement cannot hand over
+// an asset below its regulatory floor price
+func (s *AssetContract) TransferAssetWithFloor(ctx contractapi.TransactionContextInterface, id string, newOwner string, floorValue int) error {
+	if floorValue < 0 {
+		logf(ctx, "ERROR: Invalid floor value %d", floorValue)
+		return fmt.Errorf("floor value cannot be negative: %w", ErrInvalidInput)
+	}
+	return s.transferAsset(ctx, id, newOwner, false, floorValue)
+}
+
+// transferAsset moves an asset to newOwner, resetting its appraised value when
+// requested. An asset appraised below floorValue is not transferred.
+func (s *AssetContract) transferAsset(ctx contractapi.TransactionContextInterface, id string, newOwner string, resetValue bool, floorValue int) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: TransferAsset - ID: %s, New Owner: %s, Reset Value: %t, Floor: %d =====", id, newOwner, resetValue, floorValue)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
 
 	// Validate inputs
 	if err := validateAssetID(id); err != nil {
-		log.Printf("ERROR: Invalid asset ID: %v", err)
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
 		return err
 	}
 	if err := validateOwner(newOwner); err != nil {
-		log.Printf("ERROR: Invalid new owner: %v", err)
+		logf(ctx, "ERROR: Invalid new owner: %v", err)
 		return err
 	}
 
 	// Get existing asset
 	asset, err := s.ReadAsset(ctx, id)
 	if err != nil {
-		log.Printf("ERROR: Failed to read asset %s: %v", id, err)
+		logf(ctx, "ERROR: Failed to read asset %s: %v", id, err)
+		return err
+	}
+
+	// Only the owner may transfer; admins can force a transfer on its behalf
+	forced := false
+	if !clientActsAs(ctx, asset.Owner) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not transfer asset %s owned by %s", id, asset.Owner)
+			return fmt.Errorf("only the owner may transfer this asset: %w", ErrNotOwner)
+		}
+		forced = true
+		logf(ctx, "INFO: Admin is forcing the transfer of asset %s", id)
+	}
+
+	if err := checkNotLocked(asset); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if asset.EscrowOwner != "" {
+		logf(ctx, "ERROR: Asset %s is in escrow for %s", id, asset.EscrowOwner)
+		return fmt.Errorf("asset %s is in escrow for %s", id, asset.EscrowOwner)
+	}
+	if asset.PendingOwner != "" {
+		logf(ctx, "ERROR: Asset %s has a pending transfer to %s", id, asset.PendingOwner)
+		return fmt.Errorf("asset %s has a pending transfer to %s", id, asset.PendingOwner)
+	}
+	if err := checkRecipientAllowed(asset, newOwner); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := checkOwnerRegistered(ctx, newOwner); err != nil {
+		logf(ctx, "ERROR: %v", err)
 		return err
 	}
 
@@ -337,61 +756,82 @@ func (s *SmartContract) TransferAsset(ctx contractapi.TransactionContextInterfac
 	
 	// Check if already owned by newOwner
 	if oldOwner == newOwner {
-		log.Printf("ERROR: Asset %s is already owned by %s", id, newOwner)
+		logf(ctx, "ERROR: Asset %s is already owned by %s", id, newOwner)
 		return fmt.Errorf("asset %s is already owned by %s", id, newOwner)
 	}
+	if asset.AppraisedValue < floorValue {
+		logf(ctx, "ERROR: Asset %s is appraised at %d, below the floor of %d", id, asset.AppraisedValue, floorValue)
+		return fmt.Errorf("asset %s is appraised at %d, below the floor of %d: %w", id, asset.AppraisedValue, floorValue, ErrBelowFloor)
+	}
 
 	// Get client identity
 	clientID, err := ctx.GetClientIdentity().GetID()
 	if err != nil {
-		log.Printf("WARNING: Could not get client identity: %v", err)
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
 		clientID = "unknown"
 	}
 
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
 	// Update asset
 	asset.Owner = newOwner
-	asset.UpdatedAt = time.Now()
-	asset.UpdatedBy = clientID
+	asset.Owners = nil
+	stampUpdate(ctx, asset, now, clientID)
+	if resetValue {
+		asset.AppraisedValue = 0
+	}
 
 	assetJSON, err := json.Marshal(asset)
 	if err != nil {
-		log.Printf("ERROR: Failed to marshal asset: %v", err)
-		return fmt.Errorf("failed to marshal asset: %v", err)
+		logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+		return fmt.Errorf("failed to marshal asset: %w", err)
 	}
 
 	err = ctx.GetStub().PutState(id, assetJSON)
 	if err != nil {
-		log.Printf("ERROR: Failed to transfer asset: %v", err)
-		return fmt.Errorf("failed to transfer asset: %v", err)
+		logf(ctx, "ERROR: Failed to transfer asset: %v", err)
+		return fmt.Errorf("failed to transfer asset: %w", err)
+	}
+	if err := moveOwnerIndex(ctx, oldOwner, newOwner, id); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := recordChange(ctx, id, "transfer"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
 	}
 
 	// Emit event
-	eventPayload, _ := json.Marshal(map[string]interface{}{
-		"type":        "AssetTransferred",
-		"assetID":     id,
-		"oldOwner":    oldOwner,
-		"newOwner":    newOwner,
+	err = emitEvent(ctx, "AssetTransferred", map[string]interface{}{
+		"assetID":       id,
+		"oldOwner":      oldOwner,
+		"newOwner":      newOwner,
 		"transferredBy": clientID,
-		"timestamp":   time.Now().Unix(),
+		"valueReset":    resetValue,
+		"forced":        forced,
 	})
-	err = ctx.GetStub().SetEvent("AssetTransferred", eventPayload)
 	if err != nil {
-		log.Printf("WARNING: Failed to emit event: %v", err)
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
 	}
 
-	log.Printf("INFO: Successfully transferred asset %s from %s to %s", id, oldOwner, newOwner)
-	log.Printf("===== END: TransferAsset =====")
+	logf(ctx, "INFO: Successfully transferred asset %s from %s to %s", id, oldOwner, newOwner)
+	logf(ctx, "===== END: TransferAsset =====")
 	return nil
 }
 
 // GetAllAssets returns all assets found in world state
-func (s *SmartContract) GetAllAssets(ctx contractapi.TransactionContextInterface) ([]*Asset, error) {
-	log.Println("===== START: GetAllAssets =====")
+func (s *AssetContract) GetAllAssets(ctx contractapi.TransactionContextInterface) ([]*Asset, error) {
+	logln(ctx, "===== START: GetAllAssets =====")
 
 	resultsIterator, err := ctx.GetStub().GetStateByRange("", "")
 	if err != nil {
-		log.Printf("ERROR: Failed to get state by range: %v", err)
-		return nil, fmt.Errorf("failed to get state by range: %v", err)
+		logf(ctx, "ERROR: Failed to get state by range: %v", err)
+		return nil, fmt.Errorf("failed to get state by range: %w", err)
 	}
 	defer resultsIterator.Close()
 
@@ -399,37 +839,96 @@ func (s *SmartContract) GetAllAssets(ctx contractapi.TransactionContextInterface
 	for resultsIterator.HasNext() {
 		queryResponse, err := resultsIterator.Next()
 		if err != nil {
-			log.Printf("ERROR: Failed to iterate results: %v", err)
-			return nil, fmt.Errorf("failed to iterate results: %v", err)
+			logf(ctx, "ERROR: Failed to iterate results: %v", err)
+			return nil, fmt.Errorf("failed to iterate results: %w", err)
 		}
 
 		var asset Asset
 		err = json.Unmarshal(queryResponse.Value, &asset)
 		if err != nil {
-			log.Printf("WARNING: Failed to unmarshal asset, skipping: %v", err)
+			logf(ctx, "WARNING: Failed to unmarshal asset, skipping: %v", err)
 			continue
 		}
 		assets = append(assets, &asset)
 	}
 
-	log.Printf("INFO: Retrieved %d assets", len(assets))
-	log.Println("===== END: GetAllAssets =====")
+	logf(ctx, "INFO: Retrieved %d assets", len(assets))
+	logln(ctx, "===== END: GetAllAssets =====")
+	return assets, nil
+}
+
+// GetAssetsByIDRange returns the assets whose IDs fall in [startKey, endKey),
+// e.g. "vehicle." to "vehicle/" for all IDs with the "vehicle." prefix
+func (s *AssetContract) GetAssetsByIDRange(ctx contractapi.TransactionContextInterface, startKey string, endKey string) ([]*Asset, error) {
+	startKey = normalizeAssetID(startKey)
+	endKey = normalizeAssetID(endKey)
+	logf(ctx, "===== START: GetAssetsByIDRange - Start: %s, End: %s =====", startKey, endKey)
+
+	if startKey == "" && endKey == "" {
+		logln(ctx, "ERROR: Range is unbounded")
+		return nil, fmt.Errorf("at least one of start and end key must be set, use GetAllAssets to read every asset")
+	}
+	if startKey != "" && endKey != "" && startKey > endKey {
+		logf(ctx, "ERROR: Start key %s is after end key %s", startKey, endKey)
+		return nil, fmt.Errorf("start key %s must not be after end key %s", startKey, endKey)
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByRange(startKey, endKey)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get state by range: %v", err)
+		return nil, fmt.Errorf("failed to get state by range: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	assets := []*Asset{}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate results: %v", err)
+			return nil, fmt.Errorf("failed to iterate results: %w", err)
+		}
+
+		var asset Asset
+		err = json.Unmarshal(queryResponse.Value, &asset)
+		if err != nil {
+			logf(ctx, "WARNING: Failed to unmarshal asset, skipping: %v", err)
+			continue
+		}
+		assets = append(assets, &asset)
+	}
+
+	logf(ctx, "INFO: Retrieved %d assets in range", len(assets))
+	logln(ctx, "===== END: GetAssetsByIDRange =====")
 	return assets, nil
 }
 
 // GetAssetHistory returns the history of an asset
-func (s *SmartContract) GetAssetHistory(ctx contractapi.TransactionContextInterface, id string) ([]AssetHistory, error) {
-	log.Printf("===== START: GetAssetHistory - ID: %s =====", id)
+func (s *AssetContract) GetAssetHistory(ctx contractapi.TransactionContextInterface, id string) ([]AssetHistory, error) {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: GetAssetHistory - ID: %s =====", id)
 
 	if err := validateAssetID(id); err != nil {
-		log.Printf("ERROR: Invalid asset ID: %v", err)
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return nil, err
+	}
+
+	history, err := readAssetHistory(ctx, id, func(time.Time) bool { return true })
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
 		return nil, err
 	}
 
+	logf(ctx, "INFO: Retrieved %d history entries for asset %s", len(history), id)
+	logln(ctx, "===== END: GetAssetHistory =====")
+	return history, nil
+}
+
+// readAssetHistory returns the history entries of an asset whose timestamp
+// satisfies include. Entries that cannot be decoded are skipped.
+func readAssetHistory(ctx contractapi.TransactionContextInterface, id string, include func(time.Time) bool) ([]AssetHistory, error) {
 	resultsIterator, err := ctx.GetStub().GetHistoryForKey(id)
 	if err != nil {
-		log.Printf("ERROR: Failed to get history for key %s: %v", id, err)
-		return nil, fmt.Errorf("failed to get history for key %s: %v", id, err)
+		return nil, fmt.Errorf("failed to get history for key %s: %w", id, err)
 	}
 	defer resultsIterator.Close()
 
@@ -437,48 +936,47 @@ func (s *SmartContract) GetAssetHistory(ctx contractapi.TransactionContextInterf
 	for resultsIterator.HasNext() {
 		response, err := resultsIterator.Next()
 		if err != nil {
-			log.Printf("ERROR: Failed to iterate history: %v", err)
-			return nil, fmt.Errorf("failed to iterate history: %v", err)
+			return nil, fmt.Errorf("failed to iterate history: %w", err)
+		}
+
+		timestamp := time.Unix(response.Timestamp.Seconds, int64(response.Timestamp.Nanos))
+		if !include(timestamp) {
+			continue
 		}
 
 		var asset Asset
 		if len(response.Value) > 0 {
 			err = json.Unmarshal(response.Value, &asset)
 			if err != nil {
-				log.Printf("WARNING: Failed to unmarshal asset history, skipping: %v", err)
+				logf(ctx, "WARNING: Failed to unmarshal asset history, skipping: %v", err)
 				continue
 			}
 		}
 
 		historyEntry := AssetHistory{
 			TxID:      response.TxId,
-			Timestamp: time.Unix(response.Timestamp.Seconds, int64(response.Timestamp.Nanos)),
+			Timestamp: timestamp,
 			Asset:     asset,
 			IsDelete:  response.IsDelete,
 		}
 		history = append(history, historyEntry)
 	}
-
-	log.Printf("INFO: Retrieved %d history entries for asset %s", len(history), id)
-	log.Println("===== END: GetAssetHistory =====")
 	return history, nil
 }
 
 // QueryAssetsByOwner returns all assets owned by a specific owner
-func (s *SmartContract) QueryAssetsByOwner(ctx contractapi.TransactionContextInterface, owner string) ([]*Asset, error) {
-	log.Printf("===== START: QueryAssetsByOwner - Owner: %s =====", owner)
+func (s *AssetContract) QueryAssetsByOwner(ctx contractapi.TransactionContextInterface, owner string) ([]*Asset, error) {
+	logf(ctx, "===== START: QueryAssetsByOwner - Owner: %s =====", owner)
 
 	if err := validateOwner(owner); err != nil {
-		log.Printf("ERROR: Invalid owner: %v", err)
+		logf(ctx, "ERROR: Invalid owner: %v", err)
 		return nil, err
 	}
 
-	queryString := fmt.Sprintf(`{"selector":{"Owner":"%s"}}`, owner)
-	
-	resultsIterator, err := ctx.GetStub().GetQueryResult(queryString)
+	resultsIterator, err := ctx.GetStub().GetQueryResult(ownerQuery(owner))
 	if err != nil {
-		log.Printf("ERROR: Failed to execute query: %v", err)
-		return nil, fmt.Errorf("failed to execute query: %v", err)
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return nil, queryError(err, "QueryAssetsByOwnerIndexed")
 	}
 	defer resultsIterator.Close()
 
@@ -486,72 +984,256 @@ func (s *SmartContract) QueryAssetsByOwner(ctx contractapi.TransactionContextInt
 	for resultsIterator.HasNext() {
 		queryResponse, err := resultsIterator.Next()
 		if err != nil {
-			log.Printf("ERROR: Failed to iterate query results: %v", err)
-			return nil, fmt.Errorf("failed to iterate query results: %v", err)
+			logf(ctx, "ERROR: Failed to iterate query results: %v", err)
+			return nil, fmt.Errorf("failed to iterate query results: %w", err)
 		}
 
 		var asset Asset
 		err = json.Unmarshal(queryResponse.Value, &asset)
 		if err != nil {
-			log.Printf("WARNING: Failed to unmarshal asset, skipping: %v", err)
+			logf(ctx, "WARNING: Failed to unmarshal asset, skipping: %v", err)
 			continue
 		}
 		assets = append(assets, &asset)
 	}
 
-	log.Printf("INFO: Found %d assets for owner %s", len(assets), owner)
-	log.Println("===== END: QueryAssetsByOwner =====")
+	logf(ctx, "INFO: Found %d assets for owner %s", len(assets), owner)
+	logln(ctx, "===== END: QueryAssetsByOwner =====")
 	return assets, nil
 }
 
+// ownerQuery is the rich query selecting the assets of owner, shared by the
+// plain and the paginated owner queries so that both match the same assets
+func ownerQuery(owner string) string {
+	return fmt.Sprintf(`{"selector":{"Owner":"%s"}}`, owner)
+}
+
+// QueryAssetsByOwnerWithPagination returns one page of the assets owned by owner
+func (s *AssetContract) QueryAssetsByOwnerWithPagination(ctx contractapi.TransactionContextInterface, owner string, pageSize int32, bookmark string) (*PagedAssets, error) {
+	logf(ctx, "===== START: QueryAssetsByOwnerWithPagination - Owner: %s, Page Size: %d, Bookmark: %s =====", owner, pageSize, bookmark)
+
+	if err := validateOwner(owner); err != nil {
+		logf(ctx, "ERROR: Invalid owner: %v", err)
+		return nil, err
+	}
+	if pageSize <= 0 {
+		logf(ctx, "ERROR: Invalid page size %d", pageSize)
+		return nil, fmt.Errorf("page size must be positive")
+	}
+
+	resultsIterator, metadata, err := ctx.GetStub().GetQueryResultWithPagination(ownerQuery(owner), pageSize, bookmark)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return nil, queryError(err, "QueryAssetsByOwnerIndexed")
+	}
+
+	page, err := collectPagedAssets(ctx, resultsIterator, metadata, pageSize)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Found %d assets for owner %s on this page", len(page.Records), owner)
+	logln(ctx, "===== END: QueryAssetsByOwnerWithPagination =====")
+	return page, nil
+}
+
+// FoldAssetIDCase makes asset IDs case-insensitive by lower-casing them during
+// normalization. It must not be changed once assets exist, as IDs stored under
+// the previous rule would no longer be found.
+var FoldAssetIDCase = false
+
+// normalizeAssetID returns the canonical form of an asset ID under which it is
+// stored: surrounding whitespace is trimmed and, if FoldAssetIDCase is set, the
+// ID is lower-cased. Every function taking an asset ID normalizes it first so
+// that variants such as "Asset1 " and "asset1" resolve to the same key.
+func normalizeAssetID(id string) string {
+	id = strings.TrimSpace(id)
+	if FoldAssetIDCase {
+		id = strings.ToLower(id)
+	}
+	return id
+}
+
 // Validation helper functions
+
+// assetIDPattern is the character set allowed in asset IDs. It keeps keys
+// clear of the composite key separator, control bytes and characters with a
+// special meaning in CouchDB.
+var assetIDPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// maxAssetIDLength is the longest asset ID any configuration may allow. New
+// assets are held to the usually shorter MaxIDLength of the validation limits.
+const maxAssetIDLength = 256
+
+// reservedIDPrefixes are the prefixes of state keys the contract keeps for
+// itself. Asset IDs may not start with one of them, so that a user asset can
+// never shadow the config or an index entry.
+var reservedIDPrefixes = []string{"_", "index~", "config"}
+
 func validateAssetID(id string) error {
-	if id == "" {
-		return fmt.Errorf("asset ID cannot be empty")
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("asset ID cannot be empty: %w", ErrInvalidInput)
+	}
+	if len(id) > maxAssetIDLength {
+		return fmt.Errorf("asset ID cannot exceed %d characters: %w", maxAssetIDLength, ErrInvalidInput)
+	}
+	for _, prefix := range reservedIDPrefixes {
+		if strings.HasPrefix(id, prefix) {
+			return fmt.Errorf("asset ID %q uses the reserved prefix %q: %w", id, prefix, ErrInvalidInput)
+		}
+	}
+	if !assetIDPattern.MatchString(id) {
+		return fmt.Errorf("asset ID %q may only contain letters, digits, '-', '_' and '.': %w", id, ErrInvalidInput)
+	}
+	return nil
+}
+
+// validateNewAssetID validates the ID of an asset about to be created
+func validateNewAssetID(limits ValidationLimits, id string) error {
+	if err := validateAssetID(id); err != nil {
+		return err
 	}
-	if len(id) > 64 {
-		return fmt.Errorf("asset ID cannot exceed 64 characters")
+	if len(id) > limits.MaxIDLength {
+		return fmt.Errorf("asset ID cannot exceed %d characters: %w", limits.MaxIDLength, ErrInvalidInput)
 	}
 	return nil
 }
 
 func validateOwner(owner string) error {
 	if owner == "" {
-		return fmt.Errorf("owner cannot be empty")
+		return fmt.Errorf("owner cannot be empty: %w", ErrInvalidInput)
 	}
 	if len(owner) > 128 {
-		return fmt.Errorf("owner cannot exceed 128 characters")
+		return fmt.Errorf("owner cannot exceed 128 characters: %w", ErrInvalidInput)
 	}
 	return nil
 }
 
-func validateAssetData(color string, size int, owner string, appraisedValue int) error {
+// AllowedColors is the palette accepted for asset colors, compared
+// case-insensitively. An empty list accepts any color, e.g. while importing
+// legacy assets.
+var AllowedColors = []string{"blue", "red", "green", "yellow", "black", "white", "orange", "purple", "brown", "gray", "silver"}
+
+func colorAllowed(color string) bool {
+	if len(AllowedColors) == 0 {
+		return true
+	}
+	for _, allowed := range AllowedColors {
+		if strings.EqualFold(color, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxAppraisedValue is the largest appraised value an asset may have by default
+const maxAppraisedValue = 1000000000
+
+func validateAssetData(limits ValidationLimits, color string, size int, owner string, appraisedValue int) error {
 	if color == "" {
-		return fmt.Errorf("color cannot be empty")
+		return fmt.Errorf("color cannot be empty: %w", ErrInvalidInput)
 	}
 	if len(color) > 32 {
-		return fmt.Errorf("color cannot exceed 32 characters")
+		return fmt.Errorf("color cannot exceed 32 characters: %w", ErrInvalidInput)
+	}
+	if !colorAllowed(color) {
+		return fmt.Errorf("color %q is not one of the allowed colors %s: %w", color, strings.Join(AllowedColors, ", "), ErrInvalidInput)
 	}
 	if size <= 0 {
-		return fmt.Errorf("size must be positive")
+		return fmt.Errorf("size must be positive: %w", ErrInvalidInput)
+	}
+	if size < limits.MinSize {
+		return fmt.Errorf("size must be at least %d: %w", limits.MinSize, ErrInvalidInput)
 	}
-	if size > 1000000 {
-		return fmt.Errorf("size cannot exceed 1000000")
+	if size > limits.MaxSize {
+		return fmt.Errorf("size cannot exceed %d: %w", limits.MaxSize, ErrInvalidInput)
 	}
 	if err := validateOwner(owner); err != nil {
 		return err
 	}
 	if appraisedValue < 0 {
-		return fmt.Errorf("appraised value cannot be negative")
+		return fmt.Errorf("appraised value cannot be negative: %w", ErrInvalidInput)
+	}
+	if appraisedValue < limits.MinAppraisedValue {
+		return fmt.Errorf("appraised value must be at least %d: %w", limits.MinAppraisedValue, ErrInvalidInput)
 	}
-	if appraisedValue > 1000000000 {
-		return fmt.Errorf("appraised value cannot exceed 1000000000")
+	if appraisedValue > limits.MaxAppraisedValue {
+		return fmt.Errorf("appraised value cannot exceed %d: %w", limits.MaxAppraisedValue, ErrInvalidInput)
 	}
 	return nil
 }
 
+// getTxTimestamp returns the proposal timestamp of the current transaction,
+// which is identical on every endorsing peer unlike the local clock
+func getTxTimestamp(ctx contractapi.TransactionContextInterface) (time.Time, error) {
+	ts, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get transaction timestamp: %w", err)
+	}
+	return time.Unix(ts.Seconds, int64(ts.Nanos)).UTC(), nil
+}
+
+// GetTransactionTime returns the Unix seconds of the transaction timestamp, the
+// authoritative ledger time that stamps the operations of a transaction
+func (s *AssetContract) GetTransactionTime(ctx contractapi.TransactionContextInterface) (int64, error) {
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+	return now.Unix(), nil
+}
+
+// writerAttribute is the certificate attribute that must be "true" for a
+// caller to create, update or delete assets
+const writerAttribute = "asset.creator"
+
+// requireAttribute returns a permission error unless the caller's certificate
+// carries attribute attr with the given value
+func requireAttribute(ctx contractapi.TransactionContextInterface, attr string, value string) error {
+	if err := ctx.GetClientIdentity().AssertAttributeValue(attr, value); err != nil {
+		return fmt.Errorf("permission denied: caller requires attribute %s=%s: %w", attr, value, err)
+	}
+	return nil
+}
+
+// requireAdmin returns an error unless the caller holds the admin=true attribute
+func requireAdmin(ctx contractapi.TransactionContextInterface) error {
+	if err := ctx.GetClientIdentity().AssertAttributeValue("admin", "true"); err != nil {
+		return fmt.Errorf("caller is not authorized to perform admin operations: %w", err)
+	}
+	return nil
+}
+
+// clientActsAs reports whether the caller may act on behalf of owner, either as
+// a member of the MSP named owner or through an owner attribute in its certificate
+func clientActsAs(ctx contractapi.TransactionContextInterface, owner string) bool {
+	identity := ctx.GetClientIdentity()
+	if mspID, err := identity.GetMSPID(); err == nil && mspID == owner {
+		return true
+	}
+	value, found, err := identity.GetAttributeValue("owner")
+	return err == nil && found && value == owner
+}
+
+// stampUpdate records the transaction time and the caller's identity and MSP
+// as the last update of the asset and bumps its version
+func stampUpdate(ctx contractapi.TransactionContextInterface, asset *Asset, now time.Time, clientID string) {
+	mspID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client MSP ID: %v", err)
+		mspID = "unknown"
+	}
+
+	asset.UpdatedAt = now
+	asset.UpdatedBy = clientID
+	asset.UpdatedByMSP = mspID
+	asset.Version++
+}
+
 func main() {
-	assetChaincode, err := contractapi.NewChaincode(&SmartContract{})
+	assetChaincode, err := contractapi.NewChaincode(&AssetContract{}, &AdminContract{})
 	if err != nil {
 		log.Panicf("Error creating asset-transfer-basic chaincode: %v", err)
 	}
@@ -2,26 +2,39 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
-	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockTransactionContext is a mock for the transaction context
 type MockTransactionContext struct {
 	contractapi.TransactionContext
-	stub *MockStub
+	stub     shim.ChaincodeStubInterface
+	identity *MockClientIdentity
 }
 
 func (m *MockTransactionContext) GetStub() shim.ChaincodeStubInterface {
 	return m.stub
 }
 
+func (m *MockTransactionContext) GetClientIdentity() cid.ClientIdentity {
+	if m.identity == nil {
+		return defaultIdentity
+	}
+	return m.identity
+}
+
 // MockStub is a mock for the chaincode stub
 type MockStub struct {
 	mock.Mock
@@ -51,6 +64,119 @@ func (m *MockStub) SetEvent(name string, payload []byte) error {
 	return args.Error(0)
 }
 
+func (m *MockStub) GetPrivateData(collection string, key string) ([]byte, error) {
+	args := m.Called(collection, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockStub) PutPrivateData(collection string, key string, value []byte) error {
+	args := m.Called(collection, key, value)
+	return args.Error(0)
+}
+
+func (m *MockStub) GetPrivateDataHash(collection string, key string) ([]byte, error) {
+	args := m.Called(collection, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockStub) GetTransient() (map[string][]byte, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string][]byte), args.Error(1)
+}
+
+func (m *MockStub) SetStateValidationParameter(key string, ep []byte) error {
+	args := m.Called(key, ep)
+	return args.Error(0)
+}
+
+func (m *MockStub) GetStateValidationParameter(key string) ([]byte, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockStub) GetTxID() string {
+	return "mocktx"
+}
+
+func (m *MockStub) GetFunctionAndParameters() (string, []string) {
+	return "", nil
+}
+
+func (m *MockStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
+	return createCompositeKey(objectType, attributes), nil
+}
+
+func (m *MockStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
+	return &timestamp.Timestamp{Seconds: 1700000000}, nil
+}
+
+// expectChangeLog expects the change log entry written for a mutation
+func (m *MockStub) expectChangeLog() {
+	isChangeLogKey := func(key string) bool {
+		return strings.HasPrefix(key, createCompositeKey(changeLogObjectType, nil))
+	}
+	m.On("PutState", mock.MatchedBy(isChangeLogKey), mock.AnythingOfType("[]uint8")).Return(nil).Once()
+	m.On("GetStateByPartialCompositeKey", changeLogObjectType, []string{}).Return(&sliceIterator{}, nil).Once()
+}
+
+// expectDeletionReceipt expects the receipt written before an asset is hard deleted
+func (m *MockStub) expectDeletionReceipt(id string) {
+	key := createCompositeKey(deletionReceiptObjectType, []string{id})
+	m.On("PutState", key, mock.AnythingOfType("[]uint8")).Return(nil).Once()
+}
+
+// expectOwnerIndexPut expects the owner index entry written for an asset
+func (m *MockStub) expectOwnerIndexPut(owner string, id string) {
+	key := createCompositeKey(ownerIndexObjectType, []string{owner, id})
+	m.On("PutState", key, []byte{0x00}).Return(nil).Once()
+}
+
+// expectOwnerIndexDelete expects the owner index entry of an asset to be removed
+func (m *MockStub) expectOwnerIndexDelete(owner string, id string) {
+	key := createCompositeKey(ownerIndexObjectType, []string{owner, id})
+	m.On("DelState", key).Return(nil).Once()
+}
+
+// expectDefaultConfig lets mutating functions read an unset contract config
+func (m *MockStub) expectDefaultConfig() {
+	key := createCompositeKey(configObjectType, []string{configName})
+	m.On("GetState", key).Return(nil, nil).Maybe()
+}
+
+// expectEventLog expects the event log entry written alongside an emitted event
+func (m *MockStub) expectEventLog() {
+	key := createCompositeKey(eventLogObjectType, []string{"mocktx"})
+	m.On("PutState", key, mock.AnythingOfType("[]uint8")).Return(nil).Once()
+}
+
+func (m *MockStub) GetQueryResult(query string) (shim.StateQueryIteratorInterface, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(shim.StateQueryIteratorInterface), args.Error(1)
+}
+
+func (m *MockStub) GetStateByPartialCompositeKey(objectType string, attributes []string) (shim.StateQueryIteratorInterface, error) {
+	args := m.Called(objectType, attributes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(shim.StateQueryIteratorInterface), args.Error(1)
+}
+
 func (m *MockStub) GetStateByRange(startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
 	args := m.Called(startKey, endKey)
 	if args.Get(0) == nil {
@@ -91,13 +217,25 @@ func TestValidateAssetID(t *testing.T) {
 	}{
 		{"Valid ID", "asset1", false},
 		{"Empty ID", "", true},
-		{"Too Long ID", string(make([]byte, 65)), true},
-		{"Valid Max Length", string(make([]byte, 64)), false},
+		{"Whitespace ID", "   ", true},
+		{"Too Long ID", strings.Repeat("a", 65), true},
+		{"Valid Max Length", strings.Repeat("a", 64), false},
+		{"Allowed Punctuation", "vehicle-1_a.b", false},
+		{"Embedded Space", "asset 1", true},
+		{"Slash", "assets/1", true},
+		{"Embedded Null Byte", "asset\x001", true},
+		{"Composite Key Separator", "\x00chg\x00asset1", true},
+		{"Non-ASCII Letter", "assét1", true},
+		{"Reserved Index Prefix", "index~foo", true},
+		{"Reserved Config Key", "config", true},
+		{"Reserved Config Prefix", "config.backup", true},
+		{"Reserved Underscore Prefix", "_meta", true},
+		{"Reserved Word Inside", "my-config", false},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateAssetID(tt.id)
+			err := validateNewAssetID(defaultValidationLimits, tt.id)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -107,6 +245,77 @@ func TestValidateAssetID(t *testing.T) {
 	}
 }
 
+// Test that reserved prefixes are reported as such, not as bad characters
+func TestValidateAssetIDReservedPrefix(t *testing.T) {
+	for _, id := range []string{"index~foo", "config"} {
+		err := validateAssetID(id)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Contains(t, err.Error(), "reserved prefix")
+	}
+}
+
+func TestNormalizeAssetID(t *testing.T) {
+	assert.Equal(t, "Asset1", normalizeAssetID("  Asset1\t"))
+
+	FoldAssetIDCase = true
+	defer func() { FoldAssetIDCase = false }()
+	assert.Equal(t, "asset1", normalizeAssetID(" Asset1 "))
+}
+
+// Test CreateAsset rejects IDs that normalize to an existing asset
+func TestCreateAssetNormalizedDuplicates(t *testing.T) {
+	contract := AssetContract{}
+
+	t.Run("Whitespace Variant", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.CreateAsset(ctx, " asset1 ", "red", 5, "Jane", 100)
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+	})
+
+	t.Run("Case Variant With Folding", func(t *testing.T) {
+		FoldAssetIDCase = true
+		defer func() { FoldAssetIDCase = false }()
+
+		ledger := NewLedger()
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.CreateAsset(ctx, "Asset1", "blue", 10, "John", 500)
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, ledger.Get("asset1"))
+
+		_, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.CreateAsset(ctx, "ASSET1 ", "red", 5, "Jane", 100)
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+
+		_, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			asset, err := contract.ReadAsset(ctx, "aSsEt1")
+			if err == nil {
+				assert.Equal(t, "John", asset.Owner)
+			}
+			return err
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Case Variant Without Folding", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.CreateAsset(ctx, "Asset1", "red", 5, "Jane", 100)
+		})
+		assert.NoError(t, err)
+	})
+}
+
 func TestValidateOwner(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -148,11 +357,13 @@ func TestValidateAssetData(t *testing.T) {
 		{"Empty Owner", "blue", 10, "", 500, true},
 		{"Negative Value", "blue", 10, "John", -1, true},
 		{"Too Large Value", "blue", 10, "John", 1000000001, true},
+		{"Allowed Color Any Case", "Silver", 10, "John", 500, false},
+		{"Disallowed Color", "magenta", 10, "John", 500, true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateAssetData(tt.color, tt.size, tt.owner, tt.appraisedValue)
+			err := validateAssetData(defaultValidationLimits, tt.color, tt.size, tt.owner, tt.appraisedValue)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -162,11 +373,22 @@ func TestValidateAssetData(t *testing.T) {
 	}
 }
 
+// Test that an empty palette accepts any color
+func TestAllowedColorsDisabled(t *testing.T) {
+	saved := AllowedColors
+	defer func() { AllowedColors = saved }()
+
+	assert.Error(t, validateAssetData(defaultValidationLimits, "magenta", 10, "John", 500))
+	AllowedColors = nil
+	assert.NoError(t, validateAssetData(defaultValidationLimits, "magenta", 10, "John", 500))
+}
+
 // Test AssetExists
 func TestAssetExists(t *testing.T) {
 	stub := new(MockStub)
+	stub.expectDefaultConfig()
 	ctx := &MockTransactionContext{stub: stub}
-	contract := SmartContract{}
+	contract := AssetContract{}
 
 	t.Run("Asset Exists", func(t *testing.T) {
 		asset := Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300}
@@ -192,13 +414,17 @@ func TestAssetExists(t *testing.T) {
 // Test CreateAsset
 func TestCreateAsset(t *testing.T) {
 	stub := new(MockStub)
+	stub.expectDefaultConfig()
 	ctx := &MockTransactionContext{stub: stub}
-	contract := SmartContract{}
+	contract := AssetContract{}
 
 	t.Run("Create Asset Successfully", func(t *testing.T) {
 		stub.On("GetState", "asset1").Return(nil, nil).Once()
 		stub.On("PutState", "asset1", mock.AnythingOfType("[]uint8")).Return(nil).Once()
 		stub.On("SetEvent", "AssetCreated", mock.AnythingOfType("[]uint8")).Return(nil).Once()
+		stub.expectOwnerIndexPut("John", "asset1")
+		stub.expectEventLog()
+		stub.expectChangeLog()
 
 		err := contract.CreateAsset(ctx, "asset1", "blue", 10, "John", 500)
 		assert.NoError(t, err)
@@ -213,6 +439,7 @@ func TestCreateAsset(t *testing.T) {
 		err := contract.CreateAsset(ctx, "asset2", "blue", 10, "John", 500)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "already exists")
+		assert.True(t, errors.Is(err, ErrAssetExists))
 		stub.AssertExpectations(t)
 	})
 
@@ -229,11 +456,141 @@ func TestCreateAsset(t *testing.T) {
 	})
 }
 
+// Test CreateAssetIdempotent resubmissions
+func TestCreateAssetIdempotent(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+
+	create := func(color string) (*LedgerStub, error) {
+		return ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.CreateAssetIdempotent(ctx, "asset1", color, 10, "John", 500)
+		})
+	}
+
+	stub, err := create("blue")
+	require.NoError(t, err)
+	require.Len(t, stub.Events, 1)
+	assert.Equal(t, "AssetCreated", stub.LastEvent().EventName)
+	created := readCommitted(t, ledger, "asset1")
+
+	t.Run("Identical Resubmit", func(t *testing.T) {
+		stub, err := create("blue")
+		require.NoError(t, err)
+		assert.Empty(t, stub.Events)
+		assert.Empty(t, stub.writeSet)
+		assert.Equal(t, created, readCommitted(t, ledger, "asset1"))
+	})
+
+	t.Run("Conflicting Resubmit", func(t *testing.T) {
+		_, err := create("red")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrAssetExists))
+		assert.Equal(t, "blue", readCommitted(t, ledger, "asset1").Color)
+	})
+}
+
+// Test UpsertAsset
+func TestUpsertAsset(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+
+	upsert := func(color string, appraisedValue int) (*LedgerStub, error) {
+		return ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpsertAsset(ctx, "asset1", color, 10, "John", appraisedValue)
+		})
+	}
+
+	t.Run("Creates Missing Asset", func(t *testing.T) {
+		stub, err := upsert("blue", 500)
+		require.NoError(t, err)
+		require.Len(t, stub.Events, 1)
+		assert.Equal(t, "AssetCreated", stub.LastEvent().EventName)
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "blue", asset.Color)
+		assert.Equal(t, 1, asset.Version)
+		assert.Equal(t, defaultIdentity.ID, asset.CreatedBy)
+		assert.Equal(t, stub.TxTime.Unix(), asset.CreatedAt.Unix())
+	})
+
+	t.Run("Updates Existing Asset", func(t *testing.T) {
+		created := readCommitted(t, ledger, "asset1")
+
+		stub, err := upsert("red", 700)
+		require.NoError(t, err)
+		require.Len(t, stub.Events, 1)
+		assert.Equal(t, "AssetUpdated", stub.LastEvent().EventName)
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "red", asset.Color)
+		assert.Equal(t, 700, asset.AppraisedValue)
+		assert.Equal(t, 2, asset.Version)
+		assert.Equal(t, created.CreatedAt, asset.CreatedAt)
+		assert.Equal(t, created.CreatedBy, asset.CreatedBy)
+		assert.True(t, asset.UpdatedAt.After(created.UpdatedAt))
+	})
+
+	t.Run("Validation On Both Paths", func(t *testing.T) {
+		_, err := upsert("red", -1)
+		assert.Error(t, err)
+		assert.Equal(t, 700, readCommitted(t, ledger, "asset1").AppraisedValue)
+
+		_, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpsertAsset(ctx, "asset2", "blue", 0, "John", 500)
+		})
+		assert.Error(t, err)
+		assert.Nil(t, ledger.Get("asset2"))
+	})
+}
+
+// Test that asset timestamps come from the transaction rather than the peer clock
+func TestTransactionTimestamps(t *testing.T) {
+	contract := AssetContract{}
+
+	t.Run("CreatedAt Equals Tx Time", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		ctx := &MockTransactionContext{stub: stub}
+		txTime := time.Unix(1700000000, 0).UTC()
+
+		var written Asset
+		stub.On("GetState", "asset1").Return(nil, nil).Once()
+		stub.On("PutState", "asset1", mock.AnythingOfType("[]uint8")).Run(func(args mock.Arguments) {
+			require.NoError(t, json.Unmarshal(args.Get(1).([]byte), &written))
+		}).Return(nil).Once()
+		stub.On("SetEvent", "AssetCreated", mock.AnythingOfType("[]uint8")).Return(nil).Once()
+		stub.expectOwnerIndexPut("John", "asset1")
+		stub.expectEventLog()
+		stub.expectChangeLog()
+
+		require.NoError(t, contract.CreateAsset(ctx, "asset1", "blue", 10, "John", 500))
+		assert.True(t, txTime.Equal(written.CreatedAt))
+		assert.True(t, txTime.Equal(written.UpdatedAt))
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Endorsers Agree", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+
+		// Two peers simulate the same proposal, which carries one timestamp
+		peer1 := ledger.BeginTx("tx-update")
+		peer2 := ledger.BeginTx("tx-update")
+		peer2.TxTime = peer1.TxTime
+		require.NoError(t, contract.UpdateAsset(&MockTransactionContext{stub: peer1}, "asset1", "red", 10, "John", 600))
+		require.NoError(t, contract.UpdateAsset(&MockTransactionContext{stub: peer2}, "asset1", "red", 10, "John", 600))
+
+		assert.Equal(t, peer1.writeSet["asset1"], peer2.writeSet["asset1"])
+		assert.Equal(t, peer1.LastEvent().Payload, peer2.LastEvent().Payload)
+	})
+}
+
 // Test ReadAsset
 func TestReadAsset(t *testing.T) {
 	stub := new(MockStub)
+	stub.expectDefaultConfig()
 	ctx := &MockTransactionContext{stub: stub}
-	contract := SmartContract{}
+	contract := AssetContract{}
 
 	t.Run("Read Asset Successfully", func(t *testing.T) {
 		asset := Asset{
@@ -263,6 +620,48 @@ func TestReadAsset(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "does not exist")
+		assert.True(t, errors.Is(err, ErrAssetNotFound))
+		stub.AssertExpectations(t)
+	})
+}
+
+// Test TryReadAsset
+func TestTryReadAsset(t *testing.T) {
+	stub := new(MockStub)
+	stub.expectDefaultConfig()
+	ctx := &MockTransactionContext{stub: stub}
+	contract := AssetContract{}
+
+	t.Run("Found", func(t *testing.T) {
+		assetJSON, _ := json.Marshal(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+		stub.On("GetState", "asset1").Return(assetJSON, nil).Once()
+
+		asset, found, err := contract.TryReadAsset(ctx, "asset1")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "John", asset.Owner)
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		stub.On("GetState", "asset2").Return(nil, nil).Once()
+
+		asset, found, err := contract.TryReadAsset(ctx, "asset2")
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.Nil(t, asset)
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Ledger Failure", func(t *testing.T) {
+		stub.On("GetState", "asset3").Return(nil, errors.New("peer unavailable")).Once()
+
+		asset, found, err := contract.TryReadAsset(ctx, "asset3")
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrAssetNotFound))
+		assert.Contains(t, err.Error(), "peer unavailable")
+		assert.False(t, found)
+		assert.Nil(t, asset)
 		stub.AssertExpectations(t)
 	})
 }
@@ -270,8 +669,9 @@ func TestReadAsset(t *testing.T) {
 // Test UpdateAsset
 func TestUpdateAsset(t *testing.T) {
 	stub := new(MockStub)
+	stub.expectDefaultConfig()
 	ctx := &MockTransactionContext{stub: stub}
-	contract := SmartContract{}
+	contract := AssetContract{}
 
 	t.Run("Update Asset Successfully", func(t *testing.T) {
 		oldAsset := Asset{
@@ -287,12 +687,24 @@ func TestUpdateAsset(t *testing.T) {
 		stub.On("GetState", "asset1").Return(assetJSON, nil).Once()
 		stub.On("PutState", "asset1", mock.AnythingOfType("[]uint8")).Return(nil).Once()
 		stub.On("SetEvent", "AssetUpdated", mock.AnythingOfType("[]uint8")).Return(nil).Once()
+		stub.expectEventLog()
+		stub.expectChangeLog()
 
-		err := contract.UpdateAsset(ctx, "asset1", "red", 20, "Jane", 600)
+		err := contract.UpdateAsset(ctx, "asset1", "red", 20, "John", 600)
 		assert.NoError(t, err)
 		stub.AssertExpectations(t)
 	})
 
+	t.Run("Owner Change Rejected", func(t *testing.T) {
+		assetJSON, _ := json.Marshal(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+		stub.On("GetState", "asset1").Return(assetJSON, nil).Once()
+
+		err := contract.UpdateAsset(ctx, "asset1", "red", 20, "Jane", 600)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Contains(t, err.Error(), "TransferAsset")
+		stub.AssertExpectations(t)
+	})
+
 	t.Run("Asset Does Not Exist", func(t *testing.T) {
 		stub.On("GetState", "asset2").Return(nil, nil).Once()
 
@@ -302,18 +714,64 @@ func TestUpdateAsset(t *testing.T) {
 	})
 }
 
+// Test UpdateAssetFields partial updates
+func TestUpdateAssetFields(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500, Version: 1})
+
+	update := func(updatesJSON string) error {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAssetFields(ctx, "asset1", updatesJSON)
+		})
+		return err
+	}
+
+	t.Run("Only Color", func(t *testing.T) {
+		require.NoError(t, update(`{"Color": "red"}`))
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "red", asset.Color)
+		assert.Equal(t, 10, asset.Size)
+		assert.Equal(t, "John", asset.Owner)
+		assert.Equal(t, 500, asset.AppraisedValue)
+		assert.Equal(t, 2, asset.Version)
+	})
+
+	t.Run("Unknown Field Rejected", func(t *testing.T) {
+		err := update(`{"Color": "green", "Weight": 3}`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Weight")
+		assert.Equal(t, "red", readCommitted(t, ledger, "asset1").Color)
+	})
+
+	t.Run("Owner Rejected", func(t *testing.T) {
+		assert.Error(t, update(`{"Owner": "Jane"}`))
+		assert.Equal(t, "John", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Merged Asset Revalidated", func(t *testing.T) {
+		assert.Error(t, update(`{"Size": 0}`))
+		assert.Error(t, update(`{}`))
+	})
+}
+
 // Test DeleteAsset
 func TestDeleteAsset(t *testing.T) {
 	stub := new(MockStub)
-	ctx := &MockTransactionContext{stub: stub}
-	contract := SmartContract{}
+	stub.expectDefaultConfig()
+	ctx := &MockTransactionContext{stub: stub, identity: ownerIdentity("John")}
+	contract := AssetContract{}
 
 	t.Run("Delete Asset Successfully", func(t *testing.T) {
 		asset := Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500}
 		assetJSON, _ := json.Marshal(asset)
-		stub.On("GetState", "asset1").Return(assetJSON, nil).Once()
+		stub.On("GetState", "asset1").Return(assetJSON, nil).Twice()
+		stub.expectDeletionReceipt("asset1")
 		stub.On("DelState", "asset1").Return(nil).Once()
 		stub.On("SetEvent", "AssetDeleted", mock.AnythingOfType("[]uint8")).Return(nil).Once()
+		stub.expectOwnerIndexDelete("John", "asset1")
+		stub.expectEventLog()
+		stub.expectChangeLog()
 
 		err := contract.DeleteAsset(ctx, "asset1")
 		assert.NoError(t, err)
@@ -327,13 +785,24 @@ func TestDeleteAsset(t *testing.T) {
 		assert.Error(t, err)
 		stub.AssertExpectations(t)
 	})
+
+	t.Run("Not The Owner", func(t *testing.T) {
+		asset := Asset{ID: "asset3", Color: "red", Size: 5, Owner: "Jane", AppraisedValue: 300}
+		assetJSON, _ := json.Marshal(asset)
+		stub.On("GetState", "asset3").Return(assetJSON, nil).Once()
+
+		err := contract.DeleteAsset(ctx, "asset3")
+		assert.ErrorIs(t, err, ErrNotOwner)
+		stub.AssertExpectations(t)
+	})
 }
 
 // Test TransferAsset
 func TestTransferAsset(t *testing.T) {
 	stub := new(MockStub)
-	ctx := &MockTransactionContext{stub: stub}
-	contract := SmartContract{}
+	stub.expectDefaultConfig()
+	ctx := &MockTransactionContext{stub: stub, identity: ownerIdentity("John")}
+	contract := AssetContract{}
 
 	t.Run("Transfer Asset Successfully", func(t *testing.T) {
 		asset := Asset{
@@ -348,6 +817,10 @@ func TestTransferAsset(t *testing.T) {
 		stub.On("GetState", "asset1").Return(assetJSON, nil).Once()
 		stub.On("PutState", "asset1", mock.AnythingOfType("[]uint8")).Return(nil).Once()
 		stub.On("SetEvent", "AssetTransferred", mock.AnythingOfType("[]uint8")).Return(nil).Once()
+		stub.expectOwnerIndexDelete("John", "asset1")
+		stub.expectOwnerIndexPut("Jane", "asset1")
+		stub.expectEventLog()
+		stub.expectChangeLog()
 
 		err := contract.TransferAsset(ctx, "asset1", "Jane")
 		assert.NoError(t, err)
@@ -366,11 +839,182 @@ func TestTransferAsset(t *testing.T) {
 	})
 }
 
+// Test that only the owner or an admin may transfer an asset
+func TestTransferAssetOwnership(t *testing.T) {
+	contract := AssetContract{}
+
+	transfer := func(identity *MockClientIdentity) (*Ledger, *LedgerStub, error) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+		stub, err := ledger.Invoke(identity, func(ctx *MockTransactionContext) error {
+			return contract.TransferAsset(ctx, "asset1", "Jane")
+		})
+		return ledger, stub, err
+	}
+	forced := func(t *testing.T, stub *LedgerStub) interface{} {
+		payload := eventData(t, stub.LastEvent())
+		return payload["forced"]
+	}
+
+	t.Run("Owner Transfers", func(t *testing.T) {
+		ledger, stub, err := transfer(ownerIdentity("John"))
+		require.NoError(t, err)
+		assert.Equal(t, "Jane", readCommitted(t, ledger, "asset1").Owner)
+		assert.Equal(t, false, forced(t, stub))
+	})
+
+	t.Run("Owner By MSP ID", func(t *testing.T) {
+		org := &MockClientIdentity{ID: "x509::CN=User1@org1.example.com", MSPID: "John"}
+		_, _, err := transfer(org)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Non Owner Rejected", func(t *testing.T) {
+		ledger, _, err := transfer(ownerIdentity("Mallory"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only the owner may transfer this asset")
+		assert.Equal(t, "John", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Admin Forces Transfer", func(t *testing.T) {
+		ledger, stub, err := transfer(adminIdentity)
+		require.NoError(t, err)
+		assert.Equal(t, "Jane", readCommitted(t, ledger, "asset1").Owner)
+		assert.Equal(t, true, forced(t, stub))
+	})
+}
+
+// Test that creating, updating and transferring record the caller's MSP ID
+func TestAssetMSPFields(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	org2 := &MockClientIdentity{
+		ID:         "x509::CN=User1@org2.example.com",
+		MSPID:      "Org2MSP",
+		Attributes: map[string]string{writerAttribute: "true"},
+	}
+	org3 := &MockClientIdentity{ID: "x509::CN=User1@org3.example.com", MSPID: "Org3MSP"}
+
+	_, err := ledger.Invoke(org2, func(ctx *MockTransactionContext) error {
+		return contract.CreateAsset(ctx, "asset1", "blue", 5, "Org3MSP", 300)
+	})
+	require.NoError(t, err)
+	asset := readCommitted(t, ledger, "asset1")
+	assert.Equal(t, "Org2MSP", asset.CreatorOrg)
+	assert.Equal(t, "Org2MSP", asset.UpdatedByMSP)
+
+	_, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+		return contract.UpdateAsset(ctx, "asset1", "red", 5, "Org3MSP", 300)
+	})
+	require.NoError(t, err)
+	asset = readCommitted(t, ledger, "asset1")
+	assert.Equal(t, "Org2MSP", asset.CreatorOrg)
+	assert.Equal(t, "Org1MSP", asset.UpdatedByMSP)
+
+	_, err = ledger.Invoke(org3, func(ctx *MockTransactionContext) error {
+		return contract.TransferAsset(ctx, "asset1", "Jane")
+	})
+	require.NoError(t, err)
+	asset = readCommitted(t, ledger, "asset1")
+	assert.Equal(t, "Org2MSP", asset.CreatorOrg)
+	assert.Equal(t, "Org3MSP", asset.UpdatedByMSP)
+
+	t.Run("Legacy Asset Without MSP Fields", func(t *testing.T) {
+		ledger.SeedRaw("legacy", []byte(`{"ID":"legacy","Color":"blue","Size":5,"Owner":"John","AppraisedValue":300}`))
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			legacy, err := contract.ReadAsset(ctx, "legacy")
+			if err == nil {
+				assert.Empty(t, legacy.CreatorOrg)
+				assert.Empty(t, legacy.UpdatedByMSP)
+			}
+			return err
+		})
+		assert.NoError(t, err)
+	})
+}
+
+// Test TransferAssetWithValueOption
+func TestTransferAssetWithValueOption(t *testing.T) {
+	contract := AssetContract{}
+
+	transfer := func(t *testing.T, resetValue bool) (*Ledger, map[string]interface{}) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+		stub, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.TransferAssetWithValueOption(ctx, "asset1", "Jane", resetValue)
+		})
+		require.NoError(t, err)
+		event := stub.LastEvent()
+		require.Equal(t, "AssetTransferred", event.EventName)
+		payload := eventData(t, event)
+		return ledger, payload
+	}
+
+	t.Run("Reset Value", func(t *testing.T) {
+		ledger, payload := transfer(t, true)
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "Jane", asset.Owner)
+		assert.Equal(t, 0, asset.AppraisedValue)
+		assert.Equal(t, true, payload["valueReset"])
+	})
+
+	t.Run("Keep Value", func(t *testing.T) {
+		ledger, payload := transfer(t, false)
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "Jane", asset.Owner)
+		assert.Equal(t, 500, asset.AppraisedValue)
+		assert.Equal(t, false, payload["valueReset"])
+	})
+}
+
+// Test TransferAssetWithFloor
+func TestTransferAssetWithFloor(t *testing.T) {
+	contract := AssetContract{}
+
+	transfer := func(identity *MockClientIdentity, floorValue int) (*Ledger, error) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+		_, err := ledger.Invoke(identity, func(ctx *MockTransactionContext) error {
+			return contract.TransferAssetWithFloor(ctx, "asset1", "Jane", floorValue)
+		})
+		return ledger, err
+	}
+
+	t.Run("Above Floor", func(t *testing.T) {
+		for _, floorValue := range []int{400, 500} {
+			ledger, err := transfer(ownerIdentity("John"), floorValue)
+			require.NoError(t, err)
+			assert.Equal(t, "Jane", readCommitted(t, ledger, "asset1").Owner)
+		}
+	})
+
+	t.Run("Below Floor", func(t *testing.T) {
+		ledger, err := transfer(ownerIdentity("John"), 501)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrBelowFloor))
+		assert.Contains(t, err.Error(), "appraised at 500")
+		assert.Equal(t, "John", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Ownership Checked Before Floor", func(t *testing.T) {
+		_, err := transfer(ownerIdentity("Max"), 501)
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, ErrBelowFloor))
+		assert.Contains(t, err.Error(), "only the owner may transfer")
+	})
+
+	t.Run("Negative Floor", func(t *testing.T) {
+		_, err := transfer(ownerIdentity("John"), -1)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+	})
+}
+
 // Test GetAllAssets
 func TestGetAllAssets(t *testing.T) {
 	stub := new(MockStub)
+	stub.expectDefaultConfig()
 	ctx := &MockTransactionContext{stub: stub}
-	contract := SmartContract{}
+	contract := AssetContract{}
 
 	t.Run("Get All Assets Successfully", func(t *testing.T) {
 		asset1 := Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500}
@@ -397,3 +1041,132 @@ func TestGetAllAssets(t *testing.T) {
 	})
 }
 
+// Test GetTransactionTime
+func TestGetTransactionTime(t *testing.T) {
+	stub := new(MockStub)
+	ctx := &MockTransactionContext{stub: stub}
+	contract := AssetContract{}
+
+	txTime, err := contract.GetTransactionTime(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1700000000), txTime)
+}
+
+// Test the error of QueryAssetsByOwner on a peer without rich query support
+func TestQueryAssetsByOwnerOnLevelDB(t *testing.T) {
+	contract := AssetContract{}
+
+	t.Run("LevelDB", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetQueryResult", ownerQuery("John")).Return(nil, errors.New("ExecuteQuery not supported for leveldb"))
+
+		_, err := contract.QueryAssetsByOwner(ctx, "John")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrRichQueryUnsupported))
+		assert.Contains(t, err.Error(), "rich queries require CouchDB as the state database; use QueryAssetsByOwnerIndexed instead")
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Other Query Error", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetQueryResult", ownerQuery("John")).Return(nil, errors.New("couchdb unreachable"))
+
+		_, err := contract.QueryAssetsByOwner(ctx, "John")
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, ErrRichQueryUnsupported))
+		assert.Contains(t, err.Error(), "failed to execute query: couchdb unreachable")
+	})
+}
+
+// Test QueryAssetsByOwnerWithPagination
+func TestQueryAssetsByOwnerWithPagination(t *testing.T) {
+	contract := AssetContract{}
+
+	t.Run("Owner Validated Before Query", func(t *testing.T) {
+		// The stub has no query expectations, so executing the query would fail the test
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+
+		_, err := contract.QueryAssetsByOwnerWithPagination(ctx, "", 10, "")
+		assert.Error(t, err)
+		stub.AssertExpectations(t)
+	})
+
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300},
+		Asset{ID: "asset2", Color: "red", Size: 5, Owner: "Jane", AppraisedValue: 300},
+		Asset{ID: "asset3", Color: "green", Size: 5, Owner: "John", AppraisedValue: 300},
+		Asset{ID: "asset4", Color: "white", Size: 5, Owner: "John", AppraisedValue: 300},
+	)
+	query := func(owner string, pageSize int32, bookmark string) *PagedAssets {
+		var page *PagedAssets
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			page, err = contract.QueryAssetsByOwnerWithPagination(ctx, owner, pageSize, bookmark)
+			return err
+		})
+		require.NoError(t, err)
+		return page
+	}
+
+	t.Run("Page With Bookmark", func(t *testing.T) {
+		page := query("John", 2, "")
+		require.Len(t, page.Records, 2)
+		assert.Equal(t, int32(2), page.FetchedCount)
+		assert.NotEmpty(t, page.Bookmark)
+		assert.False(t, page.Done)
+
+		// Like CouchDB, the short last page still carries a bookmark
+		next := query("John", 2, page.Bookmark)
+		require.Len(t, next.Records, 1)
+		assert.Equal(t, "asset4", next.Records[0].ID)
+		assert.NotEmpty(t, next.Bookmark)
+		assert.True(t, next.Done)
+	})
+
+	t.Run("Empty Page", func(t *testing.T) {
+		page := query("Nobody", 2, "")
+		assert.Empty(t, page.Records)
+		assert.NotNil(t, page.Records)
+		assert.Equal(t, int32(0), page.FetchedCount)
+		assert.True(t, page.Done)
+	})
+}
+
+// Test the asset.creator attribute gate on write methods
+func TestRequireAttribute(t *testing.T) {
+	contract := AssetContract{}
+	reader := &MockClientIdentity{ID: "x509::CN=Reader@org1.example.com", MSPID: "Org1MSP"}
+	revoked := &MockClientIdentity{
+		ID:         "x509::CN=Revoked@org1.example.com",
+		MSPID:      "Org1MSP",
+		Attributes: map[string]string{writerAttribute: "false"},
+	}
+
+	t.Run("Attribute Present", func(t *testing.T) {
+		ctx := &MockTransactionContext{stub: new(MockStub)}
+		assert.NoError(t, requireAttribute(ctx, writerAttribute, "true"))
+	})
+
+	t.Run("Attribute Missing Or Wrong", func(t *testing.T) {
+		for _, identity := range []*MockClientIdentity{reader, revoked} {
+			ctx := &MockTransactionContext{stub: new(MockStub), identity: identity}
+			err := requireAttribute(ctx, writerAttribute, "true")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "permission denied")
+		}
+	})
+
+	t.Run("Write Methods Fail Before State Access", func(t *testing.T) {
+		// The stub has no expectations, so any state access would panic
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub, identity: reader}
+
+		assert.Error(t, contract.CreateAsset(ctx, "asset1", "blue", 5, "John", 300))
+		assert.Error(t, contract.UpdateAsset(ctx, "asset1", "blue", 5, "John", 300))
+		assert.Error(t, contract.DeleteAsset(ctx, "asset1"))
+		stub.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// changeLogObjectType is the composite key namespace of the change log. Entries
+// are keyed by inverted transaction time so a partial key scan returns the
+// newest first.
+const changeLogObjectType = "chg"
+
+// maxRecentChanges caps the number of entries GetRecentChanges returns, and
+// the number of entries the change log retains
+const maxRecentChanges = 1000
+
+// ChangeLogEntry records a single mutation of an asset
+type ChangeLogEntry struct {
+	AssetID   string `json:"AssetID"`
+	Operation string `json:"Operation"`
+	Timestamp int64  `json:"Timestamp"`
+	TxID      string `json:"TxID"`
+}
+
+// changeLogKey returns the key of an entry; the inverted time sorts the
+// newest entry first
+func changeLogKey(ctx contractapi.TransactionContextInterface, nanos int64, txID string, id string) (string, error) {
+	return ctx.GetStub().CreateCompositeKey(changeLogObjectType, []string{fmt.Sprintf("%020d", math.MaxInt64-nanos), txID, id})
+}
+
+// recordChange appends an entry for a mutation of the asset to the change log
+// and prunes the entries that fall beyond maxRecentChanges. Each entry has its
+// own key, but the pruning scan is a range read, so mutations committed in the
+// same block conflict on it and all but one are invalidated.
+func recordChange(ctx contractapi.TransactionContextInterface, id string, operation string) error {
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	txID := ctx.GetStub().GetTxID()
+	key, err := changeLogKey(ctx, now.UnixNano(), txID, id)
+	if err != nil {
+		return fmt.Errorf("failed to create change log key: %w", err)
+	}
+
+	entryJSON, err := json.Marshal(ChangeLogEntry{AssetID: id, Operation: operation, Timestamp: now.Unix(), TxID: txID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal change log entry: %w", err)
+	}
+
+	if err := ctx.GetStub().PutState(key, entryJSON); err != nil {
+		return fmt.Errorf("failed to write change log entry: %w", err)
+	}
+	return pruneChangeLog(ctx)
+}
+
+// pruneChangeLog deletes the committed entries beyond the newest
+// maxRecentChanges-1, leaving room for the entry this transaction wrote
+func pruneChangeLog(ctx contractapi.TransactionContextInterface) error {
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(changeLogObjectType, []string{})
+	if err != nil {
+		return fmt.Errorf("failed to read change log: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	kept := 0
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return fmt.Errorf("failed to iterate change log: %w", err)
+		}
+		if kept < maxRecentChanges-1 {
+			kept++
+			continue
+		}
+		if err := ctx.GetStub().DelState(queryResponse.Key); err != nil {
+			return fmt.Errorf("failed to prune change log entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetRecentChanges returns the last n asset mutations across all assets, most recent first
+func (s *AssetContract) GetRecentChanges(ctx contractapi.TransactionContextInterface, n int) ([]*ChangeLogEntry, error) {
+	logf(ctx, "===== START: GetRecentChanges - N: %d =====", n)
+
+	if n <= 0 {
+		logln(ctx, "ERROR: N must be positive")
+		return nil, fmt.Errorf("n must be positive")
+	}
+	if n > maxRecentChanges {
+		logf(ctx, "ERROR: N exceeds %d", maxRecentChanges)
+		return nil, fmt.Errorf("n cannot exceed %d", maxRecentChanges)
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(changeLogObjectType, []string{})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read change log: %v", err)
+		return nil, fmt.Errorf("failed to read change log: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	changes := make([]*ChangeLogEntry, 0, n)
+	for len(changes) < n && resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate change log: %v", err)
+			return nil, fmt.Errorf("failed to iterate change log: %w", err)
+		}
+
+		var entry ChangeLogEntry
+		err = json.Unmarshal(queryResponse.Value, &entry)
+		if err != nil {
+			logf(ctx, "WARNING: Failed to unmarshal change log entry, skipping: %v", err)
+			continue
+		}
+		changes = append(changes, &entry)
+	}
+
+	logf(ctx, "INFO: Returning %d recent changes", len(changes))
+	logln(ctx, "===== END: GetRecentChanges =====")
+	return changes, nil
+}
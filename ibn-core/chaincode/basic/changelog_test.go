@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test GetRecentChanges
+func TestGetRecentChanges(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+
+	mutations := []func(ctx *MockTransactionContext) error{
+		func(ctx *MockTransactionContext) error {
+			return contract.CreateAsset(ctx, "asset1", "blue", 10, "John", 500)
+		},
+		func(ctx *MockTransactionContext) error {
+			return contract.CreateAsset(ctx, "asset2", "red", 20, "Jane", 600)
+		},
+		func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "green", 10, "John", 550)
+		},
+		func(ctx *MockTransactionContext) error {
+			return contract.TransferAsset(ctx, "asset2", "Max")
+		},
+		func(ctx *MockTransactionContext) error {
+			// Only John may delete his own asset
+			ctx.identity = ownerIdentity("John")
+			return contract.DeleteAsset(ctx, "asset1")
+		},
+	}
+	var txIDs []string
+	for _, mutate := range mutations {
+		stub, err := ledger.Invoke(ownerIdentity("Jane"), mutate)
+		require.NoError(t, err)
+		txIDs = append(txIDs, stub.TxID)
+	}
+
+	recentChanges := func(n int) ([]*ChangeLogEntry, error) {
+		var changes []*ChangeLogEntry
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			changes, err = contract.GetRecentChanges(ctx, n)
+			return err
+		})
+		return changes, err
+	}
+
+	t.Run("Most Recent First", func(t *testing.T) {
+		changes, err := recentChanges(3)
+		require.NoError(t, err)
+		require.Len(t, changes, 3)
+
+		assert.Equal(t, "asset1", changes[0].AssetID)
+		assert.Equal(t, "delete", changes[0].Operation)
+		assert.Equal(t, txIDs[4], changes[0].TxID)
+
+		assert.Equal(t, "asset2", changes[1].AssetID)
+		assert.Equal(t, "transfer", changes[1].Operation)
+
+		assert.Equal(t, "asset1", changes[2].AssetID)
+		assert.Equal(t, "update", changes[2].Operation)
+		assert.True(t, changes[1].Timestamp > changes[2].Timestamp)
+	})
+
+	t.Run("Fewer Changes Than Requested", func(t *testing.T) {
+		changes, err := recentChanges(50)
+		require.NoError(t, err)
+		require.Len(t, changes, 5)
+		assert.Equal(t, "create", changes[4].Operation)
+		assert.Equal(t, txIDs[0], changes[4].TxID)
+	})
+
+	t.Run("Invalid N", func(t *testing.T) {
+		_, err := recentChanges(0)
+		assert.Error(t, err)
+		_, err = recentChanges(maxRecentChanges + 1)
+		assert.Error(t, err)
+	})
+}
+
+// Test that recordChange prunes the change log to maxRecentChanges entries
+func TestChangeLogPruning(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+
+	stub := ledger.BeginTx("")
+	ctx := &MockTransactionContext{stub: stub}
+	for i := 0; i < maxRecentChanges; i++ {
+		key, err := changeLogKey(ctx, int64(i), fmt.Sprintf("seed%d", i), "asset0")
+		require.NoError(t, err)
+		entryJSON, err := json.Marshal(ChangeLogEntry{AssetID: "asset0", Operation: "create", TxID: fmt.Sprintf("seed%d", i)})
+		require.NoError(t, err)
+		require.NoError(t, stub.PutState(key, entryJSON))
+	}
+	require.NoError(t, ledger.Commit(stub))
+
+	_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+		return contract.CreateAsset(ctx, "asset1", "blue", 10, "John", 500)
+	})
+	require.NoError(t, err)
+
+	var changes []*ChangeLogEntry
+	_, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+		changes, err = contract.GetRecentChanges(ctx, maxRecentChanges)
+		return err
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, maxRecentChanges)
+	assert.Equal(t, "asset1", changes[0].AssetID)
+	assert.Equal(t, "seed1", changes[maxRecentChanges-1].TxID)
+
+	oldest, err := changeLogKey(&MockTransactionContext{stub: ledger.BeginTx("")}, 0, "seed0", "asset0")
+	require.NoError(t, err)
+	assert.Nil(t, ledger.Get(oldest))
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// configObjectType and configName form the composite key the contract
+// configuration is stored under, keeping it out of asset range scans
+const (
+	configObjectType = "config"
+	configName       = "contract"
+)
+
+// ContractConfig holds channel-wide settings changed at runtime by admins
+type ContractConfig struct {
+	MaintenanceMode bool     `json:"MaintenanceMode"`
+	ImmutableFields []string `json:"ImmutableFields"`
+	// MethodMetrics enables the MethodMetric event, which names the invoked
+	// function. The time spent in it is logged, not put in the event.
+	MethodMetrics bool `json:"MethodMetrics"`
+	// Events enables chaincode events. Turning it off keeps blocks small during
+	// bulk loads, at the cost of event consumers missing those writes.
+	Events bool `json:"Events"`
+	// RequireRegisteredOwners makes transfers fail unless the new owner was
+	// registered with RegisterOwner, catching transfers to mistyped owners
+	RequireRegisteredOwners bool `json:"RequireRegisteredOwners"`
+	// MaxEventPayloadBytes bounds the size of an event payload. Larger events
+	// are rejected when emitted instead of failing the transaction at the orderer.
+	MaxEventPayloadBytes int `json:"MaxEventPayloadBytes"`
+	// CategoryMinimumValues maps an asset category to the smallest appraised
+	// value accepted for assets in it, e.g. {"real-estate": 10000}
+	CategoryMinimumValues map[string]int `json:"CategoryMinimumValues"`
+	// Limits bounds the data accepted for new and updated assets
+	Limits ValidationLimits `json:"Limits"`
+}
+
+// ValidationLimits are the bounds validateAssetData and validateNewAssetID
+// check, set by admins with InitConfig
+type ValidationLimits struct {
+	MaxIDLength       int `json:"MaxIDLength"`
+	MinSize           int `json:"MinSize"`
+	MaxSize           int `json:"MaxSize"`
+	MinAppraisedValue int `json:"MinAppraisedValue"`
+	MaxAppraisedValue int `json:"MaxAppraisedValue"`
+}
+
+// defaultValidationLimits apply until an admin stores other limits
+var defaultValidationLimits = ValidationLimits{
+	MaxIDLength:       64,
+	MinSize:           1,
+	MaxSize:           1000000,
+	MinAppraisedValue: 0,
+	MaxAppraisedValue: maxAppraisedValue,
+}
+
+// validate checks that the limits are usable
+func (l ValidationLimits) validate() error {
+	if l.MaxIDLength < 1 || l.MaxIDLength > maxAssetIDLength {
+		return fmt.Errorf("MaxIDLength must be between 1 and %d: %w", maxAssetIDLength, ErrInvalidInput)
+	}
+	if l.MinSize < 1 {
+		return fmt.Errorf("MinSize must be positive: %w", ErrInvalidInput)
+	}
+	if l.MaxSize <= l.MinSize {
+		return fmt.Errorf("MaxSize must exceed MinSize: %w", ErrInvalidInput)
+	}
+	if l.MinAppraisedValue < 0 {
+		return fmt.Errorf("MinAppraisedValue cannot be negative: %w", ErrInvalidInput)
+	}
+	if l.MaxAppraisedValue <= l.MinAppraisedValue {
+		return fmt.Errorf("MaxAppraisedValue must exceed MinAppraisedValue: %w", ErrInvalidInput)
+	}
+	return nil
+}
+
+// defaultMaxEventPayloadBytes is the event payload limit until an admin sets
+// another, well below the default orderer message size
+const defaultMaxEventPayloadBytes = 64 * 1024
+
+// defaultConfig returns the configuration used when none has been stored.
+// Settings missing from a stored configuration keep these defaults.
+func defaultConfig() *ContractConfig {
+	return &ContractConfig{Events: true, MaxEventPayloadBytes: defaultMaxEventPayloadBytes, Limits: defaultValidationLimits}
+}
+
+func configKey(ctx contractapi.TransactionContextInterface) (string, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(configObjectType, []string{configName})
+	if err != nil {
+		return "", fmt.Errorf("failed to create config key: %w", err)
+	}
+	return key, nil
+}
+
+// getConfig reads the stored configuration, falling back to defaults if unset
+func getConfig(ctx contractapi.TransactionContextInterface) (*ContractConfig, error) {
+	key, err := configKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	configJSON, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	config := defaultConfig()
+	if configJSON == nil {
+		return config, nil
+	}
+	if err := json.Unmarshal(configJSON, config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	return config, nil
+}
+
+// putConfig stores the configuration
+func putConfig(ctx contractapi.TransactionContextInterface, config *ContractConfig) error {
+	key, err := configKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := ctx.GetStub().PutState(key, configJSON); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// getValidationLimits returns the stored validation limits, or the defaults
+func getValidationLimits(ctx contractapi.TransactionContextInterface) (ValidationLimits, error) {
+	config, err := getConfig(ctx)
+	if err != nil {
+		return ValidationLimits{}, err
+	}
+	return config.Limits, nil
+}
+
+// requireWritable returns an error while the ledger is in maintenance mode.
+// Every mutating function calls it before doing anything else.
+func requireWritable(ctx contractapi.TransactionContextInterface) error {
+	config, err := getConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if config.MaintenanceMode {
+		return fmt.Errorf("ledger in maintenance mode: mutations are disabled")
+	}
+	return nil
+}
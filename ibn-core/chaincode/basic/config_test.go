@@ -0,0 +1,188 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test maintenance mode
+func TestMaintenanceMode(t *testing.T) {
+	contract := AssetContract{}
+	admin := AdminContract{}
+
+	ledger := NewLedger()
+	ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+
+	setMaintenance := func(t *testing.T, on bool) {
+		_, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return admin.SetMaintenanceMode(ctx, on)
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("Requires Admin", func(t *testing.T) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return admin.SetMaintenanceMode(ctx, true)
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Mutations Blocked", func(t *testing.T) {
+		setMaintenance(t, true)
+		defer setMaintenance(t, false)
+
+		mutations := map[string]func(ctx *MockTransactionContext) error{
+			"CreateAsset": func(ctx *MockTransactionContext) error {
+				return contract.CreateAsset(ctx, "asset2", "red", 5, "Jane", 100)
+			},
+			"UpdateAsset": func(ctx *MockTransactionContext) error {
+				return contract.UpdateAsset(ctx, "asset1", "red", 10, "John", 500)
+			},
+			"TransferAsset": func(ctx *MockTransactionContext) error {
+				return contract.TransferAsset(ctx, "asset1", "Jane")
+			},
+			"DeleteAsset": func(ctx *MockTransactionContext) error {
+				return contract.DeleteAsset(ctx, "asset1")
+			},
+			"SoftDeleteAsset": func(ctx *MockTransactionContext) error {
+				return contract.SoftDeleteAsset(ctx, "asset1")
+			},
+		}
+		for name, mutate := range mutations {
+			_, err := ledger.Invoke(nil, mutate)
+			assert.Error(t, err, name)
+			assert.Contains(t, err.Error(), "maintenance mode", name)
+		}
+		assert.Equal(t, "John", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Config Changes Blocked", func(t *testing.T) {
+		setMaintenance(t, true)
+		defer setMaintenance(t, false)
+
+		setters := map[string]func(ctx *MockTransactionContext) error{
+			"SetImmutableFields": func(ctx *MockTransactionContext) error {
+				return admin.SetImmutableFields(ctx, "Color")
+			},
+			"SetMethodMetrics": func(ctx *MockTransactionContext) error {
+				return admin.SetMethodMetrics(ctx, true)
+			},
+			"SetEvents": func(ctx *MockTransactionContext) error {
+				return admin.SetEvents(ctx, false)
+			},
+			"SetMaxEventPayloadSize": func(ctx *MockTransactionContext) error {
+				return admin.SetMaxEventPayloadSize(ctx, 1024)
+			},
+			"SetRequireRegisteredOwners": func(ctx *MockTransactionContext) error {
+				return admin.SetRequireRegisteredOwners(ctx, true)
+			},
+			"SetCategoryMinimum": func(ctx *MockTransactionContext) error {
+				return admin.SetCategoryMinimum(ctx, "real-estate", 10000)
+			},
+			"InitConfig": func(ctx *MockTransactionContext) error {
+				return admin.InitConfig(ctx, `{"MaxSize":50}`)
+			},
+		}
+		for name, set := range setters {
+			_, err := ledger.Invoke(adminIdentity, set)
+			assert.Error(t, err, name)
+			assert.Contains(t, err.Error(), "maintenance mode", name)
+		}
+	})
+
+	t.Run("Reads Allowed", func(t *testing.T) {
+		setMaintenance(t, true)
+		defer setMaintenance(t, false)
+
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			asset, err := contract.ReadAsset(ctx, "asset1")
+			if err == nil {
+				assert.Equal(t, "asset1", asset.ID)
+			}
+			return err
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Mutations Resume When Disabled", func(t *testing.T) {
+		setMaintenance(t, true)
+		setMaintenance(t, false)
+
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.TransferAsset(ctx, "asset1", "Jane")
+		})
+		assert.NoError(t, err)
+	})
+}
+
+// Test configurable validation limits
+func TestInitConfig(t *testing.T) {
+	contract := AssetContract{}
+	admin := AdminContract{}
+
+	create := func(ledger *Ledger, id string, size int, appraisedValue int) error {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.CreateAsset(ctx, id, "blue", size, "John", appraisedValue)
+		})
+		return err
+	}
+	initConfig := func(ledger *Ledger, identity *MockClientIdentity, configJSON string) error {
+		_, err := ledger.Invoke(identity, func(ctx *MockTransactionContext) error {
+			return admin.InitConfig(ctx, configJSON)
+		})
+		return err
+	}
+
+	t.Run("Defaults Without Config", func(t *testing.T) {
+		ledger := NewLedger()
+		assert.NoError(t, create(ledger, strings.Repeat("a", 64), 1000000, 1000000000))
+		assert.Error(t, create(ledger, strings.Repeat("b", 65), 10, 500))
+		assert.Error(t, create(ledger, "asset2", 1000001, 500))
+		assert.Error(t, create(ledger, "asset3", 10, 1000000001))
+	})
+
+	t.Run("Custom Limits", func(t *testing.T) {
+		ledger := NewLedger()
+		require.NoError(t, initConfig(ledger, adminIdentity, `{"MaxIDLength":100,"MaxSize":50,"MinAppraisedValue":10,"MaxAppraisedValue":2000000000}`))
+
+		assert.NoError(t, create(ledger, strings.Repeat("a", 100), 50, 2000000000))
+		assert.Error(t, create(ledger, "asset2", 51, 500))
+		err := create(ledger, "asset3", 10, 5)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "at least 10")
+
+		// The update path reads the same limits
+		_, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, strings.Repeat("a", 100), "blue", 60, "John", 500)
+		})
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+	})
+
+	t.Run("Omitted Limits Keep Defaults", func(t *testing.T) {
+		ledger := NewLedger()
+		require.NoError(t, initConfig(ledger, adminIdentity, `{"MaxSize":50}`))
+
+		assert.Error(t, create(ledger, strings.Repeat("a", 65), 10, 500))
+		assert.NoError(t, create(ledger, "asset1", 10, 1000000000))
+	})
+
+	t.Run("Invalid Config", func(t *testing.T) {
+		ledger := NewLedger()
+		for _, configJSON := range []string{
+			`not json`,
+			`{"MaxSize":1}`,
+			`{"MinSize":0}`,
+			`{"MinAppraisedValue":500,"MaxAppraisedValue":100}`,
+			`{"MaxIDLength":1000}`,
+			`{"MaxColors":3}`,
+		} {
+			err := initConfig(ledger, adminIdentity, configJSON)
+			assert.True(t, errors.Is(err, ErrInvalidInput), configJSON)
+		}
+		assert.Error(t, initConfig(ledger, nil, `{"MaxSize":50}`))
+	})
+}
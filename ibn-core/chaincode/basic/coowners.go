@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// maxCoOwners bounds the number of co-owners of an asset
+const maxCoOwners = 16
+
+// AddCoOwner makes owner a co-owner of an asset, next to its primary Owner.
+// Only the primary owner or an admin may add co-owners.
+//
+// Co-owners hold the asset jointly with the primary owner, but only the
+// primary owner slot moves on a transfer: TransferAsset, an accepted proposal
+// and a completed escrow hand the asset to the new owner alone and clear the
+// co-owners.
+func (s *AssetContract) AddCoOwner(ctx contractapi.TransactionContextInterface, id string, owner string) error {
+	return s.changeCoOwner(ctx, id, owner, true)
+}
+
+// RemoveCoOwner removes owner from the co-owners of an asset. The primary
+// owner, an admin or the co-owner itself may remove a co-owner.
+func (s *AssetContract) RemoveCoOwner(ctx contractapi.TransactionContextInterface, id string, owner string) error {
+	return s.changeCoOwner(ctx, id, owner, false)
+}
+
+func (s *AssetContract) changeCoOwner(ctx contractapi.TransactionContextInterface, id string, owner string, add bool) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: ChangeCoOwner - ID: %s, Owner: %s, Add: %t =====", id, owner, add)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+	if err := validateOwner(owner); err != nil {
+		logf(ctx, "ERROR: Invalid co-owner: %v", err)
+		return err
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if !clientActsAs(ctx, asset.Owner) && (add || !clientActsAs(ctx, owner)) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not change the co-owners of asset %s owned by %s", id, asset.Owner)
+			return fmt.Errorf("only the owner or an admin may change the co-owners of asset %s", id)
+		}
+	}
+	if err := checkNotLocked(asset); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	i := coOwnerIndex(asset, owner)
+	if add {
+		if owner == asset.Owner {
+			logf(ctx, "ERROR: %s is the primary owner of asset %s", owner, id)
+			return fmt.Errorf("%s is already the primary owner of asset %s: %w", owner, id, ErrInvalidInput)
+		}
+		if i >= 0 {
+			logf(ctx, "ERROR: %s is already a co-owner of asset %s", owner, id)
+			return fmt.Errorf("%s is already a co-owner of asset %s: %w", owner, id, ErrInvalidInput)
+		}
+		if len(asset.Owners) >= maxCoOwners {
+			logf(ctx, "ERROR: Asset %s already has %d co-owners", id, maxCoOwners)
+			return fmt.Errorf("asset %s cannot have more than %d co-owners", id, maxCoOwners)
+		}
+		asset.Owners = append(asset.Owners, owner)
+	} else {
+		if i < 0 {
+			logf(ctx, "ERROR: %s is not a co-owner of asset %s", owner, id)
+			return fmt.Errorf("%s is not a co-owner of asset %s", owner, id)
+		}
+		asset.Owners = append(asset.Owners[:i], asset.Owners[i+1:]...)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	stampUpdate(ctx, asset, now, clientID)
+
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+		return fmt.Errorf("failed to marshal asset: %w", err)
+	}
+
+	err = ctx.GetStub().PutState(id, assetJSON)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to update asset co-owners: %v", err)
+		return fmt.Errorf("failed to update asset co-owners: %w", err)
+	}
+
+	eventName := "CoOwnerRemoved"
+	if add {
+		eventName = "CoOwnerAdded"
+	}
+	if err := recordChange(ctx, id, "coOwnerChange"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, eventName, map[string]interface{}{
+		"assetID":   id,
+		"coOwner":   owner,
+		"updatedBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Co-owner %s of asset %s updated", owner, id)
+	logln(ctx, "===== END: ChangeCoOwner =====")
+	return nil
+}
+
+// coOwnerIndex returns the position of owner among the co-owners of an asset,
+// or -1
+func coOwnerIndex(asset *Asset, owner string) int {
+	for i, coOwner := range asset.Owners {
+		if coOwner == owner {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test adding and removing co-owners
+func TestCoOwners(t *testing.T) {
+	contract := AssetContract{}
+
+	setup := func(t *testing.T) *Ledger {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+		stub, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.AddCoOwner(ctx, "asset1", "Jane")
+		})
+		require.NoError(t, err)
+		event := stub.LastEvent()
+		assert.Equal(t, "CoOwnerAdded", event.EventName)
+		assert.Equal(t, "Jane", eventData(t, event)["coOwner"])
+		return ledger
+	}
+
+	t.Run("Add", func(t *testing.T) {
+		ledger := setup(t)
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.AddCoOwner(ctx, "asset1", "Max")
+		})
+		require.NoError(t, err)
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "John", asset.Owner)
+		assert.Equal(t, []string{"Jane", "Max"}, asset.Owners)
+	})
+
+	t.Run("Duplicate Rejected", func(t *testing.T) {
+		ledger := setup(t)
+		for _, owner := range []string{"Jane", "John"} {
+			_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+				return contract.AddCoOwner(ctx, "asset1", owner)
+			})
+			require.Error(t, err, owner)
+			assert.True(t, errors.Is(err, ErrInvalidInput), owner)
+		}
+		assert.Equal(t, []string{"Jane"}, readCommitted(t, ledger, "asset1").Owners)
+	})
+
+	t.Run("Invalid Owner", func(t *testing.T) {
+		ledger := setup(t)
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.AddCoOwner(ctx, "asset1", "")
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("Only Owner Adds", func(t *testing.T) {
+		ledger := setup(t)
+		_, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.AddCoOwner(ctx, "asset1", "Max")
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only the owner or an admin")
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		ledger := setup(t)
+		stub, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.RemoveCoOwner(ctx, "asset1", "Jane")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "CoOwnerRemoved", stub.LastEvent().EventName)
+		assert.Empty(t, readCommitted(t, ledger, "asset1").Owners)
+
+		_, err = ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.RemoveCoOwner(ctx, "asset1", "Jane")
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not a co-owner")
+	})
+
+	t.Run("Co-Owner Leaves", func(t *testing.T) {
+		ledger := setup(t)
+		_, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.RemoveCoOwner(ctx, "asset1", "Jane")
+		})
+		require.NoError(t, err)
+		assert.Empty(t, readCommitted(t, ledger, "asset1").Owners)
+	})
+
+	t.Run("Transfer Clears Co-Owners", func(t *testing.T) {
+		ledger := setup(t)
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.TransferAsset(ctx, "asset1", "Max")
+		})
+		require.NoError(t, err)
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "Max", asset.Owner)
+		assert.Empty(t, asset.Owners)
+	})
+
+	t.Run("Update Cannot Make Co-Owner Primary", func(t *testing.T) {
+		ledger := setup(t)
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "blue", 5, "Jane", 300)
+		})
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "John", asset.Owner)
+		assert.Equal(t, []string{"Jane"}, asset.Owners)
+	})
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// clientAssetFields are the Asset fields CreateAssetJSON takes from the client
+var clientAssetFields = map[string]bool{
+	"ID": true, "Color": true, "Size": true, "Owner": true, "AppraisedValue": true, "ExpiresAt": true,
+}
+
+// serverAssetFields are the Asset fields the chaincode always sets itself.
+// CreateAssetJSON ignores client values for them.
+var serverAssetFields = map[string]bool{
+	"CreatedAt": true, "UpdatedAt": true, "CreatedBy": true, "UpdatedBy": true,
+	"UpdatedByMSP": true, "CreatorOrg": true, "Version": true,
+}
+
+// CreateAssetJSON creates an asset from its JSON representation instead of
+// positional arguments. ID, Color, Size, Owner, AppraisedValue and the
+// optional ExpiresAt are taken from the JSON and validated like in CreateAsset.
+// Creation metadata such as CreatedBy and CreatedAt is always set from the
+// transaction, whatever the client sent. Any other field must be left out and
+// set afterwards with its own function, e.g. Tags with AddAssetTag.
+func (s *AssetContract) CreateAssetJSON(ctx contractapi.TransactionContextInterface, assetJSON string) error {
+	logln(ctx, "===== START: CreateAssetJSON =====")
+
+	var input Asset
+	decoder := json.NewDecoder(strings.NewReader(assetJSON))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&input); err != nil {
+		logf(ctx, "ERROR: Invalid asset JSON: %v", err)
+		return fmt.Errorf("asset must be a JSON object of asset fields: %v: %w", err, ErrInvalidInput)
+	}
+
+	value := reflect.ValueOf(input)
+	for i, name := range assetFieldNames() {
+		if clientAssetFields[name] || value.Field(i).IsZero() {
+			continue
+		}
+		if serverAssetFields[name] {
+			logf(ctx, "WARNING: Ignoring client-supplied %s", name)
+			continue
+		}
+		logf(ctx, "ERROR: Field %s cannot be set on create", name)
+		return fmt.Errorf("field %s cannot be set when creating an asset: %w", name, ErrInvalidInput)
+	}
+
+	var err error
+	if input.ExpiresAt.IsZero() {
+		err = s.CreateAsset(ctx, input.ID, input.Color, input.Size, input.Owner, input.AppraisedValue)
+	} else {
+		err = s.CreateAssetWithExpiry(ctx, input.ID, input.Color, input.Size, input.Owner, input.AppraisedValue, input.ExpiresAt.Unix())
+	}
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	logln(ctx, "===== END: CreateAssetJSON =====")
+	return nil
+}
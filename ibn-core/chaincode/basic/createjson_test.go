@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test CreateAssetJSON
+func TestCreateAssetJSON(t *testing.T) {
+	contract := AssetContract{}
+
+	create := func(ledger *Ledger, assetJSON string) (*LedgerStub, error) {
+		return ledger.Invoke(defaultIdentity, func(ctx *MockTransactionContext) error {
+			return contract.CreateAssetJSON(ctx, assetJSON)
+		})
+	}
+
+	t.Run("Client CreatedBy Overwritten", func(t *testing.T) {
+		ledger := NewLedger()
+		stub, err := create(ledger, `{"ID":"asset1","Color":"blue","Size":5,"Owner":"John","AppraisedValue":300,
+			"CreatedBy":"x509::CN=Mallory","CreatedAt":"2000-01-01T00:00:00Z","Version":7}`)
+		require.NoError(t, err)
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "blue", asset.Color)
+		assert.Equal(t, 300, asset.AppraisedValue)
+		assert.Equal(t, defaultIdentity.ID, asset.CreatedBy)
+		assert.Equal(t, stub.TxTime.Unix(), asset.CreatedAt.Unix())
+		assert.Equal(t, 1, asset.Version)
+		assert.Equal(t, "AssetCreated", stub.LastEvent().EventName)
+	})
+
+	t.Run("With Expiry", func(t *testing.T) {
+		ledger := NewLedger()
+		expiresAt := ledger.clock.AddDate(1, 0, 0)
+		_, err := create(ledger, `{"ID":"pass1","Color":"blue","Size":5,"Owner":"John","AppraisedValue":300,
+			"ExpiresAt":"`+expiresAt.Format("2006-01-02T15:04:05Z07:00")+`"}`)
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt.Unix(), readCommitted(t, ledger, "pass1").ExpiresAt.Unix())
+	})
+
+	t.Run("Validated Like CreateAsset", func(t *testing.T) {
+		ledger := NewLedger()
+		_, err := create(ledger, `{"ID":"asset1","Color":"","Size":5,"Owner":"John","AppraisedValue":300}`)
+		require.Error(t, err)
+		assert.Nil(t, ledger.Get("asset1"))
+	})
+
+	t.Run("Field With Own Function", func(t *testing.T) {
+		ledger := NewLedger()
+		_, err := create(ledger, `{"ID":"asset1","Color":"blue","Size":5,"Owner":"John","AppraisedValue":300,"Tags":["a"]}`)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Contains(t, err.Error(), "field Tags cannot be set")
+	})
+
+	t.Run("Unknown Field", func(t *testing.T) {
+		ledger := NewLedger()
+		_, err := create(ledger, `{"ID":"asset1","Colour":"blue"}`)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+	})
+}
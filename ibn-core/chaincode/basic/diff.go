@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// FieldChange holds the value of an asset field before and after a change
+type FieldChange struct {
+	Before interface{} `json:"Before"`
+	After  interface{} `json:"After"`
+}
+
+// diffAssets returns the fields whose value differs between two versions of an
+// asset, keyed by JSON field name. Values are compared by their JSON encoding,
+// which is what is stored in the world state.
+func diffAssets(before, after *Asset) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	names := assetFieldNames()
+	beforeValue := reflect.ValueOf(*before)
+	afterValue := reflect.ValueOf(*after)
+
+	for i, name := range names {
+		b := beforeValue.Field(i).Interface()
+		a := afterValue.Field(i).Interface()
+		bJSON, _ := json.Marshal(b)
+		aJSON, _ := json.Marshal(a)
+		if !bytes.Equal(bJSON, aJSON) {
+			changes[name] = FieldChange{Before: b, After: a}
+		}
+	}
+	return changes
+}
+
+// checkImmutableFields rejects a change to any of the configured immutable fields
+func checkImmutableFields(config *ContractConfig, before, after *Asset) error {
+	if len(config.ImmutableFields) == 0 {
+		return nil
+	}
+	changes := diffAssets(before, after)
+	for _, field := range config.ImmutableFields {
+		if change, changed := changes[field]; changed {
+			return fmt.Errorf("field %s is immutable and cannot be changed from %v to %v", field, change.Before, change.After)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffAssets(t *testing.T) {
+	before := &Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500}
+	after := *before
+	after.Color = "red"
+	after.AppraisedValue = 600
+
+	changes := diffAssets(before, &after)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, FieldChange{Before: "blue", After: "red"}, changes["Color"])
+	assert.Equal(t, FieldChange{Before: 500, After: 600}, changes["AppraisedValue"])
+	assert.Empty(t, diffAssets(before, before))
+}
+
+// Test immutable fields enforced by UpdateAsset
+func TestImmutableFields(t *testing.T) {
+	contract := AssetContract{}
+	admin := AdminContract{}
+
+	ledger := NewLedger()
+	ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+
+	setImmutable := func(t *testing.T, fields string) {
+		_, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return admin.SetImmutableFields(ctx, fields)
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("Default Allows Color Change", func(t *testing.T) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "green", 10, "John", 500)
+		})
+		require.NoError(t, err)
+		_, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "blue", 10, "John", 500)
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("Immutable Color Rejected", func(t *testing.T) {
+		setImmutable(t, "Color")
+		defer setImmutable(t, "")
+
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "red", 10, "John", 500)
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "field Color is immutable")
+		assert.Equal(t, "blue", readCommitted(t, ledger, "asset1").Color)
+	})
+
+	t.Run("Other Fields Still Mutable", func(t *testing.T) {
+		setImmutable(t, "Color")
+		defer setImmutable(t, "")
+
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "blue", 20, "John", 900)
+		})
+		require.NoError(t, err)
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, 20, asset.Size)
+		assert.Equal(t, 900, asset.AppraisedValue)
+	})
+
+	t.Run("Unknown Field Rejected", func(t *testing.T) {
+		_, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return admin.SetImmutableFields(ctx, "Colour")
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Requires Admin", func(t *testing.T) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return admin.SetImmutableFields(ctx, "Color")
+		})
+		assert.Error(t, err)
+	})
+}
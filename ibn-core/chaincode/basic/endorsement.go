@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric-chaincode-go/pkg/statebased"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// maxEndorsingOrgs bounds the number of MSPs of an asset endorsement policy
+const maxEndorsingOrgs = 16
+
+// SetAssetEndorsement sets a state-based endorsement policy on an asset, so
+// that from the next transaction on every change of it must be endorsed by a
+// peer of each MSP in mspIDsJSON, a JSON array such as ["Org1MSP","Org2MSP"].
+// The owner or an admin may set it.
+func (s *AssetContract) SetAssetEndorsement(ctx contractapi.TransactionContextInterface, id string, mspIDsJSON string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: SetAssetEndorsement - ID: %s =====", id)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+
+	var mspIDs []string
+	if err := json.Unmarshal([]byte(mspIDsJSON), &mspIDs); err != nil {
+		logf(ctx, "ERROR: Failed to parse MSP IDs: %v", err)
+		return fmt.Errorf("MSP IDs must be a JSON array of strings: %w", ErrInvalidInput)
+	}
+	if len(mspIDs) == 0 {
+		logln(ctx, "ERROR: No MSP IDs given")
+		return fmt.Errorf("endorsement policy needs at least one MSP ID: %w", ErrInvalidInput)
+	}
+	if len(mspIDs) > maxEndorsingOrgs {
+		logf(ctx, "ERROR: %d MSP IDs exceed the limit of %d", len(mspIDs), maxEndorsingOrgs)
+		return fmt.Errorf("endorsement policy cannot name more than %d MSPs: %w", maxEndorsingOrgs, ErrInvalidInput)
+	}
+	for _, mspID := range mspIDs {
+		if strings.TrimSpace(mspID) == "" {
+			logln(ctx, "ERROR: Empty MSP ID")
+			return fmt.Errorf("MSP IDs cannot be empty: %w", ErrInvalidInput)
+		}
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if !clientActsAs(ctx, asset.Owner) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not set the endorsement of asset %s owned by %s", id, asset.Owner)
+			return fmt.Errorf("only the owner or an admin may set the endorsement of asset %s", id)
+		}
+	}
+
+	endorsement, err := statebased.NewStateEP(nil)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to create endorsement policy: %v", err)
+		return fmt.Errorf("failed to create endorsement policy: %w", err)
+	}
+	if err := endorsement.AddOrgs(statebased.RoleTypePeer, mspIDs...); err != nil {
+		logf(ctx, "ERROR: Failed to add MSPs to endorsement policy: %v", err)
+		return fmt.Errorf("failed to add MSPs to endorsement policy: %w", err)
+	}
+	policy, err := endorsement.Policy()
+	if err != nil {
+		logf(ctx, "ERROR: Failed to marshal endorsement policy: %v", err)
+		return fmt.Errorf("failed to marshal endorsement policy: %w", err)
+	}
+
+	err = ctx.GetStub().SetStateValidationParameter(id, policy)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to set endorsement policy: %v", err)
+		return fmt.Errorf("failed to set endorsement policy of asset %s: %w", id, err)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	err = emitEvent(ctx, "AssetEndorsementSet", map[string]interface{}{
+		"assetID":   id,
+		"mspIDs":    endorsement.ListOrgs(),
+		"updatedBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Endorsement policy of asset %s set", id)
+	logln(ctx, "===== END: SetAssetEndorsement =====")
+	return nil
+}
+
+// GetAssetEndorsement returns the sorted MSP IDs whose endorsement a change of
+// the asset requires, or an empty list if it has no state-based policy
+func (s *AssetContract) GetAssetEndorsement(ctx contractapi.TransactionContextInterface, id string) ([]string, error) {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: GetAssetEndorsement - ID: %s =====", id)
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return nil, err
+	}
+
+	exists, err := s.AssetExists(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+	if !exists {
+		logf(ctx, "ERROR: Asset %s does not exist", id)
+		return nil, fmt.Errorf("the asset %s does not exist: %w", id, ErrAssetNotFound)
+	}
+
+	policy, err := ctx.GetStub().GetStateValidationParameter(id)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get endorsement policy: %v", err)
+		return nil, fmt.Errorf("failed to get endorsement policy of asset %s: %w", id, err)
+	}
+
+	mspIDs := []string{}
+	if len(policy) > 0 {
+		endorsement, err := statebased.NewStateEP(policy)
+		if err != nil {
+			logf(ctx, "ERROR: Failed to parse endorsement policy: %v", err)
+			return nil, fmt.Errorf("failed to parse endorsement policy of asset %s: %w", id, err)
+		}
+		mspIDs = endorsement.ListOrgs()
+		sort.Strings(mspIDs)
+	}
+
+	logf(ctx, "INFO: Asset %s requires endorsement by %d MSPs", id, len(mspIDs))
+	logln(ctx, "===== END: GetAssetEndorsement =====")
+	return mspIDs, nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/pkg/statebased"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test setting and reading back state-based endorsement policies
+func TestAssetEndorsement(t *testing.T) {
+	contract := AssetContract{}
+	assetJSON, _ := json.Marshal(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+
+	// policyOrgs returns the sorted MSP IDs of a marshaled policy
+	policyOrgs := func(policy []byte) []string {
+		endorsement, err := statebased.NewStateEP(policy)
+		if err != nil {
+			return nil
+		}
+		orgs := endorsement.ListOrgs()
+		sort.Strings(orgs)
+		return orgs
+	}
+
+	t.Run("Owner Sets Policy", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		stub.expectEventLog()
+		ctx := &MockTransactionContext{stub: stub, identity: ownerIdentity("John")}
+		stub.On("GetState", "asset1").Return(assetJSON, nil).Once()
+		stub.On("SetStateValidationParameter", "asset1", mock.MatchedBy(func(policy []byte) bool {
+			return assert.ObjectsAreEqual([]string{"Org1MSP", "Org2MSP"}, policyOrgs(policy))
+		})).Return(nil).Once()
+		stub.On("SetEvent", "AssetEndorsementSet", mock.AnythingOfType("[]uint8")).Return(nil).Once()
+
+		err := contract.SetAssetEndorsement(ctx, "asset1", `["Org2MSP","Org1MSP","Org2MSP"]`)
+		require.NoError(t, err)
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Policy Read Back", func(t *testing.T) {
+		endorsement, err := statebased.NewStateEP(nil)
+		require.NoError(t, err)
+		require.NoError(t, endorsement.AddOrgs(statebased.RoleTypePeer, "Org2MSP", "Org1MSP"))
+		policy, err := endorsement.Policy()
+		require.NoError(t, err)
+
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetState", "asset1").Return(assetJSON, nil).Once()
+		stub.On("GetStateValidationParameter", "asset1").Return(policy, nil).Once()
+
+		mspIDs, err := contract.GetAssetEndorsement(ctx, "asset1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Org1MSP", "Org2MSP"}, mspIDs)
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("No Policy", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetState", "asset1").Return(assetJSON, nil).Once()
+		stub.On("GetStateValidationParameter", "asset1").Return(nil, nil).Once()
+
+		mspIDs, err := contract.GetAssetEndorsement(ctx, "asset1")
+		require.NoError(t, err)
+		assert.Empty(t, mspIDs)
+		assert.NotNil(t, mspIDs)
+	})
+
+	t.Run("Missing Asset", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetState", "asset2").Return(nil, nil).Once()
+
+		_, err := contract.GetAssetEndorsement(ctx, "asset2")
+		assert.True(t, errors.Is(err, ErrAssetNotFound))
+		stub.AssertNotCalled(t, "GetStateValidationParameter", mock.Anything)
+	})
+
+	t.Run("Invalid MSP List", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		ctx := &MockTransactionContext{stub: stub, identity: ownerIdentity("John")}
+
+		for _, mspIDsJSON := range []string{`Org1MSP`, `[]`, `["Org1MSP",""]`, `[1]`} {
+			err := contract.SetAssetEndorsement(ctx, "asset1", mspIDsJSON)
+			assert.True(t, errors.Is(err, ErrInvalidInput), mspIDsJSON)
+		}
+		stub.AssertNotCalled(t, "SetStateValidationParameter", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Other Owner Rejected", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		ctx := &MockTransactionContext{stub: stub, identity: ownerIdentity("Jane")}
+		stub.On("GetState", "asset1").Return(assetJSON, nil).Once()
+
+		err := contract.SetAssetEndorsement(ctx, "asset1", `["Org1MSP"]`)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "only the owner or an admin")
+		stub.AssertNotCalled(t, "SetStateValidationParameter", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,40 @@
+package main
+
+import "errors"
+
+// Sentinel errors wrapped by contract methods so that callers can branch on
+// the kind of failure with errors.Is instead of matching message text
+var (
+	// ErrAssetNotFound is returned when an asset does not exist in the world state
+	ErrAssetNotFound = errors.New("asset not found")
+	// ErrAssetExists is returned when creating an asset whose ID is taken
+	ErrAssetExists = errors.New("asset already exists")
+	// ErrInvalidInput is returned when an argument fails validation
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrPrivateDataUnavailable is returned when the caller cannot read an
+	// asset's private data, typically because its organization is not a member
+	// of the collection
+	ErrPrivateDataUnavailable = errors.New("private data unavailable")
+	// ErrPriceMismatch is returned when a transfer is accepted at a price other
+	// than the asset's current appraised value
+	ErrPriceMismatch = errors.New("price mismatch")
+	// ErrAssetLocked is returned when changing an asset locked with LockAsset
+	ErrAssetLocked = errors.New("asset is locked")
+	// ErrAssetExpired is returned when reading an asset whose expiry has passed
+	ErrAssetExpired = errors.New("asset expired")
+	// ErrRichQueryUnsupported is returned when a rich query runs on a peer whose
+	// state database is LevelDB
+	ErrRichQueryUnsupported = errors.New("rich queries require CouchDB as the state database")
+	// ErrBelowFloor is returned when transferring an asset appraised below the
+	// floor value the transfer requires
+	ErrBelowFloor = errors.New("appraised value below floor")
+	// ErrOwnerNotRegistered is returned when transferring to an owner missing
+	// from the owner registry while RequireRegisteredOwners is on
+	ErrOwnerNotRegistered = errors.New("owner not registered")
+	// ErrNotOwner is returned when a caller that neither owns an asset nor is
+	// an admin tries to hand it to someone else
+	ErrNotOwner = errors.New("caller is not the owner")
+	// ErrEventTooLarge is returned when an event payload exceeds
+	// MaxEventPayloadBytes; the transaction fails instead of dropping the event
+	ErrEventTooLarge = errors.New("event payload too large")
+)
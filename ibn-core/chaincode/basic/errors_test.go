@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Test that stub errors are wrapped rather than flattened
+func TestErrorWrapping(t *testing.T) {
+	contract := AssetContract{}
+	stubErr := errors.New("ledger unavailable")
+
+	t.Run("ReadAsset Unwraps To Stub Error", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetState", "asset1").Return(nil, stubErr).Once()
+
+		_, err := contract.ReadAsset(ctx, "asset1")
+		assert.Error(t, err)
+		assert.Equal(t, stubErr, errors.Unwrap(err))
+	})
+
+	t.Run("CreateAsset Preserves Nested Cause", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetState", "asset1").Return(nil, stubErr).Once()
+
+		err := contract.CreateAsset(ctx, "asset1", "blue", 5, "John", 300)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, stubErr))
+		assert.Equal(t, stubErr, errors.Unwrap(errors.Unwrap(err)))
+	})
+
+	t.Run("UpdateAsset Unwraps Write Error", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		ctx := &MockTransactionContext{stub: stub}
+		assetJSON, _ := json.Marshal(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+		stub.On("GetState", "asset1").Return(assetJSON, nil).Once()
+		stub.On("PutState", "asset1", mock.AnythingOfType("[]uint8")).Return(stubErr).Once()
+
+		err := contract.UpdateAsset(ctx, "asset1", "red", 5, "John", 300)
+		assert.Error(t, err)
+		assert.Equal(t, stubErr, errors.Unwrap(err))
+	})
+
+	t.Run("Validation Errors Wrap ErrInvalidInput", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		ctx := &MockTransactionContext{stub: stub}
+
+		err := contract.CreateAsset(ctx, "asset1", "blue", -1, "John", 300)
+		assert.Error(t, err)
+		assert.Equal(t, ErrInvalidInput, errors.Unwrap(err))
+		assert.Contains(t, err.Error(), "size must be positive")
+	})
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// EscrowAssetWithDeadline places an asset in escrow for intendedOwner, who must
+// accept it with AcceptTransfer before deadlineUnix. Until then the asset keeps
+// its current owner and cannot be transferred elsewhere. Only the owner or an
+// admin may escrow an asset, and a locked asset cannot be escrowed.
+func (s *AssetContract) EscrowAssetWithDeadline(ctx contractapi.TransactionContextInterface, id string, intendedOwner string, deadlineUnix int64) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: EscrowAssetWithDeadline - ID: %s, Intended Owner: %s, Deadline: %d =====", id, intendedOwner, deadlineUnix)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+	if err := validateOwner(intendedOwner); err != nil {
+		logf(ctx, "ERROR: Invalid intended owner: %v", err)
+		return err
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if deadlineUnix <= now.Unix() {
+		logf(ctx, "ERROR: Deadline %d is not in the future", deadlineUnix)
+		return fmt.Errorf("escrow deadline must be in the future")
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read asset %s: %v", id, err)
+		return err
+	}
+	if !clientActsAs(ctx, asset.Owner) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not escrow asset %s owned by %s", id, asset.Owner)
+			return fmt.Errorf("only the owner may escrow asset %s: %w", id, ErrNotOwner)
+		}
+	}
+	if err := checkNotLocked(asset); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if asset.EscrowOwner != "" {
+		logf(ctx, "ERROR: Asset %s is already in escrow for %s", id, asset.EscrowOwner)
+		return fmt.Errorf("asset %s is already in escrow for %s", id, asset.EscrowOwner)
+	}
+	if asset.PendingOwner != "" {
+		logf(ctx, "ERROR: Asset %s has a pending transfer to %s", id, asset.PendingOwner)
+		return fmt.Errorf("asset %s has a pending transfer to %s", id, asset.PendingOwner)
+	}
+	if asset.Owner == intendedOwner {
+		logf(ctx, "ERROR: Asset %s is already owned by %s", id, intendedOwner)
+		return fmt.Errorf("asset %s is already owned by %s", id, intendedOwner)
+	}
+	if err := checkRecipientAllowed(asset, intendedOwner); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	asset.EscrowOwner = intendedOwner
+	asset.EscrowDeadline = time.Unix(deadlineUnix, 0).UTC()
+	stampUpdate(ctx, asset, now, clientID)
+
+	if err := s.putEscrowedAsset(ctx, asset, "escrow"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "AssetEscrowed", map[string]interface{}{
+		"assetID":       id,
+		"owner":         asset.Owner,
+		"intendedOwner": intendedOwner,
+		"deadline":      deadlineUnix,
+		"escrowedBy":    clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Asset %s is in escrow for %s", id, intendedOwner)
+	logln(ctx, "===== END: EscrowAssetWithDeadline =====")
+	return nil
+}
+
+// anyPrice disables the price check of acceptTransfer
+const anyPrice = -1
+
+// AcceptTransfer completes a transfer proposed with ProposeTransfer or an
+// escrowed transfer. Only the proposed or intended owner may accept, and an
+// escrow only before its deadline has passed.
+func (s *AssetContract) AcceptTransfer(ctx contractapi.TransactionContextInterface, id string) error {
+	return s.acceptTransfer(ctx, id, anyPrice)
+}
+
+// AcceptTransferAtPrice accepts a transfer like AcceptTransfer, but only if the
+// asset is still appraised at agreedValue. This keeps the seller from changing
+// the value between the proposal and the acceptance.
+func (s *AssetContract) AcceptTransferAtPrice(ctx contractapi.TransactionContextInterface, id string, agreedValue int) error {
+	if agreedValue < 0 {
+		logf(ctx, "ERROR: Invalid agreed value %d", agreedValue)
+		return fmt.Errorf("agreed value cannot be negative: %w", ErrInvalidInput)
+	}
+	return s.acceptTransfer(ctx, id, agreedValue)
+}
+
+func (s *AssetContract) acceptTransfer(ctx contractapi.TransactionContextInterface, id string, agreedValue int) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: AcceptTransfer - ID: %s, Agreed Value: %d =====", id, agreedValue)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read asset %s: %v", id, err)
+		return err
+	}
+	if err := checkNotLocked(asset); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if agreedValue != anyPrice && asset.AppraisedValue != agreedValue {
+		logf(ctx, "ERROR: Asset %s is appraised at %d, agreed %d", id, asset.AppraisedValue, agreedValue)
+		return fmt.Errorf("asset %s is appraised at %d, not the agreed %d: %w", id, asset.AppraisedValue, agreedValue, ErrPriceMismatch)
+	}
+	if asset.PendingOwner != "" {
+		if err := s.acceptProposedTransfer(ctx, asset); err != nil {
+			return err
+		}
+		logln(ctx, "===== END: AcceptTransfer =====")
+		return nil
+	}
+	if asset.EscrowOwner == "" {
+		logf(ctx, "ERROR: Asset %s has no pending transfer and is not in escrow", id)
+		return fmt.Errorf("asset %s has no pending transfer and is not in escrow", id)
+	}
+	if !clientActsAs(ctx, asset.EscrowOwner) {
+		logf(ctx, "ERROR: Caller may not accept asset %s on behalf of %s", id, asset.EscrowOwner)
+		return fmt.Errorf("only %s may accept asset %s", asset.EscrowOwner, id)
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if !now.Before(asset.EscrowDeadline) {
+		logf(ctx, "ERROR: Escrow of asset %s expired at %s", id, asset.EscrowDeadline)
+		return fmt.Errorf("escrow of asset %s expired at %s", id, asset.EscrowDeadline)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	oldOwner := asset.Owner
+	asset.Owner = asset.EscrowOwner
+	asset.Owners = nil
+	clearEscrow(asset)
+	stampUpdate(ctx, asset, now, clientID)
+
+	if err := s.putEscrowedAsset(ctx, asset, "transfer"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := moveOwnerIndex(ctx, oldOwner, asset.Owner, id); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "AssetTransferred", map[string]interface{}{
+		"assetID":       id,
+		"oldOwner":      oldOwner,
+		"newOwner":      asset.Owner,
+		"transferredBy": clientID,
+		"valueReset":    false,
+		"settledPrice":  asset.AppraisedValue,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Asset %s accepted by %s", id, asset.Owner)
+	logln(ctx, "===== END: AcceptTransfer =====")
+	return nil
+}
+
+// ExpireEscrow releases an escrow whose deadline has passed without acceptance,
+// leaving the asset with its original owner. Anyone may call it.
+func (s *AssetContract) ExpireEscrow(ctx contractapi.TransactionContextInterface, id string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: ExpireEscrow - ID: %s =====", id)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read asset %s: %v", id, err)
+		return err
+	}
+	if asset.EscrowOwner == "" {
+		logf(ctx, "ERROR: Asset %s is not in escrow", id)
+		return fmt.Errorf("asset %s is not in escrow", id)
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if now.Before(asset.EscrowDeadline) {
+		logf(ctx, "ERROR: Escrow of asset %s is open until %s", id, asset.EscrowDeadline)
+		return fmt.Errorf("escrow of asset %s has not expired yet", id)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	intendedOwner := asset.EscrowOwner
+	clearEscrow(asset)
+	stampUpdate(ctx, asset, now, clientID)
+
+	if err := s.putEscrowedAsset(ctx, asset, "escrowExpire"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "AssetEscrowExpired", map[string]interface{}{
+		"assetID":       id,
+		"owner":         asset.Owner,
+		"intendedOwner": intendedOwner,
+		"expiredBy":     clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Escrow of asset %s expired, reverted to %s", id, asset.Owner)
+	logln(ctx, "===== END: ExpireEscrow =====")
+	return nil
+}
+
+// clearEscrow removes any pending escrow from the asset
+func clearEscrow(asset *Asset) {
+	asset.EscrowOwner = ""
+	asset.EscrowDeadline = time.Time{}
+}
+
+// putEscrowedAsset writes an asset changed by the escrow workflow and records
+// the change under operation
+func (s *AssetContract) putEscrowedAsset(ctx contractapi.TransactionContextInterface, asset *Asset, operation string) error {
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		return fmt.Errorf("failed to marshal asset: %w", err)
+	}
+
+	err = ctx.GetStub().PutState(asset.ID, assetJSON)
+	if err != nil {
+		return fmt.Errorf("failed to put asset %s to world state: %w", asset.ID, err)
+	}
+	return recordChange(ctx, asset.ID, operation)
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buyerIdentity belongs to the MSP that escrow tests transfer assets to
+var buyerIdentity = &MockClientIdentity{ID: "x509::CN=User1@org2.example.com", MSPID: "Org2MSP"}
+
+// Test the escrow workflow with an acceptance deadline
+func TestEscrowWithDeadline(t *testing.T) {
+	contract := AssetContract{}
+
+	setup := func(t *testing.T) (*Ledger, int64) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "Org1MSP", AppraisedValue: 300})
+		deadline := ledger.clock.Add(time.Hour).Unix()
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.EscrowAssetWithDeadline(ctx, "asset1", "Org2MSP", deadline)
+		})
+		require.NoError(t, err)
+		return ledger, deadline
+	}
+
+	t.Run("Accept Before Deadline", func(t *testing.T) {
+		ledger, _ := setup(t)
+
+		stub, err := ledger.Invoke(buyerIdentity, func(ctx *MockTransactionContext) error {
+			return contract.AcceptTransfer(ctx, "asset1")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "AssetTransferred", stub.LastEvent().EventName)
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "Org2MSP", asset.Owner)
+		assert.Empty(t, asset.EscrowOwner)
+		assert.True(t, asset.EscrowDeadline.IsZero())
+	})
+
+	t.Run("Only Intended Owner Accepts", func(t *testing.T) {
+		ledger, _ := setup(t)
+
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.AcceptTransfer(ctx, "asset1")
+		})
+		assert.Error(t, err)
+		assert.Equal(t, "Org1MSP", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Expire After Deadline Reverts", func(t *testing.T) {
+		ledger, deadline := setup(t)
+		ledger.clock = time.Unix(deadline, 0).UTC()
+
+		_, err := ledger.Invoke(buyerIdentity, func(ctx *MockTransactionContext) error {
+			return contract.AcceptTransfer(ctx, "asset1")
+		})
+		assert.Error(t, err)
+
+		stub, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.ExpireEscrow(ctx, "asset1")
+		})
+		require.NoError(t, err)
+
+		event := stub.LastEvent()
+		assert.Equal(t, "AssetEscrowExpired", event.EventName)
+		payload := eventData(t, event)
+		assert.Equal(t, "Org2MSP", payload["intendedOwner"])
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "Org1MSP", asset.Owner)
+		assert.Empty(t, asset.EscrowOwner)
+	})
+
+	t.Run("Expire Before Deadline Fails", func(t *testing.T) {
+		ledger, _ := setup(t)
+
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.ExpireEscrow(ctx, "asset1")
+		})
+		assert.Error(t, err)
+		assert.Equal(t, "Org2MSP", readCommitted(t, ledger, "asset1").EscrowOwner)
+	})
+
+	t.Run("Escrowed Asset Cannot Be Transferred", func(t *testing.T) {
+		ledger, _ := setup(t)
+
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.TransferAsset(ctx, "asset1", "Org3MSP")
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Deadline Must Be In Future", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "Org1MSP", AppraisedValue: 300})
+
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.EscrowAssetWithDeadline(ctx, "asset1", "Org2MSP", ledger.clock.Unix())
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Only Owner Escrows", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "Org1MSP", AppraisedValue: 300})
+		deadline := ledger.clock.Add(time.Hour).Unix()
+
+		_, err := ledger.Invoke(buyerIdentity, func(ctx *MockTransactionContext) error {
+			return contract.EscrowAssetWithDeadline(ctx, "asset1", "Org2MSP", deadline)
+		})
+		assert.True(t, errors.Is(err, ErrNotOwner))
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Empty(t, asset.EscrowOwner)
+		assert.Equal(t, "Org1MSP", asset.Owner)
+	})
+
+	t.Run("Locked Asset Cannot Be Escrowed", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "Org1MSP", AppraisedValue: 300, Locked: true})
+
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.EscrowAssetWithDeadline(ctx, "asset1", "Org2MSP", ledger.clock.Add(time.Hour).Unix())
+		})
+		assert.True(t, errors.Is(err, ErrAssetLocked))
+		assert.Empty(t, readCommitted(t, ledger, "asset1").EscrowOwner)
+	})
+}
+
+// Test accepting an escrowed transfer at an agreed price
+func TestAcceptTransferAtPrice(t *testing.T) {
+	contract := AssetContract{}
+
+	setup := func(t *testing.T) *Ledger {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "Org1MSP", AppraisedValue: 300})
+		deadline := ledger.clock.Add(time.Hour).Unix()
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.EscrowAssetWithDeadline(ctx, "asset1", "Org2MSP", deadline)
+		})
+		require.NoError(t, err)
+		return ledger
+	}
+	accept := func(ledger *Ledger, agreedValue int) (*LedgerStub, error) {
+		return ledger.Invoke(buyerIdentity, func(ctx *MockTransactionContext) error {
+			return contract.AcceptTransferAtPrice(ctx, "asset1", agreedValue)
+		})
+	}
+
+	t.Run("Matching Price", func(t *testing.T) {
+		ledger := setup(t)
+
+		stub, err := accept(ledger, 300)
+		require.NoError(t, err)
+		event := stub.LastEvent()
+		assert.Equal(t, "AssetTransferred", event.EventName)
+		assert.Equal(t, float64(300), eventData(t, event)["settledPrice"])
+		assert.Equal(t, "Org2MSP", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Value Changed After Escrow", func(t *testing.T) {
+		ledger := setup(t)
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "blue", 5, "Org1MSP", 350)
+		})
+		require.NoError(t, err)
+
+		_, err = accept(ledger, 300)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrPriceMismatch))
+		assert.Contains(t, err.Error(), "appraised at 350")
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "Org1MSP", asset.Owner)
+		assert.Equal(t, "Org2MSP", asset.EscrowOwner)
+	})
+
+	t.Run("Proposed Transfer Checks Price", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.ProposeTransfer(ctx, "asset1", "Jane")
+		})
+		require.NoError(t, err)
+
+		acceptAs := func(agreedValue int) (*LedgerStub, error) {
+			return ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+				return contract.AcceptTransferAtPrice(ctx, "asset1", agreedValue)
+			})
+		}
+		_, err = acceptAs(250)
+		assert.True(t, errors.Is(err, ErrPriceMismatch))
+
+		stub, err := acceptAs(300)
+		require.NoError(t, err)
+		assert.Equal(t, float64(300), eventData(t, stub.LastEvent())["settledPrice"])
+		assert.Equal(t, "Jane", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Negative Price", func(t *testing.T) {
+		ledger := setup(t)
+
+		_, err := accept(ledger, -1)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+	})
+}
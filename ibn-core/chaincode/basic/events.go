@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// eventLogObjectType is the composite key namespace of the event log (evt~<txid>)
+const eventLogObjectType = "evt"
+
+// EventLogEntry is the persisted copy of the event emitted by a transaction
+type EventLogEntry struct {
+	TxID      string `json:"TxID"`
+	EventName string `json:"EventName"`
+	Payload   string `json:"Payload"`
+}
+
+// silentContext marks a transaction context whose writes must not emit events,
+// used by data maintenance such as migrations so that event consumers are not
+// flooded with one event per rewritten asset
+type silentContext struct {
+	contractapi.TransactionContextInterface
+}
+
+// withoutEvents returns a context that performs the same state writes as ctx
+// but on which setEvent is a no-op
+func withoutEvents(ctx contractapi.TransactionContextInterface) contractapi.TransactionContextInterface {
+	return &silentContext{ctx}
+}
+
+// eventsSuppressed reports whether ctx was created by withoutEvents
+func eventsSuppressed(ctx contractapi.TransactionContextInterface) bool {
+	_, silent := ctx.(*silentContext)
+	return silent
+}
+
+// eventSchemaVersion is the version of the EventEnvelope layout. It changes
+// whenever a field of the envelope or of an event's data is renamed or removed.
+const eventSchemaVersion = 1
+
+// EventEnvelope wraps the data of every chaincode event. Metric is only set
+// while method metrics are enabled.
+type EventEnvelope struct {
+	SchemaVersion int           `json:"schemaVersion"`
+	EventType     string        `json:"eventType"`
+	TxID          string        `json:"txID"`
+	Timestamp     int64         `json:"timestamp"`
+	Data          interface{}   `json:"data"`
+	Metric        *MethodMetric `json:"metric,omitempty"`
+}
+
+// newEventEnvelope marshals data into an envelope stamped with the transaction
+// ID and timestamp, and with the method metric when metrics are enabled
+func newEventEnvelope(ctx contractapi.TransactionContextInterface, eventType string, data interface{}) ([]byte, error) {
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	metric, err := methodMetric(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	envelopeJSON, err := json.Marshal(EventEnvelope{
+		SchemaVersion: eventSchemaVersion,
+		EventType:     eventType,
+		TxID:          ctx.GetStub().GetTxID(),
+		Timestamp:     now.Unix(),
+		Data:          data,
+		Metric:        metric,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+	}
+	return envelopeJSON, nil
+}
+
+// emitEvent wraps data in an EventEnvelope and emits it through setEvent
+func emitEvent(ctx contractapi.TransactionContextInterface, eventType string, data interface{}) error {
+	if eventsSuppressed(ctx) {
+		return nil
+	}
+
+	envelopeJSON, err := newEventEnvelope(ctx, eventType, data)
+	if err != nil {
+		return err
+	}
+	return setEvent(ctx, eventType, envelopeJSON)
+}
+
+// setEvent emits a chaincode event and records it in the event log so that clients
+// which missed it can recover it later. Fabric only delivers the last event set
+// by a transaction, and likewise only the last one is kept in the log.
+// Nothing is emitted or logged on a context returned by withoutEvents, nor
+// while an admin has turned events off. A payload above MaxEventPayloadBytes
+// is an ErrEventTooLarge error, which callers return so that the transaction
+// fails up front rather than being rejected by the orderer or committed
+// without its event.
+func setEvent(ctx contractapi.TransactionContextInterface, name string, payload []byte) error {
+	if eventsSuppressed(ctx) {
+		return nil
+	}
+
+	config, err := getConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if !config.Events {
+		return nil
+	}
+	if len(payload) > config.MaxEventPayloadBytes {
+		return fmt.Errorf("%w: %s event payload of %d bytes exceeds the limit of %d bytes; emit a smaller summary or raise MaxEventPayloadBytes", ErrEventTooLarge, name, len(payload), config.MaxEventPayloadBytes)
+	}
+
+	if err := ctx.GetStub().SetEvent(name, payload); err != nil {
+		return err
+	}
+	if metrics, ok := ctx.(*metricsContext); ok {
+		metrics.eventSet = true
+	}
+
+	txID := ctx.GetStub().GetTxID()
+	key, err := ctx.GetStub().CreateCompositeKey(eventLogObjectType, []string{txID})
+	if err != nil {
+		return fmt.Errorf("failed to create event log key: %w", err)
+	}
+
+	entryJSON, err := json.Marshal(EventLogEntry{TxID: txID, EventName: name, Payload: string(payload)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event log entry: %w", err)
+	}
+
+	if err := ctx.GetStub().PutState(key, entryJSON); err != nil {
+		return fmt.Errorf("failed to write event log entry: %w", err)
+	}
+	return nil
+}
+
+// GetEventLogForTx returns the event that the given committed transaction emitted
+func (s *AssetContract) GetEventLogForTx(ctx contractapi.TransactionContextInterface, txID string) (*EventLogEntry, error) {
+	logf(ctx, "===== START: GetEventLogForTx - TxID: %s =====", txID)
+
+	if txID == "" {
+		logln(ctx, "ERROR: Transaction ID cannot be empty")
+		return nil, fmt.Errorf("transaction ID cannot be empty")
+	}
+
+	key, err := ctx.GetStub().CreateCompositeKey(eventLogObjectType, []string{txID})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to create event log key: %v", err)
+		return nil, fmt.Errorf("failed to create event log key: %w", err)
+	}
+
+	entryJSON, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read event log: %v", err)
+		return nil, fmt.Errorf("failed to read from world state: %w", err)
+	}
+	if entryJSON == nil {
+		logf(ctx, "ERROR: No event logged for transaction %s", txID)
+		return nil, fmt.Errorf("no event logged for transaction %s", txID)
+	}
+
+	var entry EventLogEntry
+	err = json.Unmarshal(entryJSON, &entry)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to unmarshal event log entry: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal event log entry: %w", err)
+	}
+
+	logln(ctx, "===== END: GetEventLogForTx =====")
+	return &entry, nil
+}
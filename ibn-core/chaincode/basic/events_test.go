@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// eventData decodes the envelope of an emitted event and returns its data
+func eventData(t *testing.T, event *peer.ChaincodeEvent) map[string]interface{} {
+	var envelope struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(event.Payload, &envelope))
+	return envelope.Data
+}
+
+// Test the envelope wrapping every event
+func TestEventEnvelope(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+
+	stub, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+		return contract.CreateAsset(ctx, "asset1", "blue", 10, "John", 500)
+	})
+	require.NoError(t, err)
+
+	event := stub.LastEvent()
+	assert.Equal(t, "AssetCreated", event.EventName)
+	var envelope EventEnvelope
+	require.NoError(t, json.Unmarshal(event.Payload, &envelope))
+	assert.Equal(t, eventSchemaVersion, envelope.SchemaVersion)
+	assert.Equal(t, "AssetCreated", envelope.EventType)
+	assert.Equal(t, stub.TxID, envelope.TxID)
+	assert.Equal(t, stub.TxTime.Unix(), envelope.Timestamp)
+
+	data := eventData(t, event)
+	assert.Equal(t, "asset1", data["assetID"])
+	assert.Equal(t, "John", data["owner"])
+	assert.Equal(t, float64(500), data["appraisedValue"])
+}
+
+// Test the persisted event log
+func TestEventLog(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+
+	createStub, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+		return contract.CreateAsset(ctx, "asset1", "blue", 10, "John", 500)
+	})
+	require.NoError(t, err)
+
+	t.Run("Entry Written On Mutation", func(t *testing.T) {
+		key := createCompositeKey(eventLogObjectType, []string{createStub.TxID})
+		entryJSON := ledger.Get(key)
+		require.NotNil(t, entryJSON)
+
+		var entry EventLogEntry
+		require.NoError(t, json.Unmarshal(entryJSON, &entry))
+		assert.Equal(t, "AssetCreated", entry.EventName)
+		assert.Equal(t, string(createStub.LastEvent().Payload), entry.Payload)
+	})
+
+	t.Run("Entry Retrievable By TxID", func(t *testing.T) {
+		var entry *EventLogEntry
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			entry, err = contract.GetEventLogForTx(ctx, createStub.TxID)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, createStub.TxID, entry.TxID)
+		assert.Equal(t, "AssetCreated", entry.EventName)
+	})
+
+	t.Run("Log Does Not Show Up As Asset", func(t *testing.T) {
+		var assets []*Asset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.GetAllAssets(ctx)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Len(t, assets, 1)
+	})
+
+	t.Run("Unknown Transaction", func(t *testing.T) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			_, err := contract.GetEventLogForTx(ctx, "nope")
+			return err
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no event logged")
+	})
+}
+
+// Test that writes through withoutEvents still change state but emit nothing
+func TestWithoutEvents(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+
+	stub, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+		return contract.UpdateAsset(withoutEvents(ctx), "asset1", "red", 5, "John", 300)
+	})
+	require.NoError(t, err)
+	assert.Empty(t, stub.Events)
+	assert.Nil(t, ledger.Get(createCompositeKey(eventLogObjectType, []string{stub.TxID})))
+	assert.Equal(t, "red", readCommitted(t, ledger, "asset1").Color)
+
+	stub, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+		return contract.UpdateAsset(ctx, "asset1", "green", 5, "John", 300)
+	})
+	require.NoError(t, err)
+	assert.Len(t, stub.Events, 1)
+}
+
+// Test turning events off and on through the config
+func TestSetEvents(t *testing.T) {
+	contract := AssetContract{}
+	admin := AdminContract{}
+
+	t.Run("No SetEvent When Disabled", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		key := createCompositeKey(configObjectType, []string{configName})
+		stub.On("GetState", key).Return([]byte(`{"Events":false}`), nil)
+
+		// The stub has no SetEvent or PutState expectations, so emitting would fail the test
+		err := emitEvent(ctx, "AssetCreated", map[string]interface{}{"assetID": "asset1"})
+		require.NoError(t, err)
+		stub.AssertNotCalled(t, "SetEvent", mock.Anything, mock.Anything)
+		stub.AssertExpectations(t)
+	})
+
+	ledger := NewLedger()
+	ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+	setEvents := func(on bool) error {
+		_, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return admin.SetEvents(ctx, on)
+		})
+		return err
+	}
+
+	t.Run("Only Admins May Toggle", func(t *testing.T) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return admin.SetEvents(ctx, false)
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Writes Without Events", func(t *testing.T) {
+		require.NoError(t, setEvents(false))
+
+		stub, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "red", 5, "John", 300)
+		})
+		require.NoError(t, err)
+		assert.Empty(t, stub.Events)
+		assert.Nil(t, ledger.Get(createCompositeKey(eventLogObjectType, []string{stub.TxID})))
+		assert.Equal(t, "red", readCommitted(t, ledger, "asset1").Color)
+	})
+
+	t.Run("Events Turned Back On", func(t *testing.T) {
+		require.NoError(t, setEvents(true))
+
+		stub, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "green", 5, "John", 300)
+		})
+		require.NoError(t, err)
+		require.Len(t, stub.Events, 1)
+		assert.Equal(t, "AssetUpdated", stub.LastEvent().EventName)
+	})
+}
+
+// Test the event payload size guard
+func TestEventPayloadSizeGuard(t *testing.T) {
+	contract := AssetContract{}
+	admin := AdminContract{}
+	ledger := NewLedger()
+
+	t.Run("Oversized Payload Rejected", func(t *testing.T) {
+		ids := make([]string, 0, 10000)
+		for i := 0; i < cap(ids); i++ {
+			ids = append(ids, fmt.Sprintf("asset%d", i))
+		}
+
+		var emitErr error
+		stub, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			emitErr = emitEvent(ctx, "AssetsPurged", map[string]interface{}{"assetIDs": ids})
+			return nil
+		})
+		require.NoError(t, err)
+		require.Error(t, emitErr)
+		assert.True(t, errors.Is(emitErr, ErrEventTooLarge))
+		assert.Contains(t, emitErr.Error(), "AssetsPurged event payload of")
+		assert.Contains(t, emitErr.Error(), fmt.Sprintf("exceeds the limit of %d bytes", defaultMaxEventPayloadBytes))
+		assert.Empty(t, stub.Events)
+		assert.Nil(t, ledger.Get(createCompositeKey(eventLogObjectType, []string{stub.TxID})))
+	})
+
+	t.Run("Configurable Limit", func(t *testing.T) {
+		_, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return admin.SetMaxEventPayloadSize(ctx, 64)
+		})
+		require.NoError(t, err)
+
+		// The transaction fails rather than committing without its event
+		stub, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.CreateAsset(ctx, "asset1", "blue", 10, "John", 500)
+		})
+		assert.True(t, errors.Is(err, ErrEventTooLarge))
+		assert.Empty(t, stub.Events)
+		assert.Nil(t, ledger.Get("asset1"))
+	})
+
+	t.Run("Limit Must Be Positive", func(t *testing.T) {
+		_, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return admin.SetMaxEventPayloadSize(ctx, 0)
+		})
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+	})
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// isExpired reports whether the asset has an expiry at or before now. Assets
+// without an ExpiresAt never expire.
+func isExpired(asset *Asset, now time.Time) bool {
+	return !asset.ExpiresAt.IsZero() && !now.Before(asset.ExpiresAt)
+}
+
+// CreateAssetWithExpiry creates an asset like CreateAsset that expires at the
+// given Unix time, e.g. for a time-limited entitlement
+func (s *AssetContract) CreateAssetWithExpiry(ctx contractapi.TransactionContextInterface, id string, color string, size int, owner string, appraisedValue int, expiresAtUnix int64) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: CreateAssetWithExpiry - ID: %s, Expires At: %d =====", id, expiresAtUnix)
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if expiresAtUnix <= now.Unix() {
+		logf(ctx, "ERROR: Expiry %d is not in the future", expiresAtUnix)
+		return fmt.Errorf("expiry must be in the future: %w", ErrInvalidInput)
+	}
+
+	if err := s.createAsset(ctx, id, color, size, owner, appraisedValue, time.Unix(expiresAtUnix, 0).UTC(), false); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Asset %s expires at %d", id, expiresAtUnix)
+	logln(ctx, "===== END: CreateAssetWithExpiry =====")
+	return nil
+}
+
+// ReadAssetChecked returns the asset like ReadAsset, but an asset whose expiry
+// has passed at the transaction time is an ErrAssetExpired error
+func (s *AssetContract) ReadAssetChecked(ctx contractapi.TransactionContextInterface, id string) (*Asset, error) {
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if isExpired(asset, now) {
+		return nil, fmt.Errorf("the asset %s expired at %s: %w", asset.ID, asset.ExpiresAt.Format(time.RFC3339), ErrAssetExpired)
+	}
+
+	return asset, nil
+}
+
+// GetAllAssetsWithExpiryOption returns all assets like GetAllAssets and, when
+// excludeExpired is set, leaves out those expired at the transaction time
+func (s *AssetContract) GetAllAssetsWithExpiryOption(ctx contractapi.TransactionContextInterface, excludeExpired bool) ([]*Asset, error) {
+	logf(ctx, "===== START: GetAllAssetsWithExpiryOption - Exclude Expired: %t =====", excludeExpired)
+
+	assets, err := s.GetAllAssets(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+	if !excludeExpired {
+		logln(ctx, "===== END: GetAllAssetsWithExpiryOption =====")
+		return assets, nil
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	current := []*Asset{}
+	for _, asset := range assets {
+		if !isExpired(asset, now) {
+			current = append(current, asset)
+		}
+	}
+
+	logf(ctx, "INFO: Excluded %d expired assets", len(assets)-len(current))
+	logln(ctx, "===== END: GetAllAssetsWithExpiryOption =====")
+	return current, nil
+}
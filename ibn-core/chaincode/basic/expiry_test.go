@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test expiring assets against the transaction time
+func TestCreateAssetWithExpiry(t *testing.T) {
+	contract := AssetContract{}
+
+	setup := func(t *testing.T) (*Ledger, int64) {
+		ledger := NewLedger()
+		expiresAt := ledger.clock.Add(time.Hour).Unix()
+		stub, err := ledger.Invoke(defaultIdentity, func(ctx *MockTransactionContext) error {
+			return contract.CreateAssetWithExpiry(ctx, "pass1", "blue", 5, "John", 300, expiresAt)
+		})
+		require.NoError(t, err)
+		event := stub.LastEvent()
+		assert.Equal(t, "AssetCreated", event.EventName)
+		assert.EqualValues(t, expiresAt, eventData(t, event)["expiresAt"])
+		_, err = ledger.Invoke(defaultIdentity, func(ctx *MockTransactionContext) error {
+			return contract.CreateAsset(ctx, "asset1", "red", 5, "John", 300)
+		})
+		require.NoError(t, err)
+		return ledger, expiresAt
+	}
+
+	t.Run("Not Yet Expired", func(t *testing.T) {
+		ledger, expiresAt := setup(t)
+
+		var asset *Asset
+		_, err := ledger.Invoke(defaultIdentity, func(ctx *MockTransactionContext) error {
+			var err error
+			asset, err = contract.ReadAssetChecked(ctx, "pass1")
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt, asset.ExpiresAt.Unix())
+		assert.Equal(t, 1, asset.Version)
+
+		var assets []*Asset
+		_, err = ledger.Invoke(defaultIdentity, func(ctx *MockTransactionContext) error {
+			var err error
+			assets, err = contract.GetAllAssetsWithExpiryOption(ctx, true)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Len(t, assets, 2)
+	})
+
+	t.Run("Past Expiry", func(t *testing.T) {
+		ledger, expiresAt := setup(t)
+		ledger.clock = time.Unix(expiresAt, 0).UTC()
+
+		_, err := ledger.Invoke(defaultIdentity, func(ctx *MockTransactionContext) error {
+			_, err := contract.ReadAssetChecked(ctx, "pass1")
+			return err
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrAssetExpired))
+		assert.Contains(t, err.Error(), "asset expired")
+
+		// Assets without an expiry never expire
+		_, err = ledger.Invoke(defaultIdentity, func(ctx *MockTransactionContext) error {
+			_, err := contract.ReadAssetChecked(ctx, "asset1")
+			return err
+		})
+		require.NoError(t, err)
+
+		var current, all []*Asset
+		_, err = ledger.Invoke(defaultIdentity, func(ctx *MockTransactionContext) error {
+			var err error
+			if current, err = contract.GetAllAssetsWithExpiryOption(ctx, true); err != nil {
+				return err
+			}
+			all, err = contract.GetAllAssetsWithExpiryOption(ctx, false)
+			return err
+		})
+		require.NoError(t, err)
+		require.Len(t, current, 1)
+		assert.Equal(t, "asset1", current[0].ID)
+		assert.Len(t, all, 2)
+	})
+
+	t.Run("Expiry In The Past", func(t *testing.T) {
+		ledger := NewLedger()
+
+		_, err := ledger.Invoke(defaultIdentity, func(ctx *MockTransactionContext) error {
+			return contract.CreateAssetWithExpiry(ctx, "pass1", "blue", 5, "John", 300, ledger.clock.Unix())
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Nil(t, ledger.Get("pass1"))
+	})
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// maxExportAssets caps the number of assets a single export may contain
+const maxExportAssets = 1000
+
+// HoldingsManifest describes an export so the receiving party can verify it.
+// AssetsHash is the hex SHA-256 of the JSON encoding of the exported assets
+// array, which is sorted by asset ID.
+type HoldingsManifest struct {
+	Owner      string `json:"Owner"`
+	Timestamp  int64  `json:"Timestamp"`
+	AssetCount int    `json:"AssetCount"`
+	AssetsHash string `json:"AssetsHash"`
+}
+
+// HoldingsExport is the verifiable export of an owner's holdings
+type HoldingsExport struct {
+	Manifest HoldingsManifest `json:"Manifest"`
+	Assets   []*Asset         `json:"Assets"`
+}
+
+// ExportOwnerHoldings returns all assets of an owner together with a manifest
+// covering their count and content hash
+func (s *AssetContract) ExportOwnerHoldings(ctx contractapi.TransactionContextInterface, owner string) (*HoldingsExport, error) {
+	logf(ctx, "===== START: ExportOwnerHoldings - Owner: %s =====", owner)
+
+	if err := validateOwner(owner); err != nil {
+		logf(ctx, "ERROR: Invalid owner: %v", err)
+		return nil, err
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	assets, err := s.QueryAssetsByOwner(ctx, owner)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get assets for owner %s: %v", owner, err)
+		return nil, err
+	}
+	if len(assets) > maxExportAssets {
+		logf(ctx, "ERROR: Owner %s holds %d assets, more than %d", owner, len(assets), maxExportAssets)
+		return nil, fmt.Errorf("owner %s holds more than %d assets, too many to export at once", owner, maxExportAssets)
+	}
+	if assets == nil {
+		assets = []*Asset{}
+	}
+
+	sort.Slice(assets, func(i, j int) bool {
+		return assets[i].ID < assets[j].ID
+	})
+
+	assetsJSON, err := json.Marshal(assets)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to marshal assets: %v", err)
+		return nil, fmt.Errorf("failed to marshal assets: %w", err)
+	}
+	digest := sha256.Sum256(assetsJSON)
+
+	export := &HoldingsExport{
+		Manifest: HoldingsManifest{
+			Owner:      owner,
+			Timestamp:  now.Unix(),
+			AssetCount: len(assets),
+			AssetsHash: hex.EncodeToString(digest[:]),
+		},
+		Assets: assets,
+	}
+
+	logf(ctx, "INFO: Exported %d assets of owner %s", len(assets), owner)
+	logln(ctx, "===== END: ExportOwnerHoldings =====")
+	return export, nil
+}
+
+// csvHeader is the header row of ExportAssetsCSV
+var csvHeader = []string{"ID", "Color", "Size", "Owner", "AppraisedValue", "Category", "CreatedAt", "UpdatedAt", "Version"}
+
+// csvCell neutralizes a text value that a spreadsheet would evaluate as a
+// formula by prefixing it with a single quote
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// ExportAssetsCSV returns all assets as CSV with a header row and one row per
+// asset in ID order. Fields containing commas, quotes or line breaks are
+// quoted as in RFC 4180, and text starting with =, +, - or @ is prefixed with
+// a single quote so that spreadsheets do not run it as a formula.
+func (s *AssetContract) ExportAssetsCSV(ctx contractapi.TransactionContextInterface) (string, error) {
+	logln(ctx, "===== START: ExportAssetsCSV =====")
+
+	resultsIterator, err := ctx.GetStub().GetStateByRange("", "")
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get state by range: %v", err)
+		return "", fmt.Errorf("failed to get state by range: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(csvHeader); err != nil {
+		logf(ctx, "ERROR: Failed to write CSV header: %v", err)
+		return "", fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	count := 0
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate results: %v", err)
+			return "", fmt.Errorf("failed to iterate results: %w", err)
+		}
+
+		var asset Asset
+		err = json.Unmarshal(queryResponse.Value, &asset)
+		if err != nil {
+			logf(ctx, "WARNING: Failed to unmarshal asset, skipping: %v", err)
+			continue
+		}
+		if count == maxExportAssets {
+			logf(ctx, "ERROR: More than %d assets to export", maxExportAssets)
+			return "", fmt.Errorf("more than %d assets, too many to export at once", maxExportAssets)
+		}
+
+		err = writer.Write([]string{
+			csvCell(asset.ID),
+			csvCell(asset.Color),
+			strconv.Itoa(asset.Size),
+			csvCell(asset.Owner),
+			strconv.Itoa(asset.AppraisedValue),
+			csvCell(asset.Category),
+			asset.CreatedAt.Format(time.RFC3339),
+			asset.UpdatedAt.Format(time.RFC3339),
+			strconv.Itoa(asset.Version),
+		})
+		if err != nil {
+			logf(ctx, "ERROR: Failed to write CSV row for %s: %v", asset.ID, err)
+			return "", fmt.Errorf("failed to write CSV row for %s: %w", asset.ID, err)
+		}
+		count++
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		logf(ctx, "ERROR: Failed to write CSV: %v", err)
+		return "", fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	logf(ctx, "INFO: Exported %d assets as CSV", count)
+	logln(ctx, "===== END: ExportAssetsCSV =====")
+	return buf.String(), nil
+}
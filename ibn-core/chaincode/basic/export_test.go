@@ -0,0 +1,134 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test ExportOwnerHoldings manifest
+func TestExportOwnerHoldings(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset3", Color: "green", Size: 15, Owner: "John", AppraisedValue: 700},
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300},
+		Asset{ID: "asset2", Color: "red", Size: 10, Owner: "Jane", AppraisedValue: 500},
+	)
+
+	export := func(owner string) (*HoldingsExport, *LedgerStub, error) {
+		var result *HoldingsExport
+		stub, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			result, err = contract.ExportOwnerHoldings(ctx, owner)
+			return err
+		})
+		return result, stub, err
+	}
+
+	first, stub, err := export("John")
+	require.NoError(t, err)
+	assert.Equal(t, "John", first.Manifest.Owner)
+	assert.Equal(t, stub.TxTime.Unix(), first.Manifest.Timestamp)
+	assert.Equal(t, 2, first.Manifest.AssetCount)
+	require.Len(t, first.Assets, 2)
+	assert.Equal(t, "asset1", first.Assets[0].ID)
+	assert.Equal(t, "asset3", first.Assets[1].ID)
+
+	t.Run("Hash Matches Content", func(t *testing.T) {
+		assetsJSON, err := json.Marshal(first.Assets)
+		require.NoError(t, err)
+		digest := sha256.Sum256(assetsJSON)
+		assert.Equal(t, hex.EncodeToString(digest[:]), first.Manifest.AssetsHash)
+	})
+
+	t.Run("Hash Is Stable", func(t *testing.T) {
+		second, _, err := export("John")
+		require.NoError(t, err)
+		assert.Equal(t, first.Manifest.AssetsHash, second.Manifest.AssetsHash)
+	})
+
+	t.Run("Hash Changes With Content", func(t *testing.T) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "blue", 5, "John", 301)
+		})
+		require.NoError(t, err)
+		changed, _, err := export("John")
+		require.NoError(t, err)
+		assert.NotEqual(t, first.Manifest.AssetsHash, changed.Manifest.AssetsHash)
+	})
+
+	t.Run("Owner Without Assets", func(t *testing.T) {
+		empty, _, err := export("Nobody")
+		require.NoError(t, err)
+		assert.Equal(t, 0, empty.Manifest.AssetCount)
+		assert.NotNil(t, empty.Assets)
+	})
+
+	t.Run("Invalid Owner", func(t *testing.T) {
+		_, _, err := export("")
+		assert.Error(t, err)
+	})
+}
+
+// Test ExportAssetsCSV quoting
+func TestExportAssetsCSV(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset2", Color: "red", Size: 10, Owner: `Smith, "Jr"`, AppraisedValue: 500, Version: 3},
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300, Version: 1},
+	)
+
+	var export string
+	_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+		export, err = contract.ExportAssetsCSV(ctx)
+		return err
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(export, "\n"), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "ID,Color,Size,Owner,AppraisedValue,Category,CreatedAt,UpdatedAt,Version", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "asset1,blue,5,John,300,"))
+	assert.True(t, strings.HasPrefix(lines[2], `asset2,red,10,"Smith, ""Jr""",500,`))
+	assert.True(t, strings.HasSuffix(lines[2], ",3"))
+
+	records, err := csv.NewReader(strings.NewReader(export)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, `Smith, "Jr"`, records[2][3])
+}
+
+// Test that ExportAssetsCSV neutralizes spreadsheet formulas
+func TestExportAssetsCSVFormulas(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "=HYPERLINK(\"http://x\")", Size: 5, Owner: "+1", AppraisedValue: 300},
+		Asset{ID: "asset2", Color: "@SUM(A1)", Size: 5, Owner: "-2", AppraisedValue: 300},
+		Asset{ID: "asset3", Color: "blue", Size: 5, Owner: "John-Smith", AppraisedValue: 300},
+	)
+
+	var export string
+	_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+		export, err = contract.ExportAssetsCSV(ctx)
+		return err
+	})
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(strings.NewReader(export)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, `'=HYPERLINK("http://x")`, records[1][1])
+	assert.Equal(t, "'+1", records[1][3])
+	assert.Equal(t, "'@SUM(A1)", records[2][1])
+	assert.Equal(t, "'-2", records[2][3])
+	assert.Equal(t, "blue", records[3][1])
+	assert.Equal(t, "John-Smith", records[3][3])
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// SetAssetParent makes parentID the parent of the asset with the given id.
+// The parent must exist, and an asset cannot become its own ancestor.
+func (s *AssetContract) SetAssetParent(ctx contractapi.TransactionContextInterface, id string, parentID string) error {
+	id = normalizeAssetID(id)
+	parentID = normalizeAssetID(parentID)
+	logf(ctx, "===== START: SetAssetParent - ID: %s, Parent: %s =====", id, parentID)
+
+	if err := requireAttribute(ctx, writerAttribute, "true"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+	if err := validateAssetID(parentID); err != nil {
+		logf(ctx, "ERROR: Invalid parent ID: %v", err)
+		return err
+	}
+	if id == parentID {
+		logf(ctx, "ERROR: Asset %s cannot be its own parent", id)
+		return fmt.Errorf("asset %s cannot be its own parent", id)
+	}
+
+	exists, err := s.AssetExists(ctx, parentID)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to check parent existence: %v", err)
+		return fmt.Errorf("failed to check parent existence: %w", err)
+	}
+	if !exists {
+		logf(ctx, "ERROR: Parent asset %s does not exist", parentID)
+		return fmt.Errorf("the parent asset %s does not exist: %w", parentID, ErrAssetNotFound)
+	}
+	if err := s.checkNotAncestor(ctx, id, parentID); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := s.updateParent(ctx, id, parentID); err != nil {
+		return err
+	}
+
+	logln(ctx, "===== END: SetAssetParent =====")
+	return nil
+}
+
+// ClearParent removes the parent reference of an asset, typically to repair an
+// orphan whose parent was deleted
+func (s *AssetContract) ClearParent(ctx contractapi.TransactionContextInterface, id string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: ClearParent - ID: %s =====", id)
+
+	if err := requireAttribute(ctx, writerAttribute, "true"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+
+	if err := s.updateParent(ctx, id, ""); err != nil {
+		return err
+	}
+
+	logln(ctx, "===== END: ClearParent =====")
+	return nil
+}
+
+// FindOrphanChildren returns assets whose ParentID references an asset that no
+// longer exists in the world state
+func (s *AssetContract) FindOrphanChildren(ctx contractapi.TransactionContextInterface) ([]*Asset, error) {
+	logln(ctx, "===== START: FindOrphanChildren =====")
+
+	assets, err := s.GetAllAssets(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get assets: %v", err)
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(assets))
+	for _, asset := range assets {
+		existing[asset.ID] = true
+	}
+
+	orphans := []*Asset{}
+	for _, asset := range assets {
+		if asset.ParentID != "" && !existing[asset.ParentID] {
+			orphans = append(orphans, asset)
+		}
+	}
+
+	logf(ctx, "INFO: Found %d orphaned child assets", len(orphans))
+	logln(ctx, "===== END: FindOrphanChildren =====")
+	return orphans, nil
+}
+
+// checkNotAncestor walks the parent chain up from parentID and returns an
+// error if it reaches id, which would make the hierarchy a cycle. The walk
+// stops at a root or at an orphan whose parent no longer exists.
+func (s *AssetContract) checkNotAncestor(ctx contractapi.TransactionContextInterface, id string, parentID string) error {
+	visited := map[string]bool{}
+	for current := parentID; current != ""; {
+		if current == id {
+			return fmt.Errorf("asset %s is an ancestor of %s and cannot become its child: %w", id, parentID, ErrInvalidInput)
+		}
+		if visited[current] {
+			return fmt.Errorf("the parent chain of %s already contains a cycle at %s", parentID, current)
+		}
+		visited[current] = true
+
+		asset, err := s.ReadAsset(ctx, current)
+		if errors.Is(err, ErrAssetNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		current = asset.ParentID
+	}
+	return nil
+}
+
+// updateParent rewrites the ParentID of an asset and emits AssetParentChanged.
+// Only the owner or an admin may change the parent of an unlocked asset.
+func (s *AssetContract) updateParent(ctx contractapi.TransactionContextInterface, id string, parentID string) error {
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if !clientActsAs(ctx, asset.Owner) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not change the parent of asset %s owned by %s", id, asset.Owner)
+			return fmt.Errorf("only the owner or an admin may change the parent of asset %s: %w", id, ErrNotOwner)
+		}
+	}
+	if err := checkNotLocked(asset); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if parentID == "" && asset.ParentID == "" {
+		logf(ctx, "ERROR: Asset %s has no parent", id)
+		return fmt.Errorf("asset %s has no parent", id)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	oldParentID := asset.ParentID
+	asset.ParentID = parentID
+	stampUpdate(ctx, asset, now, clientID)
+
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+		return fmt.Errorf("failed to marshal asset: %w", err)
+	}
+
+	err = ctx.GetStub().PutState(id, assetJSON)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to update asset parent: %v", err)
+		return fmt.Errorf("failed to update asset parent: %w", err)
+	}
+	if err := recordChange(ctx, id, "parentChange"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "AssetParentChanged", map[string]interface{}{
+		"assetID":     id,
+		"oldParentID": oldParentID,
+		"newParentID": parentID,
+		"updatedBy":   clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Parent of asset %s changed from %q to %q", id, oldParentID, parentID)
+	return nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test orphan detection for parent-child assets
+func TestOrphanChildren(t *testing.T) {
+	contract := AssetContract{}
+
+	newLedger := func() *Ledger {
+		ledger := NewLedger()
+		ledger.Seed(
+			Asset{ID: "parent1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500},
+			Asset{ID: "child1", Color: "blue", Size: 1, Owner: "John", AppraisedValue: 50, ParentID: "parent1"},
+			Asset{ID: "child2", Color: "red", Size: 1, Owner: "John", AppraisedValue: 50, ParentID: "gone"},
+			Asset{ID: "loose", Color: "red", Size: 1, Owner: "Jane", AppraisedValue: 50},
+		)
+		return ledger
+	}
+
+	t.Run("Find Orphans", func(t *testing.T) {
+		ledger := newLedger()
+		var orphans []*Asset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			orphans, err = contract.FindOrphanChildren(ctx)
+			return err
+		})
+		require.NoError(t, err)
+		require.Len(t, orphans, 1)
+		assert.Equal(t, "child2", orphans[0].ID)
+	})
+
+	t.Run("Clear Parent Repairs Orphan", func(t *testing.T) {
+		ledger := newLedger()
+		stub, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.ClearParent(ctx, "child2")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "", readCommitted(t, ledger, "child2").ParentID)
+		assert.Equal(t, "AssetParentChanged", stub.LastEvent().EventName)
+
+		var orphans []*Asset
+		_, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			orphans, err = contract.FindOrphanChildren(ctx)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Empty(t, orphans)
+	})
+
+	t.Run("Clear Parent Without Parent", func(t *testing.T) {
+		ledger := newLedger()
+		_, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.ClearParent(ctx, "loose")
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "has no parent")
+	})
+
+	t.Run("Re-parent Orphan", func(t *testing.T) {
+		ledger := newLedger()
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SetAssetParent(ctx, "child2", "parent1")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "parent1", readCommitted(t, ledger, "child2").ParentID)
+	})
+
+	t.Run("Parent Must Exist", func(t *testing.T) {
+		ledger := newLedger()
+		_, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.SetAssetParent(ctx, "loose", "missing")
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "does not exist")
+	})
+
+	t.Run("Self Parent Rejected", func(t *testing.T) {
+		ledger := newLedger()
+		_, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.SetAssetParent(ctx, "loose", "loose")
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Cycle Rejected", func(t *testing.T) {
+		ledger := newLedger()
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SetAssetParent(ctx, "parent1", "child1")
+		})
+		assert.ErrorIs(t, err, ErrInvalidInput)
+		assert.Equal(t, "", readCommitted(t, ledger, "parent1").ParentID)
+
+		// A longer chain is rejected as well: grandchild -> child1 -> parent1
+		_, err = ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SetAssetParent(ctx, "child2", "child1")
+		})
+		require.NoError(t, err)
+		_, err = ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SetAssetParent(ctx, "parent1", "child2")
+		})
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+
+	t.Run("Requires Owner Or Admin", func(t *testing.T) {
+		ledger := newLedger()
+		_, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.SetAssetParent(ctx, "child2", "parent1")
+		})
+		assert.ErrorIs(t, err, ErrNotOwner)
+		_, err = ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.ClearParent(ctx, "child1")
+		})
+		assert.ErrorIs(t, err, ErrNotOwner)
+	})
+
+	t.Run("Requires Writer", func(t *testing.T) {
+		ledger := newLedger()
+		_, err := ledger.Invoke(buyerIdentity, func(ctx *MockTransactionContext) error {
+			return contract.ClearParent(ctx, "child2")
+		})
+		assert.Error(t, err)
+		assert.Equal(t, "gone", readCommitted(t, ledger, "child2").ParentID)
+	})
+
+	t.Run("Locked Asset Rejected", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(
+			Asset{ID: "parent1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500},
+			Asset{ID: "child1", Color: "blue", Size: 1, Owner: "John", AppraisedValue: 50, Locked: true},
+		)
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SetAssetParent(ctx, "child1", "parent1")
+		})
+		assert.ErrorIs(t, err, ErrAssetLocked)
+	})
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// ValuePoint is one point of an asset's appraised value time series
+type ValuePoint struct {
+	Timestamp      time.Time `json:"Timestamp"`
+	AppraisedValue int       `json:"AppraisedValue"`
+}
+
+// GetAssetValueTrend returns the appraised value of an asset over its history,
+// oldest first. Consecutive entries with an unchanged value are collapsed into
+// the first of them, and deletions are skipped.
+func (s *AssetContract) GetAssetValueTrend(ctx contractapi.TransactionContextInterface, id string) ([]ValuePoint, error) {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: GetAssetValueTrend - ID: %s =====", id)
+
+	history, err := s.GetAssetHistory(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get history for asset %s: %v", id, err)
+		return nil, err
+	}
+
+	// Peers may return history newest first, so order it explicitly
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Timestamp.Before(history[j].Timestamp)
+	})
+
+	trend := []ValuePoint{}
+	for _, entry := range history {
+		if entry.IsDelete {
+			continue
+		}
+		if n := len(trend); n > 0 && trend[n-1].AppraisedValue == entry.Asset.AppraisedValue {
+			continue
+		}
+		trend = append(trend, ValuePoint{Timestamp: entry.Timestamp, AppraisedValue: entry.Asset.AppraisedValue})
+	}
+
+	logf(ctx, "INFO: Collapsed %d history entries into %d value points for asset %s", len(history), len(trend), id)
+	logln(ctx, "===== END: GetAssetValueTrend =====")
+	return trend, nil
+}
+
+// OwnershipChange is one change of an asset's owner
+type OwnershipChange struct {
+	FromOwner string    `json:"FromOwner"`
+	ToOwner   string    `json:"ToOwner"`
+	TxID      string    `json:"TxID"`
+	Timestamp time.Time `json:"Timestamp"`
+	By        string    `json:"By"`
+}
+
+// GetOwnershipTrail returns the changes of an asset's owner over its history,
+// oldest first. The creation and updates that keep the owner are skipped, as
+// is the creation of a new asset under the ID of a deleted one.
+func (s *AssetContract) GetOwnershipTrail(ctx contractapi.TransactionContextInterface, id string) ([]OwnershipChange, error) {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: GetOwnershipTrail - ID: %s =====", id)
+
+	history, err := s.GetAssetHistory(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get history for asset %s: %v", id, err)
+		return nil, err
+	}
+
+	// Peers may return history newest first, so order it explicitly
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Timestamp.Before(history[j].Timestamp)
+	})
+
+	trail := []OwnershipChange{}
+	owner := ""
+	for _, entry := range history {
+		if entry.IsDelete {
+			owner = ""
+			continue
+		}
+		if owner != "" && entry.Asset.Owner != owner {
+			trail = append(trail, OwnershipChange{
+				FromOwner: owner,
+				ToOwner:   entry.Asset.Owner,
+				TxID:      entry.TxID,
+				Timestamp: entry.Timestamp,
+				By:        entry.Asset.UpdatedBy,
+			})
+		}
+		owner = entry.Asset.Owner
+	}
+
+	logf(ctx, "INFO: Found %d ownership changes in %d history entries for asset %s", len(trail), len(history), id)
+	logln(ctx, "===== END: GetOwnershipTrail =====")
+	return trail, nil
+}
+
+// maxVolatilityCandidates bounds how many asset histories FindVolatileAssets walks
+const maxVolatilityCandidates = 100
+
+// VolatileAsset describes the latest appraised value change of an asset
+type VolatileAsset struct {
+	AssetID       string  `json:"AssetID"`
+	PreviousValue int     `json:"PreviousValue"`
+	CurrentValue  int     `json:"CurrentValue"`
+	ChangePercent float64 `json:"ChangePercent"`
+}
+
+// FindVolatileAssets returns the candidate assets, given as a JSON array of IDs,
+// whose two most recent appraised values differ by more than percentThreshold
+// percent of the earlier one. Assets with fewer than two values or whose earlier
+// value is zero are skipped, as their relative change is undefined.
+func (s *AssetContract) FindVolatileAssets(ctx contractapi.TransactionContextInterface, percentThreshold float64, idsJSON string) ([]*VolatileAsset, error) {
+	logf(ctx, "===== START: FindVolatileAssets - Threshold: %.2f%%, IDs: %s =====", percentThreshold, idsJSON)
+
+	if math.IsNaN(percentThreshold) || math.IsInf(percentThreshold, 0) || percentThreshold <= 0 {
+		logf(ctx, "ERROR: Invalid threshold %v", percentThreshold)
+		return nil, fmt.Errorf("percent threshold must be a positive number")
+	}
+
+	var ids []string
+	if err := json.Unmarshal([]byte(idsJSON), &ids); err != nil {
+		logf(ctx, "ERROR: Invalid candidate IDs: %v", err)
+		return nil, fmt.Errorf("candidate IDs must be a JSON array of asset IDs: %w", err)
+	}
+	if len(ids) > maxVolatilityCandidates {
+		logf(ctx, "ERROR: %d candidates exceed the limit", len(ids))
+		return nil, fmt.Errorf("at most %d candidate assets may be checked at once", maxVolatilityCandidates)
+	}
+
+	volatile := []*VolatileAsset{}
+	for _, id := range ids {
+		trend, err := s.GetAssetValueTrend(ctx, id)
+		if err != nil {
+			logf(ctx, "ERROR: %v", err)
+			return nil, err
+		}
+		if len(trend) < 2 {
+			continue
+		}
+
+		previous := trend[len(trend)-2].AppraisedValue
+		current := trend[len(trend)-1].AppraisedValue
+		if previous == 0 {
+			continue
+		}
+		change := float64(current-previous) / float64(previous) * 100
+		if math.Abs(change) > percentThreshold {
+			volatile = append(volatile, &VolatileAsset{
+				AssetID:       normalizeAssetID(id),
+				PreviousValue: previous,
+				CurrentValue:  current,
+				ChangePercent: change,
+			})
+		}
+	}
+
+	logf(ctx, "INFO: %d of %d candidate assets exceed %.2f%%", len(volatile), len(ids), percentThreshold)
+	logln(ctx, "===== END: FindVolatileAssets =====")
+	return volatile, nil
+}
+
+// GetAssetHistoryInRange returns the history entries of an asset whose
+// timestamp lies within [fromUnix, toUnix]
+func (s *AssetContract) GetAssetHistoryInRange(ctx contractapi.TransactionContextInterface, id string, fromUnix int64, toUnix int64) ([]AssetHistory, error) {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: GetAssetHistoryInRange - ID: %s, From: %d, To: %d =====", id, fromUnix, toUnix)
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return nil, err
+	}
+	if fromUnix < 0 || toUnix < fromUnix {
+		logf(ctx, "ERROR: Invalid window [%d, %d]", fromUnix, toUnix)
+		return nil, fmt.Errorf("invalid time window: from must be non-negative and not after to")
+	}
+
+	from := time.Unix(fromUnix, 0)
+	// The window includes every instant of its last second
+	end := time.Unix(toUnix+1, 0)
+	history, err := readAssetHistory(ctx, id, func(timestamp time.Time) bool {
+		return !timestamp.Before(from) && timestamp.Before(end)
+	})
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Retrieved %d history entries for asset %s in window", len(history), id)
+	logln(ctx, "===== END: GetAssetHistoryInRange =====")
+	return history, nil
+}
+
+// maxRecentHistory bounds the number of entries GetRecentAssetHistory returns
+const maxRecentHistory = 1000
+
+// GetRecentAssetHistory returns the last limit history entries of an asset in
+// the same oldest-to-newest order as GetAssetHistory. The history iterator
+// cannot be read backwards, so the whole history is walked and trimmed.
+func (s *AssetContract) GetRecentAssetHistory(ctx contractapi.TransactionContextInterface, id string, limit int) ([]AssetHistory, error) {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: GetRecentAssetHistory - ID: %s, Limit: %d =====", id, limit)
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return nil, err
+	}
+	if limit <= 0 || limit > maxRecentHistory {
+		logf(ctx, "ERROR: Invalid limit %d", limit)
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxRecentHistory)
+	}
+
+	history, err := readAssetHistory(ctx, id, func(time.Time) bool { return true })
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+
+	logf(ctx, "INFO: Retrieved %d recent history entries for asset %s", len(history), id)
+	logln(ctx, "===== END: GetRecentAssetHistory =====")
+	return history, nil
+}
+
+// AssetChange lists the fields that one transaction changed on an asset. A
+// delete is recorded as a tombstone without field changes.
+type AssetChange struct {
+	TxID      string                 `json:"TxID"`
+	Timestamp time.Time              `json:"Timestamp"`
+	Tombstone bool                   `json:"Tombstone"`
+	Changes   map[string]FieldChange `json:"Changes"`
+}
+
+// GetAssetChangeLog walks the history of an asset oldest to newest and diffs
+// each version against the one before it. The first version, and the first
+// after a delete, is diffed against an empty asset, so its changes are the
+// initial field values.
+func (s *AssetContract) GetAssetChangeLog(ctx contractapi.TransactionContextInterface, id string) ([]*AssetChange, error) {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: GetAssetChangeLog - ID: %s =====", id)
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return nil, err
+	}
+
+	history, err := readAssetHistory(ctx, id, func(time.Time) bool { return true })
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	changeLog := make([]*AssetChange, 0, len(history))
+	previous := &Asset{}
+	for i := range history {
+		entry := &history[i]
+		change := &AssetChange{TxID: entry.TxID, Timestamp: entry.Timestamp}
+		if entry.IsDelete {
+			change.Tombstone = true
+			change.Changes = map[string]FieldChange{}
+			previous = &Asset{}
+		} else {
+			change.Changes = diffAssets(previous, &entry.Asset)
+			previous = &entry.Asset
+		}
+		changeLog = append(changeLog, change)
+	}
+
+	logf(ctx, "INFO: Built %d change log entries for asset %s", len(changeLog), id)
+	logln(ctx, "===== END: GetAssetChangeLog =====")
+	return changeLog, nil
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test GetAssetValueTrend over a history with several value changes
+func TestGetAssetValueTrend(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+
+	invoke := func(fn func(ctx *MockTransactionContext) error) {
+		_, err := ledger.Invoke(ownerIdentity("John"), fn)
+		require.NoError(t, err)
+	}
+	invoke(func(ctx *MockTransactionContext) error {
+		return contract.CreateAsset(ctx, "asset1", "blue", 5, "John", 100)
+	})
+	for _, value := range []int{200, 200, 150} {
+		value := value
+		invoke(func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "blue", 5, "John", value)
+		})
+	}
+	// Changes to other fields do not add points
+	invoke(func(ctx *MockTransactionContext) error {
+		return contract.TransferAsset(ctx, "asset1", "Jane")
+	})
+	invoke(func(ctx *MockTransactionContext) error {
+		return contract.UpdateAsset(ctx, "asset1", "blue", 5, "Jane", 300)
+	})
+
+	var trend []ValuePoint
+	invoke(func(ctx *MockTransactionContext) (err error) {
+		trend, err = contract.GetAssetValueTrend(ctx, "asset1")
+		return err
+	})
+
+	var values []int
+	for i, point := range trend {
+		values = append(values, point.AppraisedValue)
+		if i > 0 {
+			assert.True(t, point.Timestamp.After(trend[i-1].Timestamp))
+		}
+	}
+	assert.Equal(t, []int{100, 200, 150, 300}, values)
+
+	t.Run("Unknown Asset Has Empty Trend", func(t *testing.T) {
+		invoke(func(ctx *MockTransactionContext) (err error) {
+			trend, err = contract.GetAssetValueTrend(ctx, "missing")
+			return err
+		})
+		assert.Empty(t, trend)
+	})
+}
+
+// Test GetOwnershipTrail over a create, an update and two transfers
+func TestGetOwnershipTrail(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+
+	steps := []struct {
+		identity *MockClientIdentity
+		fn       func(ctx *MockTransactionContext) error
+	}{
+		{defaultIdentity, func(ctx *MockTransactionContext) error {
+			return contract.CreateAsset(ctx, "asset1", "blue", 5, "John", 300)
+		}},
+		{defaultIdentity, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "red", 5, "John", 300)
+		}},
+		{ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.TransferAsset(ctx, "asset1", "Jane")
+		}},
+		{ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.TransferAsset(ctx, "asset1", "Max")
+		}},
+	}
+	var txIDs []string
+	for _, step := range steps {
+		stub, err := ledger.Invoke(step.identity, step.fn)
+		require.NoError(t, err)
+		txIDs = append(txIDs, stub.TxID)
+	}
+
+	var trail []OwnershipChange
+	_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+		trail, err = contract.GetOwnershipTrail(ctx, "asset1")
+		return err
+	})
+	require.NoError(t, err)
+
+	require.Len(t, trail, 2)
+	assert.Equal(t, "John", trail[0].FromOwner)
+	assert.Equal(t, "Jane", trail[0].ToOwner)
+	assert.Equal(t, txIDs[2], trail[0].TxID)
+	assert.Equal(t, ownerIdentity("John").ID, trail[0].By)
+	assert.Equal(t, "Jane", trail[1].FromOwner)
+	assert.Equal(t, "Max", trail[1].ToOwner)
+	assert.Equal(t, txIDs[3], trail[1].TxID)
+	assert.Equal(t, ownerIdentity("Jane").ID, trail[1].By)
+	assert.True(t, trail[0].Timestamp.Before(trail[1].Timestamp))
+}
+
+// Test FindVolatileAssets over histories with and without large value swings
+func TestFindVolatileAssets(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+
+	history := func(id string, values ...int) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.CreateAsset(ctx, id, "blue", 5, "John", values[0])
+		})
+		require.NoError(t, err)
+		for _, value := range values[1:] {
+			value := value
+			_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+				return contract.UpdateAsset(ctx, id, "blue", 5, "John", value)
+			})
+			require.NoError(t, err)
+		}
+	}
+	history("stable", 1000, 1050, 1100)
+	history("spike", 1000, 1000, 1600)
+	history("crash", 100, 1000, 400)
+	history("single", 500)
+	history("fromzero", 0, 700)
+
+	find := func(threshold float64, idsJSON string) ([]*VolatileAsset, error) {
+		var result []*VolatileAsset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			result, err = contract.FindVolatileAssets(ctx, threshold, idsJSON)
+			return err
+		})
+		return result, err
+	}
+
+	result, err := find(25, `["stable", "spike", "crash", "single", "fromzero", "missing"]`)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, VolatileAsset{AssetID: "spike", PreviousValue: 1000, CurrentValue: 1600, ChangePercent: 60}, *result[0])
+	assert.Equal(t, VolatileAsset{AssetID: "crash", PreviousValue: 1000, CurrentValue: 400, ChangePercent: -60}, *result[1])
+
+	t.Run("Higher Threshold", func(t *testing.T) {
+		result, err := find(60, `["spike", "crash"]`)
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		_, err := find(0, `["spike"]`)
+		assert.Error(t, err)
+		_, err = find(-5, `["spike"]`)
+		assert.Error(t, err)
+		_, err = find(10, `spike`)
+		assert.Error(t, err)
+	})
+}
+
+// Test GetAssetHistoryInRange with entries before, inside and after the window
+func TestGetAssetHistoryInRange(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+
+	// updateAt commits an update whose transaction timestamp is exactly at
+	updateAt := func(at time.Time, value int) {
+		ledger.clock = at.Add(-time.Second)
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "blue", 5, "John", value)
+		})
+		require.NoError(t, err)
+	}
+	q3Start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	q4Start := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	ledger.clock = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 100})
+	updateAt(q3Start, 200)
+	updateAt(time.Date(2024, 9, 30, 23, 59, 59, 0, time.UTC), 300)
+	updateAt(q4Start, 400)
+
+	inRange := func(from, to int64) ([]AssetHistory, error) {
+		var history []AssetHistory
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			history, err = contract.GetAssetHistoryInRange(ctx, "asset1", from, to)
+			return err
+		})
+		return history, err
+	}
+
+	t.Run("Only Entries In Window", func(t *testing.T) {
+		history, err := inRange(q3Start.Unix(), q4Start.Unix()-1)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		values := []int{history[0].Asset.AppraisedValue, history[1].Asset.AppraisedValue}
+		assert.ElementsMatch(t, []int{200, 300}, values)
+	})
+
+	t.Run("Empty Window", func(t *testing.T) {
+		history, err := inRange(q3Start.Unix()+1, q3Start.Unix()+60)
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+
+	t.Run("Invalid Window", func(t *testing.T) {
+		_, err := inRange(q4Start.Unix(), q3Start.Unix())
+		assert.Error(t, err)
+		_, err = inRange(-1, q3Start.Unix())
+		assert.Error(t, err)
+	})
+}
+
+// Test GetRecentAssetHistory trimming to the newest entries
+func TestGetRecentAssetHistory(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 100})
+	for value := 101; value <= 114; value++ {
+		value := value
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "blue", 5, "John", value)
+		})
+		require.NoError(t, err)
+	}
+
+	recent := func(limit int) ([]AssetHistory, error) {
+		var history []AssetHistory
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			history, err = contract.GetRecentAssetHistory(ctx, "asset1", limit)
+			return err
+		})
+		return history, err
+	}
+
+	t.Run("Last Five Of Fifteen", func(t *testing.T) {
+		history, err := recent(5)
+		require.NoError(t, err)
+		require.Len(t, history, 5)
+		for i, entry := range history {
+			assert.Equal(t, 110+i, entry.Asset.AppraisedValue)
+		}
+	})
+
+	t.Run("Limit Above History Length", func(t *testing.T) {
+		history, err := recent(maxRecentHistory)
+		require.NoError(t, err)
+		assert.Len(t, history, 15)
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		_, err := recent(0)
+		assert.Error(t, err)
+		_, err = recent(maxRecentHistory + 1)
+		assert.Error(t, err)
+	})
+}
+
+// Test GetAssetChangeLog over a create, a color change, a transfer and a delete
+func TestGetAssetChangeLog(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+
+	invoke := func(fn func(ctx *MockTransactionContext) error) {
+		_, err := ledger.Invoke(ownerIdentity("John"), fn)
+		require.NoError(t, err)
+	}
+	invoke(func(ctx *MockTransactionContext) error {
+		return contract.CreateAsset(ctx, "asset1", "blue", 5, "John", 100)
+	})
+	invoke(func(ctx *MockTransactionContext) error {
+		return contract.UpdateAssetFields(ctx, "asset1", `{"Color": "red"}`)
+	})
+	invoke(func(ctx *MockTransactionContext) error {
+		return contract.TransferAsset(ctx, "asset1", "Jane")
+	})
+	_, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+		return contract.DeleteAsset(ctx, "asset1")
+	})
+	require.NoError(t, err)
+
+	var changeLog []*AssetChange
+	_, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+		changeLog, err = contract.GetAssetChangeLog(ctx, "asset1")
+		return err
+	})
+	require.NoError(t, err)
+	require.Len(t, changeLog, 4)
+
+	created := changeLog[0].Changes
+	assert.Equal(t, FieldChange{Before: "", After: "blue"}, created["Color"])
+	assert.Equal(t, FieldChange{Before: "", After: "John"}, created["Owner"])
+
+	recolored := changeLog[1].Changes
+	assert.Equal(t, FieldChange{Before: "blue", After: "red"}, recolored["Color"])
+	assert.NotContains(t, recolored, "Owner")
+
+	transferred := changeLog[2].Changes
+	assert.Equal(t, FieldChange{Before: "John", After: "Jane"}, transferred["Owner"])
+	assert.NotContains(t, transferred, "Color")
+
+	assert.True(t, changeLog[3].Tombstone)
+	assert.Empty(t, changeLog[3].Changes)
+	for _, change := range changeLog[:3] {
+		assert.False(t, change.Tombstone)
+		assert.NotEmpty(t, change.TxID)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// AssetHold annotates an asset with the holds currently restricting it
+type AssetHold struct {
+	Asset         *Asset `json:"Asset"`
+	Locked        bool   `json:"Locked"`
+	EscrowPending bool   `json:"EscrowPending"`
+	EscrowOwner   string `json:"EscrowOwner"`
+}
+
+// Held reports whether any hold currently applies to the asset
+func (h *AssetHold) Held() bool {
+	return h.Locked || h.EscrowPending
+}
+
+// GetOwnerAssetHolds returns the assets of an owner annotated with their hold
+// state: a lock or a pending escrow
+func (s *AssetContract) GetOwnerAssetHolds(ctx contractapi.TransactionContextInterface, owner string) ([]*AssetHold, error) {
+	logf(ctx, "===== START: GetOwnerAssetHolds - Owner: %s =====", owner)
+
+	if err := validateOwner(owner); err != nil {
+		logf(ctx, "ERROR: Invalid owner: %v", err)
+		return nil, err
+	}
+
+	assets, err := s.QueryAssetsByOwner(ctx, owner)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get assets for owner %s: %v", owner, err)
+		return nil, err
+	}
+
+	holds := make([]*AssetHold, 0, len(assets))
+	held := 0
+	for _, asset := range assets {
+		hold := &AssetHold{
+			Asset:         asset,
+			Locked:        asset.Locked,
+			EscrowPending: asset.EscrowOwner != "",
+			EscrowOwner:   asset.EscrowOwner,
+		}
+		if hold.Held() {
+			held++
+		}
+		holds = append(holds, hold)
+	}
+
+	logf(ctx, "INFO: %d of %d assets of owner %s are held", held, len(holds), owner)
+	logln(ctx, "===== END: GetOwnerAssetHolds =====")
+	return holds, nil
+}
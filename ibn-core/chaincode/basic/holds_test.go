@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test GetOwnerAssetHolds over assets in various hold states
+func TestGetOwnerAssetHolds(t *testing.T) {
+	contract := AssetContract{}
+
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "free", Color: "blue", Size: 5, Owner: "Tomoko", AppraisedValue: 300},
+		Asset{ID: "locked", Color: "red", Size: 5, Owner: "Tomoko", AppraisedValue: 300, Locked: true},
+		Asset{ID: "escrowed", Color: "black", Size: 5, Owner: "Tomoko", AppraisedValue: 300, EscrowOwner: "Brad"},
+		Asset{ID: "other", Color: "black", Size: 5, Owner: "Brad", AppraisedValue: 300, Locked: true},
+	)
+
+	t.Run("Annotates Hold States", func(t *testing.T) {
+		var holds []*AssetHold
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			holds, err = contract.GetOwnerAssetHolds(ctx, "Tomoko")
+			return err
+		})
+		require.NoError(t, err)
+		require.Len(t, holds, 3)
+
+		byID := map[string]*AssetHold{}
+		for _, hold := range holds {
+			byID[hold.Asset.ID] = hold
+		}
+		assert.False(t, byID["free"].Held())
+		assert.True(t, byID["locked"].Locked)
+		assert.True(t, byID["escrowed"].EscrowPending)
+		assert.Equal(t, "Brad", byID["escrowed"].EscrowOwner)
+	})
+
+	t.Run("Invalid Owner", func(t *testing.T) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			_, err := contract.GetOwnerAssetHolds(ctx, "")
+			return err
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// maxClientInfoAttributes bounds how many attributes GetClientInfo looks up
+const maxClientInfoAttributes = 32
+
+// ClientInfo describes the identity the peer sees for the caller
+type ClientInfo struct {
+	MSPID      string            `json:"MSPID"`
+	ClientID   string            `json:"ClientID"`
+	Attributes map[string]string `json:"Attributes"`
+}
+
+// GetClientInfo returns the caller's MSP ID, enrolled ID and the values of the
+// certificate attributes named in attributesJSON, a JSON array of names that
+// may be empty. Attributes absent from the certificate are left out of the map.
+// It does not access the world state.
+func (s *AssetContract) GetClientInfo(ctx contractapi.TransactionContextInterface, attributesJSON string) (*ClientInfo, error) {
+	logf(ctx, "===== START: GetClientInfo - Attributes: %s =====", attributesJSON)
+
+	var names []string
+	if attributesJSON != "" {
+		if err := json.Unmarshal([]byte(attributesJSON), &names); err != nil {
+			logf(ctx, "ERROR: Invalid attribute names: %v", err)
+			return nil, fmt.Errorf("attributes must be a JSON array of attribute names: %w", err)
+		}
+	}
+	if len(names) > maxClientInfoAttributes {
+		logf(ctx, "ERROR: %d attributes exceed the limit", len(names))
+		return nil, fmt.Errorf("at most %d attributes may be requested at once", maxClientInfoAttributes)
+	}
+
+	identity := ctx.GetClientIdentity()
+	mspID, err := identity.GetMSPID()
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get client MSP ID: %v", err)
+		return nil, fmt.Errorf("failed to get client MSP ID: %w", err)
+	}
+	clientID, err := identity.GetID()
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get client identity: %v", err)
+		return nil, fmt.Errorf("failed to get client identity: %w", err)
+	}
+
+	info := &ClientInfo{
+		MSPID:      mspID,
+		ClientID:   clientID,
+		Attributes: make(map[string]string, len(names)),
+	}
+	for _, name := range names {
+		value, found, err := identity.GetAttributeValue(name)
+		if err != nil {
+			logf(ctx, "ERROR: Failed to read attribute %s: %v", name, err)
+			return nil, fmt.Errorf("failed to read attribute %s: %w", name, err)
+		}
+		if found {
+			info.Attributes[name] = value
+		}
+	}
+
+	logf(ctx, "INFO: Client %s of %s with %d of %d requested attributes", clientID, mspID, len(info.Attributes), len(names))
+	logln(ctx, "===== END: GetClientInfo =====")
+	return info, nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test GetClientInfo against a mocked client identity
+func TestGetClientInfo(t *testing.T) {
+	contract := AssetContract{}
+	identity := &MockClientIdentity{
+		ID:         "x509::CN=User1@org2.example.com",
+		MSPID:      "Org2MSP",
+		Attributes: map[string]string{"role": "auditor", "admin": "false"},
+	}
+	// The stub has no expectations, so any state access would panic
+	stub := new(MockStub)
+	ctx := &MockTransactionContext{stub: stub, identity: identity}
+
+	t.Run("Requested Attributes", func(t *testing.T) {
+		info, err := contract.GetClientInfo(ctx, `["role", "missing"]`)
+		require.NoError(t, err)
+		assert.Equal(t, "Org2MSP", info.MSPID)
+		assert.Equal(t, "x509::CN=User1@org2.example.com", info.ClientID)
+		assert.Equal(t, map[string]string{"role": "auditor"}, info.Attributes)
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("No Attributes", func(t *testing.T) {
+		info, err := contract.GetClientInfo(ctx, "")
+		require.NoError(t, err)
+		assert.NotNil(t, info.Attributes)
+		assert.Empty(t, info.Attributes)
+	})
+
+	t.Run("Invalid Attribute List", func(t *testing.T) {
+		_, err := contract.GetClientInfo(ctx, `"role"`)
+		assert.Error(t, err)
+	})
+}
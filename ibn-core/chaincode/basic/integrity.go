@@ -0,0 +1,125 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// GetAssetStateHash returns the hex encoded SHA-256 digest of the asset JSON as
+// stored in the world state. Clients can keep the digest and compare it with a
+// later one to detect any modification of the asset in between.
+func (s *AssetContract) GetAssetStateHash(ctx contractapi.TransactionContextInterface, id string) (string, error) {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: GetAssetStateHash - ID: %s =====", id)
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return "", err
+	}
+
+	assetJSON, err := ctx.GetStub().GetState(id)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read asset %s: %v", id, err)
+		return "", fmt.Errorf("failed to read from world state: %w", err)
+	}
+	if assetJSON == nil {
+		logf(ctx, "ERROR: Asset %s does not exist", id)
+		return "", fmt.Errorf("the asset %s does not exist: %w", id, ErrAssetNotFound)
+	}
+
+	digest := sha256.Sum256(assetJSON)
+
+	logln(ctx, "===== END: GetAssetStateHash =====")
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// deletionReceiptObjectType is the composite key namespace of deletion receipts (deleted~<id>)
+const deletionReceiptObjectType = "deleted"
+
+// DeletionReceipt proves that an asset existed without retaining its data
+type DeletionReceipt struct {
+	AssetID   string `json:"AssetID"`
+	StateHash string `json:"StateHash"`
+	DeletedBy string `json:"DeletedBy"`
+	DeletedAt int64  `json:"DeletedAt"`
+	TxID      string `json:"TxID"`
+}
+
+// writeDeletionReceipt records the SHA-256 digest of the current state of an
+// asset that is about to be hard deleted. It must be called before DelState.
+func writeDeletionReceipt(ctx contractapi.TransactionContextInterface, id string, deletedBy string) error {
+	assetJSON, err := ctx.GetStub().GetState(id)
+	if err != nil {
+		return fmt.Errorf("failed to read from world state: %w", err)
+	}
+	if assetJSON == nil {
+		return fmt.Errorf("the asset %s does not exist: %w", id, ErrAssetNotFound)
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256(assetJSON)
+	receiptJSON, err := json.Marshal(DeletionReceipt{
+		AssetID:   id,
+		StateHash: hex.EncodeToString(digest[:]),
+		DeletedBy: deletedBy,
+		DeletedAt: now.Unix(),
+		TxID:      ctx.GetStub().GetTxID(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal deletion receipt: %w", err)
+	}
+
+	key, err := ctx.GetStub().CreateCompositeKey(deletionReceiptObjectType, []string{id})
+	if err != nil {
+		return fmt.Errorf("failed to create deletion receipt key: %w", err)
+	}
+	if err := ctx.GetStub().PutState(key, receiptJSON); err != nil {
+		return fmt.Errorf("failed to write deletion receipt: %w", err)
+	}
+	return nil
+}
+
+// GetDeletionReceipt returns the receipt written when an asset was hard deleted.
+// If an ID was deleted more than once, the receipt of the latest deletion is kept.
+func (s *AssetContract) GetDeletionReceipt(ctx contractapi.TransactionContextInterface, id string) (*DeletionReceipt, error) {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: GetDeletionReceipt - ID: %s =====", id)
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return nil, err
+	}
+
+	key, err := ctx.GetStub().CreateCompositeKey(deletionReceiptObjectType, []string{id})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to create deletion receipt key: %v", err)
+		return nil, fmt.Errorf("failed to create deletion receipt key: %w", err)
+	}
+
+	receiptJSON, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read deletion receipt: %v", err)
+		return nil, fmt.Errorf("failed to read deletion receipt: %w", err)
+	}
+	if receiptJSON == nil {
+		logf(ctx, "ERROR: No deletion receipt for asset %s", id)
+		return nil, fmt.Errorf("no deletion receipt for asset %s", id)
+	}
+
+	var receipt DeletionReceipt
+	if err := json.Unmarshal(receiptJSON, &receipt); err != nil {
+		logf(ctx, "ERROR: Failed to unmarshal deletion receipt: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal deletion receipt: %w", err)
+	}
+
+	logln(ctx, "===== END: GetDeletionReceipt =====")
+	return &receipt, nil
+}
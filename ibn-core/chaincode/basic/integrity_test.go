@@ -0,0 +1,112 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test GetAssetStateHash
+func TestGetAssetStateHash(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+
+	stateHash := func(id string) (string, error) {
+		var hash string
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			hash, err = contract.GetAssetStateHash(ctx, id)
+			return err
+		})
+		return hash, err
+	}
+
+	t.Run("Hash Matches Stored JSON", func(t *testing.T) {
+		hash, err := stateHash("asset1")
+		require.NoError(t, err)
+		digest := sha256.Sum256(ledger.Get("asset1"))
+		assert.Equal(t, hex.EncodeToString(digest[:]), hash)
+	})
+
+	t.Run("Hash Changes After Update", func(t *testing.T) {
+		before, err := stateHash("asset1")
+		require.NoError(t, err)
+		_, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "red", 10, "John", 500)
+		})
+		require.NoError(t, err)
+		after, err := stateHash("asset1")
+		require.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("Asset Does Not Exist", func(t *testing.T) {
+		_, err := stateHash("missing")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "does not exist")
+	})
+}
+
+// Test that hard deletes leave a retrievable deletion receipt
+func TestDeletionReceipt(t *testing.T) {
+	contract := AssetContract{}
+	admin := AdminContract{}
+	ledger := NewLedger()
+	ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+	lastState := sha256.Sum256(ledger.Get("asset1"))
+
+	receipt := func(id string) (*DeletionReceipt, error) {
+		var r *DeletionReceipt
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			r, err = contract.GetDeletionReceipt(ctx, id)
+			return err
+		})
+		return r, err
+	}
+
+	t.Run("No Receipt Before Deletion", func(t *testing.T) {
+		_, err := receipt("asset1")
+		assert.Error(t, err)
+	})
+
+	t.Run("DeleteAsset Writes Receipt", func(t *testing.T) {
+		stub, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.DeleteAsset(ctx, "asset1")
+		})
+		require.NoError(t, err)
+		assert.Nil(t, ledger.Get("asset1"))
+
+		r, err := receipt("asset1")
+		require.NoError(t, err)
+		assert.Equal(t, DeletionReceipt{
+			AssetID:   "asset1",
+			StateHash: hex.EncodeToString(lastState[:]),
+			DeletedBy: ownerIdentity("John").ID,
+			DeletedAt: stub.TxTime.Unix(),
+			TxID:      stub.TxID,
+		}, *r)
+	})
+
+	t.Run("Purge Writes Receipt", func(t *testing.T) {
+		ledger.Seed(Asset{ID: "asset2", Color: "red", Size: 10, Owner: "Jane", AppraisedValue: 600})
+		_, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.SoftDeleteAsset(ctx, "asset2")
+		})
+		require.NoError(t, err)
+		softDeleted := sha256.Sum256(ledger.Get("asset2"))
+
+		_, err = ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			_, err := admin.PurgeDeletedAssets(ctx, 0)
+			return err
+		})
+		require.NoError(t, err)
+
+		r, err := receipt("asset2")
+		require.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(softDeleted[:]), r.StateHash)
+		assert.Equal(t, adminIdentity.ID, r.DeletedBy)
+	})
+}
@@ -0,0 +1,684 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// errMVCCReadConflict mirrors the MVCC_READ_CONFLICT validation code a peer
+// assigns when a transaction read a key that was modified before it committed.
+var errMVCCReadConflict = errors.New("MVCC_READ_CONFLICT")
+
+// MockClientIdentity is a static client identity for tests
+type MockClientIdentity struct {
+	ID         string
+	MSPID      string
+	Attributes map[string]string
+}
+
+func (m *MockClientIdentity) GetID() (string, error) {
+	return m.ID, nil
+}
+
+func (m *MockClientIdentity) GetMSPID() (string, error) {
+	return m.MSPID, nil
+}
+
+func (m *MockClientIdentity) GetAttributeValue(attrName string) (string, bool, error) {
+	value, found := m.Attributes[attrName]
+	return value, found, nil
+}
+
+func (m *MockClientIdentity) AssertAttributeValue(attrName, attrValue string) error {
+	value, found := m.Attributes[attrName]
+	if !found {
+		return fmt.Errorf("attribute '%s' was not found", attrName)
+	}
+	if value != attrValue {
+		return fmt.Errorf("attribute '%s' equals '%s', not '%s'", attrName, value, attrValue)
+	}
+	return nil
+}
+
+func (m *MockClientIdentity) GetX509Certificate() (*x509.Certificate, error) {
+	return nil, nil
+}
+
+// defaultIdentity is used when a test context does not set one explicitly
+var defaultIdentity = &MockClientIdentity{
+	ID:         "x509::CN=User1@org1.example.com",
+	MSPID:      "Org1MSP",
+	Attributes: map[string]string{writerAttribute: "true"},
+}
+
+// ownerIdentity returns a writer identity whose owner attribute lets it act
+// on behalf of owner
+func ownerIdentity(owner string) *MockClientIdentity {
+	return &MockClientIdentity{
+		ID:         "x509::CN=" + owner + "@org1.example.com",
+		MSPID:      "Org1MSP",
+		Attributes: map[string]string{writerAttribute: "true", "owner": owner},
+	}
+}
+
+// historyRecord is one committed modification of a key
+type historyRecord struct {
+	txID      string
+	timestamp time.Time
+	value     []byte
+	isDelete  bool
+}
+
+// versionedValue is a committed value together with the version that wrote it
+type versionedValue struct {
+	value   []byte
+	version uint64
+}
+
+// Ledger is an in-memory model of the committed world state of a channel.
+// Transactions are simulated against it through LedgerStub and only become
+// visible once committed, mirroring the endorse/validate/commit split of a
+// real peer including MVCC read-set validation.
+type Ledger struct {
+	state   map[string]versionedValue
+	history map[string][]historyRecord
+	version uint64
+	clock   time.Time
+	txSeq   int
+}
+
+// NewLedger returns an empty ledger whose clock starts at a fixed instant
+func NewLedger() *Ledger {
+	return &Ledger{
+		state:   make(map[string]versionedValue),
+		history: make(map[string][]historyRecord),
+		clock:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// BeginTx starts simulating a new transaction against the current committed state.
+// Each transaction advances the ledger clock by one second.
+func (l *Ledger) BeginTx(txID string) *LedgerStub {
+	l.txSeq++
+	if txID == "" {
+		txID = fmt.Sprintf("tx%d", l.txSeq)
+	}
+	l.clock = l.clock.Add(time.Second)
+	return &LedgerStub{
+		ledger:   l,
+		TxID:     txID,
+		TxTime:   l.clock,
+		readSet:  make(map[string]uint64),
+		writeSet: make(map[string][]byte),
+		deletes:  make(map[string]bool),
+	}
+}
+
+// Commit validates the read set of a simulated transaction against the
+// committed state and applies its write set. A stale read results in
+// errMVCCReadConflict and leaves the ledger untouched.
+func (l *Ledger) Commit(stub *LedgerStub) error {
+	for key, readVersion := range stub.readSet {
+		if l.state[key].version != readVersion {
+			return fmt.Errorf("%w: key %s read at version %d, committed version is %d",
+				errMVCCReadConflict, key, readVersion, l.state[key].version)
+		}
+	}
+
+	l.version++
+	for _, key := range stub.writeOrder {
+		if stub.deletes[key] {
+			delete(l.state, key)
+			l.history[key] = append(l.history[key], historyRecord{txID: stub.TxID, timestamp: stub.TxTime, isDelete: true})
+			continue
+		}
+		value := stub.writeSet[key]
+		l.state[key] = versionedValue{value: value, version: l.version}
+		l.history[key] = append(l.history[key], historyRecord{txID: stub.TxID, timestamp: stub.TxTime, value: value})
+	}
+	return nil
+}
+
+// Invoke simulates fn in a new transaction as the given identity and commits it
+// when fn succeeds. The stub is returned so callers can inspect events.
+func (l *Ledger) Invoke(identity *MockClientIdentity, fn func(ctx *MockTransactionContext) error) (*LedgerStub, error) {
+	stub := l.BeginTx("")
+	ctx := &MockTransactionContext{stub: stub, identity: identity}
+	if err := fn(ctx); err != nil {
+		return stub, err
+	}
+	return stub, l.Commit(stub)
+}
+
+// Seed commits the given assets directly, bypassing the contract
+func (l *Ledger) Seed(assets ...Asset) {
+	stub := l.BeginTx("")
+	for _, asset := range assets {
+		assetJSON, _ := json.Marshal(asset)
+		stub.PutState(asset.ID, assetJSON)
+	}
+	if err := l.Commit(stub); err != nil {
+		panic(err)
+	}
+}
+
+// SeedRaw commits a raw value under key, e.g. a record in a legacy format
+func (l *Ledger) SeedRaw(key string, value []byte) {
+	stub := l.BeginTx("")
+	stub.PutState(key, value)
+	if err := l.Commit(stub); err != nil {
+		panic(err)
+	}
+}
+
+// Get returns the committed value for key
+func (l *Ledger) Get(key string) []byte {
+	return l.state[key].value
+}
+
+// sortedKeys returns committed keys in [startKey, endKey) in lexicographic order.
+// An empty endKey means unbounded.
+func (l *Ledger) sortedKeys(startKey, endKey string) []string {
+	var keys []string
+	for key := range l.state {
+		if key < startKey || (endKey != "" && key >= endKey) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LedgerStub simulates a single transaction. Reads always observe the committed
+// state (Fabric has no read-your-own-writes) and are recorded in the read set;
+// writes are buffered in the write set until Ledger.Commit.
+type LedgerStub struct {
+	shim.ChaincodeStubInterface
+
+	ledger     *Ledger
+	TxID       string
+	TxTime     time.Time
+	Function   string
+	Args       []string
+	Transient  map[string][]byte
+	readSet    map[string]uint64
+	writeSet   map[string][]byte
+	deletes    map[string]bool
+	writeOrder []string
+	Events     []*peer.ChaincodeEvent
+}
+
+func (s *LedgerStub) GetTxID() string {
+	return s.TxID
+}
+
+func (s *LedgerStub) GetFunctionAndParameters() (string, []string) {
+	return s.Function, s.Args
+}
+
+func (s *LedgerStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
+	return &timestamp.Timestamp{Seconds: s.TxTime.Unix(), Nanos: int32(s.TxTime.Nanosecond())}, nil
+}
+
+func (s *LedgerStub) GetTransient() (map[string][]byte, error) {
+	return s.Transient, nil
+}
+
+func (s *LedgerStub) GetState(key string) ([]byte, error) {
+	committed := s.ledger.state[key]
+	s.readSet[key] = committed.version
+	return committed.value, nil
+}
+
+func (s *LedgerStub) recordWrite(key string) {
+	if _, seen := s.writeSet[key]; !seen && !s.deletes[key] {
+		s.writeOrder = append(s.writeOrder, key)
+	}
+}
+
+func (s *LedgerStub) PutState(key string, value []byte) error {
+	if key == "" {
+		return errors.New("key must not be an empty string")
+	}
+	s.recordWrite(key)
+	delete(s.deletes, key)
+	s.writeSet[key] = value
+	return nil
+}
+
+func (s *LedgerStub) DelState(key string) error {
+	s.recordWrite(key)
+	delete(s.writeSet, key)
+	s.deletes[key] = true
+	return nil
+}
+
+func (s *LedgerStub) SetEvent(name string, payload []byte) error {
+	if name == "" {
+		return errors.New("event name can not be empty string")
+	}
+	s.Events = append(s.Events, &peer.ChaincodeEvent{EventName: name, Payload: payload, TxId: s.TxID})
+	return nil
+}
+
+// LastEvent returns the event that would be delivered for the transaction;
+// Fabric only keeps the last SetEvent call of a transaction.
+func (s *LedgerStub) LastEvent() *peer.ChaincodeEvent {
+	if len(s.Events) == 0 {
+		return nil
+	}
+	return s.Events[len(s.Events)-1]
+}
+
+// Written returns the buffered write for key and whether the key was written
+func (s *LedgerStub) Written(key string) ([]byte, bool) {
+	value, ok := s.writeSet[key]
+	return value, ok
+}
+
+// iterator returns the committed values of keys. Range and partial composite
+// key reads are added to the read set; rich query results are not re-validated
+// by the peer, so those are not.
+func (s *LedgerStub) iterator(keys []string, recordReads bool) *sliceIterator {
+	it := &sliceIterator{}
+	for _, key := range keys {
+		committed := s.ledger.state[key]
+		if recordReads {
+			s.readSet[key] = committed.version
+		}
+		it.kvs = append(it.kvs, &queryresult.KV{Key: key, Value: committed.value})
+	}
+	return it
+}
+
+func (s *LedgerStub) GetStateByRange(startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	var keys []string
+	for _, key := range s.ledger.sortedKeys(startKey, endKey) {
+		// Simple range queries never return composite keys
+		if !strings.HasPrefix(key, compositeKeyNamespace) {
+			keys = append(keys, key)
+		}
+	}
+	return s.iterator(keys, true), nil
+}
+
+func (s *LedgerStub) GetStateByRangeWithPagination(startKey, endKey string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	if bookmark != "" {
+		startKey = bookmark
+	}
+	var keys []string
+	for _, key := range s.ledger.sortedKeys(startKey, endKey) {
+		if !strings.HasPrefix(key, compositeKeyNamespace) {
+			keys = append(keys, key)
+		}
+	}
+	page, next := paginate(keys, pageSize)
+	return s.iterator(page, true), &peer.QueryResponseMetadata{FetchedRecordsCount: int32(len(page)), Bookmark: next}, nil
+}
+
+const compositeKeyNamespace = "\x00"
+
+func (s *LedgerStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
+	return createCompositeKey(objectType, attributes), nil
+}
+
+func createCompositeKey(objectType string, attributes []string) string {
+	key := compositeKeyNamespace + objectType + "\x00"
+	for _, attribute := range attributes {
+		key += attribute + "\x00"
+	}
+	return key
+}
+
+func (s *LedgerStub) SplitCompositeKey(compositeKey string) (string, []string, error) {
+	if !strings.HasPrefix(compositeKey, compositeKeyNamespace) {
+		return "", nil, fmt.Errorf("not a composite key: %q", compositeKey)
+	}
+	parts := strings.Split(strings.TrimSuffix(compositeKey[1:], "\x00"), "\x00")
+	return parts[0], parts[1:], nil
+}
+
+func (s *LedgerStub) GetStateByPartialCompositeKey(objectType string, attributes []string) (shim.StateQueryIteratorInterface, error) {
+	prefix := createCompositeKey(objectType, attributes)
+	return s.iterator(s.ledger.sortedKeys(prefix, prefix+"\U0010FFFF"), true), nil
+}
+
+func (s *LedgerStub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInterface, error) {
+	it := &historyIterator{}
+	for _, record := range s.ledger.history[key] {
+		it.mods = append(it.mods, &queryresult.KeyModification{
+			TxId:      record.txID,
+			Value:     record.value,
+			Timestamp: &timestamp.Timestamp{Seconds: record.timestamp.Unix(), Nanos: int32(record.timestamp.Nanosecond())},
+			IsDelete:  record.isDelete,
+		})
+	}
+	return it, nil
+}
+
+// GetQueryResult evaluates a subset of the CouchDB Mango query language over
+// the committed JSON documents: selectors with the comparison, $in, $exists,
+// $and, $or and $not operators, sort, fields and limit.
+func (s *LedgerStub) GetQueryResult(query string) (shim.StateQueryIteratorInterface, error) {
+	keys, q, err := s.ledger.runQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if q.Limit > 0 && len(keys) > q.Limit {
+		keys = keys[:q.Limit]
+	}
+	return s.projectedIterator(keys, q.Fields), nil
+}
+
+func (s *LedgerStub) GetQueryResultWithPagination(query string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	keys, q, err := s.ledger.runQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bookmark != "" {
+		offset, err := strconv.Atoi(bookmark)
+		if err != nil || offset > len(keys) {
+			return nil, nil, fmt.Errorf("invalid bookmark %q", bookmark)
+		}
+		keys = keys[offset:]
+	}
+	page, _ := paginate(keys, pageSize)
+	next := ""
+	if len(page) > 0 {
+		consumed := len(page)
+		if bookmark != "" {
+			offset, _ := strconv.Atoi(bookmark)
+			consumed += offset
+		}
+		next = strconv.Itoa(consumed)
+	}
+	return s.projectedIterator(page, q.Fields), &peer.QueryResponseMetadata{FetchedRecordsCount: int32(len(page)), Bookmark: next}, nil
+}
+
+func (s *LedgerStub) projectedIterator(keys []string, fields []string) *sliceIterator {
+	it := s.iterator(keys, false)
+	if len(fields) == 0 {
+		return it
+	}
+	for _, kv := range it.kvs {
+		var doc map[string]interface{}
+		json.Unmarshal(kv.Value, &doc)
+		projected := make(map[string]interface{})
+		for _, field := range fields {
+			if value, ok := doc[field]; ok {
+				projected[field] = value
+			}
+		}
+		kv.Value, _ = json.Marshal(projected)
+	}
+	return it
+}
+
+// paginate splits keys into a page of at most pageSize and the key that starts
+// the next page ("" when exhausted)
+func paginate(keys []string, pageSize int32) ([]string, string) {
+	if pageSize <= 0 || int(pageSize) >= len(keys) {
+		return keys, ""
+	}
+	return keys[:pageSize], keys[pageSize]
+}
+
+type mangoQuery struct {
+	Selector map[string]interface{} `json:"selector"`
+	Sort     []map[string]string    `json:"sort"`
+	Fields   []string               `json:"fields"`
+	Limit    int                    `json:"limit"`
+}
+
+func (l *Ledger) runQuery(query string) ([]string, mangoQuery, error) {
+	var q mangoQuery
+	if err := json.Unmarshal([]byte(query), &q); err != nil {
+		return nil, q, fmt.Errorf("invalid query: %v", err)
+	}
+	if q.Selector == nil {
+		return nil, q, errors.New("query must contain a selector")
+	}
+
+	docs := make(map[string]map[string]interface{})
+	var keys []string
+	for _, key := range l.sortedKeys("", "") {
+		var doc map[string]interface{}
+		if err := json.Unmarshal(l.state[key].value, &doc); err != nil {
+			continue
+		}
+		if matchSelector(doc, q.Selector) {
+			docs[key] = doc
+			keys = append(keys, key)
+		}
+	}
+
+	if len(q.Sort) > 0 {
+		sort.SliceStable(keys, func(i, j int) bool {
+			for _, clause := range q.Sort {
+				for field, direction := range clause {
+					c := collate(docs[keys[i]][field], docs[keys[j]][field])
+					if c == 0 {
+						continue
+					}
+					if direction == "desc" {
+						return c > 0
+					}
+					return c < 0
+				}
+			}
+			return false
+		})
+	}
+	return keys, q, nil
+}
+
+func matchSelector(doc map[string]interface{}, selector map[string]interface{}) bool {
+	for field, condition := range selector {
+		switch field {
+		case "$and", "$or":
+			clauses, _ := condition.([]interface{})
+			matched := 0
+			for _, clause := range clauses {
+				sub, _ := clause.(map[string]interface{})
+				if matchSelector(doc, sub) {
+					matched++
+				}
+			}
+			if field == "$and" && matched != len(clauses) {
+				return false
+			}
+			if field == "$or" && matched == 0 {
+				return false
+			}
+		case "$not":
+			sub, _ := condition.(map[string]interface{})
+			if matchSelector(doc, sub) {
+				return false
+			}
+		default:
+			value, present := doc[field]
+			if !matchCondition(value, present, condition) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchCondition(value interface{}, present bool, condition interface{}) bool {
+	operators, ok := condition.(map[string]interface{})
+	if !ok {
+		return present && collate(value, condition) == 0
+	}
+	for op, operand := range operators {
+		switch op {
+		case "$eq":
+			if !present || collate(value, operand) != 0 {
+				return false
+			}
+		case "$ne":
+			if present && collate(value, operand) == 0 {
+				return false
+			}
+		case "$gt":
+			if !present || collate(value, operand) <= 0 {
+				return false
+			}
+		case "$gte":
+			if !present || collate(value, operand) < 0 {
+				return false
+			}
+		case "$lt":
+			if !present || collate(value, operand) >= 0 {
+				return false
+			}
+		case "$lte":
+			if !present || collate(value, operand) > 0 {
+				return false
+			}
+		case "$exists":
+			if want, _ := operand.(bool); want != present {
+				return false
+			}
+		case "$in":
+			candidates, _ := operand.([]interface{})
+			found := false
+			for _, candidate := range candidates {
+				if present && collate(value, candidate) == 0 {
+					found = true
+				}
+			}
+			if !found {
+				return false
+			}
+		case "$elemMatch":
+			elements, _ := value.([]interface{})
+			found := false
+			for _, element := range elements {
+				if matchCondition(element, true, operand) {
+					found = true
+				}
+			}
+			if !found {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// collate orders JSON values following CouchDB collation:
+// null < false < true < numbers < strings < arrays < objects
+func collate(a, b interface{}) int {
+	rank := func(v interface{}) int {
+		switch v.(type) {
+		case nil:
+			return 0
+		case bool:
+			return 1
+		case float64:
+			return 2
+		case string:
+			return 3
+		case []interface{}:
+			return 4
+		default:
+			return 5
+		}
+	}
+	ra, rb := rank(a), rank(b)
+	if ra != rb {
+		return ra - rb
+	}
+	switch av := a.(type) {
+	case bool:
+		bv := b.(bool)
+		if av == bv {
+			return 0
+		}
+		if !av {
+			return -1
+		}
+		return 1
+	case float64:
+		bv := b.(float64)
+		if av < bv {
+			return -1
+		}
+		if av > bv {
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(av, b.(string))
+	case nil:
+		return 0
+	default:
+		aj, _ := json.Marshal(a)
+		bj, _ := json.Marshal(b)
+		return strings.Compare(string(aj), string(bj))
+	}
+}
+
+// sliceIterator is a StateQueryIteratorInterface over a fixed result set
+type sliceIterator struct {
+	kvs    []*queryresult.KV
+	pos    int
+	Closed bool
+}
+
+func (it *sliceIterator) HasNext() bool {
+	return it.pos < len(it.kvs)
+}
+
+func (it *sliceIterator) Next() (*queryresult.KV, error) {
+	if !it.HasNext() {
+		return nil, errors.New("iterator exhausted")
+	}
+	kv := it.kvs[it.pos]
+	it.pos++
+	return kv, nil
+}
+
+func (it *sliceIterator) Close() error {
+	it.Closed = true
+	return nil
+}
+
+// historyIterator is a HistoryQueryIteratorInterface over recorded modifications,
+// oldest first
+type historyIterator struct {
+	mods []*queryresult.KeyModification
+	pos  int
+}
+
+func (it *historyIterator) HasNext() bool {
+	return it.pos < len(it.mods)
+}
+
+func (it *historyIterator) Next() (*queryresult.KeyModification, error) {
+	if !it.HasNext() {
+		return nil, errors.New("iterator exhausted")
+	}
+	mod := it.mods[it.pos]
+	it.pos++
+	return mod, nil
+}
+
+func (it *historyIterator) Close() error {
+	return nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// maxLockReasonLength bounds the reason recorded by LockAsset
+const maxLockReasonLength = 256
+
+// checkNotLocked returns an ErrAssetLocked error if the asset is locked
+func checkNotLocked(asset *Asset) error {
+	if !asset.Locked {
+		return nil
+	}
+	return fmt.Errorf("cannot change %s: %w by %s: %s", asset.ID, ErrAssetLocked, asset.LockedBy, asset.LockReason)
+}
+
+// LockAsset locks an asset, e.g. during settlement, so that it cannot be
+// updated, transferred or deleted until UnlockAsset. The owner or an admin may
+// lock it.
+func (s *AssetContract) LockAsset(ctx contractapi.TransactionContextInterface, id string, reason string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: LockAsset - ID: %s, Reason: %s =====", id, reason)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+	if strings.TrimSpace(reason) == "" {
+		logln(ctx, "ERROR: Lock reason is empty")
+		return fmt.Errorf("lock reason cannot be empty: %w", ErrInvalidInput)
+	}
+	if len(reason) > maxLockReasonLength {
+		logf(ctx, "ERROR: Lock reason of %d characters is too long", len(reason))
+		return fmt.Errorf("lock reason cannot exceed %d characters: %w", maxLockReasonLength, ErrInvalidInput)
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if !clientActsAs(ctx, asset.Owner) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not lock asset %s owned by %s", id, asset.Owner)
+			return fmt.Errorf("only the owner or an admin may lock asset %s", id)
+		}
+	}
+	if err := checkNotLocked(asset); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	asset.Locked = true
+	asset.LockReason = reason
+	asset.LockedBy = clientID
+	if err := putLockedAsset(ctx, asset, clientID, "lock"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "AssetLocked", map[string]interface{}{
+		"assetID":  id,
+		"reason":   reason,
+		"lockedBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Asset %s locked", id)
+	logln(ctx, "===== END: LockAsset =====")
+	return nil
+}
+
+// UnlockAsset releases the lock of an asset. Only the identity that locked it
+// or an admin may unlock it.
+func (s *AssetContract) UnlockAsset(ctx contractapi.TransactionContextInterface, id string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: UnlockAsset - ID: %s =====", id)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if !asset.Locked {
+		logf(ctx, "ERROR: Asset %s is not locked", id)
+		return fmt.Errorf("asset %s is not locked", id)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+	if clientID != asset.LockedBy {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not unlock asset %s locked by %s", id, asset.LockedBy)
+			return fmt.Errorf("only the identity that locked asset %s or an admin may unlock it", id)
+		}
+	}
+
+	lockedBy := asset.LockedBy
+	asset.Locked = false
+	asset.LockReason = ""
+	asset.LockedBy = ""
+	if err := putLockedAsset(ctx, asset, clientID, "unlock"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "AssetUnlocked", map[string]interface{}{
+		"assetID":    id,
+		"lockedBy":   lockedBy,
+		"unlockedBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Asset %s unlocked", id)
+	logln(ctx, "===== END: UnlockAsset =====")
+	return nil
+}
+
+// putLockedAsset stamps and writes an asset whose lock changed and records the
+// change under operation
+func putLockedAsset(ctx contractapi.TransactionContextInterface, asset *Asset, clientID string, operation string) error {
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	stampUpdate(ctx, asset, now, clientID)
+
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		return fmt.Errorf("failed to marshal asset: %w", err)
+	}
+	if err := ctx.GetStub().PutState(asset.ID, assetJSON); err != nil {
+		return fmt.Errorf("failed to put asset %s to world state: %w", asset.ID, err)
+	}
+	return recordChange(ctx, asset.ID, operation)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test that a locked asset cannot change until it is unlocked
+func TestLockAsset(t *testing.T) {
+	contract := AssetContract{}
+
+	setup := func(t *testing.T) *Ledger {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+		stub, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.LockAsset(ctx, "asset1", "settlement in progress")
+		})
+		require.NoError(t, err)
+		event := stub.LastEvent()
+		assert.Equal(t, "AssetLocked", event.EventName)
+		assert.Equal(t, "settlement in progress", eventData(t, event)["reason"])
+		return ledger
+	}
+
+	t.Run("Lock Records Reason And Locker", func(t *testing.T) {
+		ledger := setup(t)
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.True(t, asset.Locked)
+		assert.Equal(t, "settlement in progress", asset.LockReason)
+		assert.Equal(t, ownerIdentity("John").ID, asset.LockedBy)
+	})
+
+	t.Run("Locked Asset Cannot Change", func(t *testing.T) {
+		ledger := setup(t)
+
+		changes := map[string]func(ctx *MockTransactionContext) error{
+			"transfer": func(ctx *MockTransactionContext) error {
+				return contract.TransferAsset(ctx, "asset1", "Jane")
+			},
+			"update": func(ctx *MockTransactionContext) error {
+				return contract.UpdateAsset(ctx, "asset1", "red", 5, "John", 300)
+			},
+			"delete": func(ctx *MockTransactionContext) error {
+				return contract.DeleteAsset(ctx, "asset1")
+			},
+		}
+		for name, change := range changes {
+			_, err := ledger.Invoke(ownerIdentity("John"), change)
+			require.Error(t, err, name)
+			assert.True(t, errors.Is(err, ErrAssetLocked), name)
+			assert.Contains(t, err.Error(), "asset is locked")
+		}
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "John", asset.Owner)
+		assert.Equal(t, "blue", asset.Color)
+	})
+
+	t.Run("Lock Twice", func(t *testing.T) {
+		ledger := setup(t)
+
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.LockAsset(ctx, "asset1", "again")
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrAssetLocked))
+	})
+
+	t.Run("Only Owner Or Admin Locks", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+
+		_, err := ledger.Invoke(ownerIdentity("Max"), func(ctx *MockTransactionContext) error {
+			return contract.LockAsset(ctx, "asset1", "mine now")
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only the owner or an admin may lock")
+		assert.False(t, readCommitted(t, ledger, "asset1").Locked)
+	})
+
+	t.Run("Empty Reason", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.LockAsset(ctx, "asset1", " ")
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+	})
+
+	t.Run("Only Locker Or Admin Unlocks", func(t *testing.T) {
+		ledger := setup(t)
+
+		_, err := ledger.Invoke(ownerIdentity("Max"), func(ctx *MockTransactionContext) error {
+			return contract.UnlockAsset(ctx, "asset1")
+		})
+		require.Error(t, err)
+		assert.True(t, readCommitted(t, ledger, "asset1").Locked)
+
+		stub, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return contract.UnlockAsset(ctx, "asset1")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "AssetUnlocked", stub.LastEvent().EventName)
+		assert.False(t, readCommitted(t, ledger, "asset1").Locked)
+	})
+
+	t.Run("Unlock Then Transfer", func(t *testing.T) {
+		ledger := setup(t)
+
+		stub, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.UnlockAsset(ctx, "asset1")
+		})
+		require.NoError(t, err)
+		event := stub.LastEvent()
+		assert.Equal(t, "AssetUnlocked", event.EventName)
+		assert.Equal(t, ownerIdentity("John").ID, eventData(t, event)["unlockedBy"])
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.False(t, asset.Locked)
+		assert.Empty(t, asset.LockReason)
+		assert.Empty(t, asset.LockedBy)
+
+		_, err = ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.TransferAsset(ctx, "asset1", "Jane")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Jane", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Unlock Unlocked Asset", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.UnlockAsset(ctx, "asset1")
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not locked")
+	})
+}
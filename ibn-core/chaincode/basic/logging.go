@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// invokedFunction returns the name of the transaction function being invoked,
+// without its contract namespace
+func invokedFunction(ctx contractapi.TransactionContextInterface) string {
+	function, _ := ctx.GetStub().GetFunctionAndParameters()
+	if i := strings.LastIndex(function, ":"); i >= 0 {
+		function = function[i+1:]
+	}
+	return function
+}
+
+// logPrefix tags a log line with the transaction ID and the invoked function,
+// so that the lines of one transaction can be picked out of the peer log even
+// when it calls several contract methods
+func logPrefix(ctx contractapi.TransactionContextInterface) string {
+	return fmt.Sprintf("[tx=%s fn=%s] ", ctx.GetStub().GetTxID(), invokedFunction(ctx))
+}
+
+// logf logs like log.Printf with the transaction prefix
+func logf(ctx contractapi.TransactionContextInterface, format string, args ...interface{}) {
+	log.Print(logPrefix(ctx) + fmt.Sprintf(format, args...))
+}
+
+// logln logs like log.Println with the transaction prefix
+func logln(ctx contractapi.TransactionContextInterface, args ...interface{}) {
+	log.Print(logPrefix(ctx) + fmt.Sprintln(args...))
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test that log lines carry the transaction ID and invoked function
+func TestTransactionLogPrefix(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	stub := ledger.BeginTx("tx-correlate")
+	stub.Function = "asset:UpdateAsset"
+	ctx := &MockTransactionContext{stub: stub}
+	require.NoError(t, contract.UpdateAsset(ctx, "asset1", "red", 5, "John", 300))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.NotEmpty(t, lines)
+	for _, line := range lines {
+		assert.Contains(t, line, "[tx=tx-correlate fn=UpdateAsset] ")
+	}
+	assert.Contains(t, buf.String(), "[tx=tx-correlate fn=UpdateAsset] ===== START: UpdateAsset")
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// MergeAssets consolidates two lots of the same owner: the Size and
+// AppraisedValue of sourceID are added to targetID, the source is deleted and
+// its ID is recorded in the MergedFrom list of the target. The owner or an
+// admin may merge.
+func (s *AssetContract) MergeAssets(ctx contractapi.TransactionContextInterface, sourceID string, targetID string) error {
+	sourceID = normalizeAssetID(sourceID)
+	targetID = normalizeAssetID(targetID)
+	logf(ctx, "===== START: MergeAssets - Source: %s, Target: %s =====", sourceID, targetID)
+
+	if err := requireAttribute(ctx, writerAttribute, "true"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(sourceID); err != nil {
+		logf(ctx, "ERROR: Invalid source ID: %v", err)
+		return err
+	}
+	if err := validateAssetID(targetID); err != nil {
+		logf(ctx, "ERROR: Invalid target ID: %v", err)
+		return err
+	}
+	if sourceID == targetID {
+		logf(ctx, "ERROR: Asset %s cannot be merged into itself", sourceID)
+		return fmt.Errorf("asset %s cannot be merged into itself: %w", sourceID, ErrInvalidInput)
+	}
+
+	source, err := s.ReadAsset(ctx, sourceID)
+	if err != nil {
+		logf(ctx, "ERROR: Source asset %s does not exist: %v", sourceID, err)
+		return err
+	}
+	target, err := s.ReadAsset(ctx, targetID)
+	if err != nil {
+		logf(ctx, "ERROR: Target asset %s does not exist: %v", targetID, err)
+		return err
+	}
+	if source.Owner != target.Owner {
+		logf(ctx, "ERROR: Source owner %s differs from target owner %s", source.Owner, target.Owner)
+		return fmt.Errorf("cannot merge asset %s of %s into asset %s of %s: %w", sourceID, source.Owner, targetID, target.Owner, ErrInvalidInput)
+	}
+	if !clientActsAs(ctx, target.Owner) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not merge assets owned by %s", target.Owner)
+			return fmt.Errorf("only the owner or an admin may merge assets of %s", target.Owner)
+		}
+	}
+	if err := checkNotLocked(source); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := checkNotLocked(target); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	limits, err := getValidationLimits(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	merged := *target
+	merged.Size += source.Size
+	merged.AppraisedValue += source.AppraisedValue
+	if err := validateAssetData(limits, merged.Color, merged.Size, merged.Owner, merged.AppraisedValue); err != nil {
+		logf(ctx, "ERROR: Merged asset is invalid: %v", err)
+		return err
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	merged.MergedFrom = append(append([]string{}, target.MergedFrom...), sourceID)
+	stampUpdate(ctx, &merged, now, clientID)
+
+	config, err := getConfig(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := checkImmutableFields(config, target, &merged); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	assetJSON, err := json.Marshal(merged)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+		return fmt.Errorf("failed to marshal asset: %w", err)
+	}
+
+	err = ctx.GetStub().PutState(targetID, assetJSON)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to update target asset: %v", err)
+		return fmt.Errorf("failed to update target asset %s: %w", targetID, err)
+	}
+	if err := recordChange(ctx, targetID, "merge"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := removeAsset(ctx, source, clientID, "merge"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "AssetsMerged", map[string]interface{}{
+		"sourceID":       sourceID,
+		"targetID":       targetID,
+		"owner":          merged.Owner,
+		"size":           merged.Size,
+		"appraisedValue": merged.AppraisedValue,
+		"mergedBy":       clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Merged asset %s into %s", sourceID, targetID)
+	logln(ctx, "===== END: MergeAssets =====")
+	return nil
+}
+
+// AssetSplit is one of the new assets SplitAsset carves out of a source asset
+type AssetSplit struct {
+	ID             string `json:"ID"`
+	Size           int    `json:"Size"`
+	AppraisedValue int    `json:"AppraisedValue"`
+}
+
+// SplitAsset divides sourceID into the new assets described by splitsJSON, a
+// JSON array of AssetSplit. The portions must add up to exactly the Size and
+// AppraisedValue of the source. The new assets inherit the owner and color of
+// the source, which is deleted; if any of them cannot be created the whole
+// split fails. The owner or an admin may split.
+func (s *AssetContract) SplitAsset(ctx contractapi.TransactionContextInterface, sourceID string, splitsJSON string) error {
+	sourceID = normalizeAssetID(sourceID)
+	logf(ctx, "===== START: SplitAsset - Source: %s =====", sourceID)
+
+	if err := requireAttribute(ctx, writerAttribute, "true"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(sourceID); err != nil {
+		logf(ctx, "ERROR: Invalid source ID: %v", err)
+		return err
+	}
+
+	var splits []AssetSplit
+	if err := json.Unmarshal([]byte(splitsJSON), &splits); err != nil {
+		logf(ctx, "ERROR: Failed to parse splits: %v", err)
+		return fmt.Errorf("splits must be a JSON array of {ID, Size, AppraisedValue}: %w", ErrInvalidInput)
+	}
+	if len(splits) < 2 {
+		logf(ctx, "ERROR: %d splits given", len(splits))
+		return fmt.Errorf("an asset must be split into at least 2 assets: %w", ErrInvalidInput)
+	}
+	if len(splits) > maxBatchSize {
+		logf(ctx, "ERROR: %d splits exceed the limit of %d", len(splits), maxBatchSize)
+		return fmt.Errorf("an asset cannot be split into more than %d assets: %w", maxBatchSize, ErrInvalidInput)
+	}
+
+	seen := make(map[string]bool, len(splits))
+	totalSize, totalValue := 0, 0
+	for i := range splits {
+		splits[i].ID = normalizeAssetID(splits[i].ID)
+		id := splits[i].ID
+		if id == sourceID {
+			logf(ctx, "ERROR: Split %d reuses the source ID", i)
+			return fmt.Errorf("split %d cannot reuse the source ID %s: %w", i, sourceID, ErrInvalidInput)
+		}
+		if seen[id] {
+			logf(ctx, "ERROR: Duplicate split ID %s", id)
+			return fmt.Errorf("split ID %s is listed twice: %w", id, ErrInvalidInput)
+		}
+		seen[id] = true
+		totalSize += splits[i].Size
+		totalValue += splits[i].AppraisedValue
+	}
+
+	source, err := s.ReadAsset(ctx, sourceID)
+	if err != nil {
+		logf(ctx, "ERROR: Source asset %s does not exist: %v", sourceID, err)
+		return err
+	}
+	if !clientActsAs(ctx, source.Owner) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not split asset %s owned by %s", sourceID, source.Owner)
+			return fmt.Errorf("only the owner or an admin may split asset %s", sourceID)
+		}
+	}
+	if err := checkNotLocked(source); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if totalSize != source.Size || totalValue != source.AppraisedValue {
+		logf(ctx, "ERROR: Portions add up to size %d and value %d, source has size %d and value %d", totalSize, totalValue, source.Size, source.AppraisedValue)
+		return fmt.Errorf("portions add up to size %d and value %d, but asset %s has size %d and value %d: %w", totalSize, totalValue, sourceID, source.Size, source.AppraisedValue, ErrInvalidInput)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	if err := removeAsset(ctx, source, clientID, "split"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	// The new assets are announced by the single AssetSplit event below
+	silent := withoutEvents(ctx)
+	ids := make([]string, 0, len(splits))
+	for _, split := range splits {
+		err := s.createAsset(silent, split.ID, source.Color, split.Size, source.Owner, split.AppraisedValue, time.Time{}, false)
+		if err != nil {
+			logf(ctx, "ERROR: Failed to create split %s: %v", split.ID, err)
+			return fmt.Errorf("failed to create split %s: %w", split.ID, err)
+		}
+		ids = append(ids, split.ID)
+	}
+
+	err = emitEvent(ctx, "AssetSplit", map[string]interface{}{
+		"sourceID": sourceID,
+		"owner":    source.Owner,
+		"assetIDs": ids,
+		"splitBy":  clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Split asset %s into %d assets", sourceID, len(ids))
+	logln(ctx, "===== END: SplitAsset =====")
+	return nil
+}
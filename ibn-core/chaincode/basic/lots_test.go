@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test MergeAssets
+func TestMergeAssets(t *testing.T) {
+	contract := AssetContract{}
+
+	setup := func() *Ledger {
+		ledger := NewLedger()
+		ledger.Seed(
+			Asset{ID: "lot1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500},
+			Asset{ID: "lot2", Color: "blue", Size: 15, Owner: "John", AppraisedValue: 700},
+			Asset{ID: "lot3", Color: "blue", Size: 5, Owner: "Jane", AppraisedValue: 100},
+		)
+		return ledger
+	}
+	merge := func(ledger *Ledger, sourceID, targetID string) (*LedgerStub, error) {
+		return ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.MergeAssets(ctx, sourceID, targetID)
+		})
+	}
+
+	t.Run("Merge Successfully", func(t *testing.T) {
+		ledger := setup()
+		stub, err := merge(ledger, "lot1", "lot2")
+		require.NoError(t, err)
+
+		assert.Nil(t, ledger.Get("lot1"))
+		assert.NotNil(t, ledger.Get(createCompositeKey(deletionReceiptObjectType, []string{"lot1"})))
+
+		target := readCommitted(t, ledger, "lot2")
+		assert.Equal(t, 25, target.Size)
+		assert.Equal(t, 1200, target.AppraisedValue)
+		assert.Equal(t, []string{"lot1"}, target.MergedFrom)
+		assert.Equal(t, 1, target.Version)
+
+		event := stub.LastEvent()
+		assert.Equal(t, "AssetsMerged", event.EventName)
+		payload := eventData(t, event)
+		assert.Equal(t, "lot1", payload["sourceID"])
+		assert.Equal(t, "lot2", payload["targetID"])
+		assert.Equal(t, float64(25), payload["size"])
+		assert.Equal(t, float64(1200), payload["appraisedValue"])
+	})
+
+	t.Run("Different Owners Rejected", func(t *testing.T) {
+		ledger := setup()
+		_, err := merge(ledger, "lot3", "lot2")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.NotNil(t, ledger.Get("lot3"))
+		assert.Equal(t, 15, readCommitted(t, ledger, "lot2").Size)
+	})
+
+	t.Run("Merge Into Itself Rejected", func(t *testing.T) {
+		_, err := merge(setup(), "lot1", "lot1")
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+	})
+
+	t.Run("Missing Source", func(t *testing.T) {
+		_, err := merge(setup(), "nope", "lot2")
+		assert.True(t, errors.Is(err, ErrAssetNotFound))
+	})
+
+	t.Run("Other Caller Rejected", func(t *testing.T) {
+		ledger := setup()
+		_, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.MergeAssets(ctx, "lot1", "lot2")
+		})
+		assert.Error(t, err)
+		assert.NotNil(t, ledger.Get("lot1"))
+	})
+
+	t.Run("Writer Attribute Required", func(t *testing.T) {
+		ledger := setup()
+		owner := &MockClientIdentity{ID: "x509::CN=John@org1.example.com", MSPID: "Org1MSP", Attributes: map[string]string{"owner": "John"}}
+		_, err := ledger.Invoke(owner, func(ctx *MockTransactionContext) error {
+			return contract.MergeAssets(ctx, "lot1", "lot2")
+		})
+		assert.Error(t, err)
+		assert.NotNil(t, ledger.Get("lot1"))
+	})
+}
+
+// Test SplitAsset
+func TestSplitAsset(t *testing.T) {
+	contract := AssetContract{}
+
+	setup := func() *Ledger {
+		ledger := NewLedger()
+		ledger.Seed(
+			Asset{ID: "lot1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500},
+			Asset{ID: "lot9", Color: "red", Size: 1, Owner: "John", AppraisedValue: 1},
+		)
+		return ledger
+	}
+	split := func(ledger *Ledger, sourceID, splitsJSON string) (*LedgerStub, error) {
+		return ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SplitAsset(ctx, sourceID, splitsJSON)
+		})
+	}
+
+	t.Run("Exact Split", func(t *testing.T) {
+		ledger := setup()
+		stub, err := split(ledger, "lot1", `[{"ID":"lot1a","Size":4,"AppraisedValue":200},{"ID":"lot1b","Size":6,"AppraisedValue":300}]`)
+		require.NoError(t, err)
+
+		assert.Nil(t, ledger.Get("lot1"))
+		assert.NotNil(t, ledger.Get(createCompositeKey(deletionReceiptObjectType, []string{"lot1"})))
+
+		first := readCommitted(t, ledger, "lot1a")
+		assert.Equal(t, "John", first.Owner)
+		assert.Equal(t, "blue", first.Color)
+		assert.Equal(t, 4, first.Size)
+		assert.Equal(t, 200, first.AppraisedValue)
+		second := readCommitted(t, ledger, "lot1b")
+		assert.Equal(t, 6, second.Size)
+		assert.Equal(t, 300, second.AppraisedValue)
+		assert.NotNil(t, ledger.Get(createCompositeKey(ownerIndexObjectType, []string{"John", "lot1b"})))
+
+		require.Len(t, stub.Events, 1)
+		event := stub.LastEvent()
+		assert.Equal(t, "AssetSplit", event.EventName)
+		payload := eventData(t, event)
+		assert.Equal(t, "lot1", payload["sourceID"])
+		assert.Equal(t, []interface{}{"lot1a", "lot1b"}, payload["assetIDs"])
+	})
+
+	t.Run("Portions Do Not Sum", func(t *testing.T) {
+		ledger := setup()
+		_, err := split(ledger, "lot1", `[{"ID":"lot1a","Size":4,"AppraisedValue":200},{"ID":"lot1b","Size":6,"AppraisedValue":250}]`)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Contains(t, err.Error(), "portions add up to")
+		assert.NotNil(t, ledger.Get("lot1"))
+		assert.Nil(t, ledger.Get("lot1a"))
+	})
+
+	t.Run("Existing Split ID Fails Atomically", func(t *testing.T) {
+		ledger := setup()
+		_, err := split(ledger, "lot1", `[{"ID":"lot1a","Size":4,"AppraisedValue":200},{"ID":"lot9","Size":6,"AppraisedValue":300}]`)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrAssetExists))
+		assert.NotNil(t, ledger.Get("lot1"))
+		assert.Nil(t, ledger.Get("lot1a"))
+	})
+
+	t.Run("Invalid Splits", func(t *testing.T) {
+		for _, splitsJSON := range []string{
+			`nope`,
+			`[{"ID":"lot1a","Size":10,"AppraisedValue":500}]`,
+			`[{"ID":"lot1a","Size":4,"AppraisedValue":200},{"ID":"lot1a","Size":6,"AppraisedValue":300}]`,
+			`[{"ID":"lot1","Size":4,"AppraisedValue":200},{"ID":"lot1b","Size":6,"AppraisedValue":300}]`,
+		} {
+			_, err := split(setup(), "lot1", splitsJSON)
+			assert.True(t, errors.Is(err, ErrInvalidInput), splitsJSON)
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+const (
+	// maxMetadataEntries bounds the number of metadata keys of an asset
+	maxMetadataEntries = 32
+	// maxMetadataValueLength bounds the length of a metadata value
+	maxMetadataValueLength = 256
+)
+
+// metadataKeyPattern restricts metadata keys to short identifiers
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+func validateMetadataKey(key string) error {
+	if !metadataKeyPattern.MatchString(key) {
+		return fmt.Errorf("metadata key %q must be 1 to 64 letters, digits, '_', '.' or '-'", key)
+	}
+	return nil
+}
+
+// SetAssetMetadata sets a free-form metadata entry on an asset. An empty value
+// removes the key.
+func (s *AssetContract) SetAssetMetadata(ctx contractapi.TransactionContextInterface, id string, key string, value string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: SetAssetMetadata - ID: %s, Key: %s =====", id, key)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+	if err := validateMetadataKey(key); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if len(value) > maxMetadataValueLength {
+		logf(ctx, "ERROR: Metadata value too long")
+		return fmt.Errorf("metadata value cannot exceed %d characters", maxMetadataValueLength)
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if !clientActsAs(ctx, asset.Owner) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not change the metadata of asset %s owned by %s", id, asset.Owner)
+			return fmt.Errorf("only the owner or an admin may change the metadata of asset %s: %w", id, ErrNotOwner)
+		}
+	}
+	if err := checkNotLocked(asset); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if value == "" {
+		delete(asset.Metadata, key)
+	} else {
+		if _, exists := asset.Metadata[key]; !exists && len(asset.Metadata) >= maxMetadataEntries {
+			logf(ctx, "ERROR: Asset %s already has %d metadata entries", id, maxMetadataEntries)
+			return fmt.Errorf("asset %s cannot have more than %d metadata entries", id, maxMetadataEntries)
+		}
+		if asset.Metadata == nil {
+			asset.Metadata = make(map[string]string)
+		}
+		asset.Metadata[key] = value
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	stampUpdate(ctx, asset, now, clientID)
+
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+		return fmt.Errorf("failed to marshal asset: %w", err)
+	}
+
+	err = ctx.GetStub().PutState(id, assetJSON)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to update asset metadata: %v", err)
+		return fmt.Errorf("failed to update asset metadata: %w", err)
+	}
+	if err := recordChange(ctx, id, "metadataChange"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "AssetMetadataChanged", map[string]interface{}{
+		"assetID":   id,
+		"key":       key,
+		"removed":   value == "",
+		"updatedBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Metadata %s of asset %s updated", key, id)
+	logln(ctx, "===== END: SetAssetMetadata =====")
+	return nil
+}
+
+// DuplicateMetadataGroup is a set of assets sharing one value of a metadata key
+type DuplicateMetadataGroup struct {
+	Value    string   `json:"Value"`
+	AssetIDs []string `json:"AssetIDs"`
+}
+
+// FindDuplicateMetadataValues scans all assets and returns, ordered by value,
+// the groups of assets that share a value for the given metadata key, so that
+// duplicates can be resolved before the key is treated as unique
+func (s *AssetContract) FindDuplicateMetadataValues(ctx contractapi.TransactionContextInterface, key string) ([]*DuplicateMetadataGroup, error) {
+	logf(ctx, "===== START: FindDuplicateMetadataValues - Key: %s =====", key)
+
+	if err := validateMetadataKey(key); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	assets, err := s.GetAllAssets(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get assets: %v", err)
+		return nil, err
+	}
+
+	byValue := make(map[string][]string)
+	for _, asset := range assets {
+		if value, ok := asset.Metadata[key]; ok {
+			byValue[value] = append(byValue[value], asset.ID)
+		}
+	}
+
+	groups := []*DuplicateMetadataGroup{}
+	for value, ids := range byValue {
+		if len(ids) > 1 {
+			sort.Strings(ids)
+			groups = append(groups, &DuplicateMetadataGroup{Value: value, AssetIDs: ids})
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Value < groups[j].Value
+	})
+
+	logf(ctx, "INFO: Found %d duplicated values of metadata key %s", len(groups), key)
+	logln(ctx, "===== END: FindDuplicateMetadataValues =====")
+	return groups, nil
+}
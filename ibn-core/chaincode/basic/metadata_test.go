@@ -0,0 +1,86 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test SetAssetMetadata
+func TestSetAssetMetadata(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+
+	set := func(key, value string) error {
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SetAssetMetadata(ctx, "asset1", key, value)
+		})
+		return err
+	}
+
+	require.NoError(t, set("serial", "SN-1"))
+	require.NoError(t, set("batch", "B7"))
+	asset := readCommitted(t, ledger, "asset1")
+	assert.Equal(t, map[string]string{"serial": "SN-1", "batch": "B7"}, asset.Metadata)
+	assert.Equal(t, ownerIdentity("John").ID, asset.UpdatedBy)
+	assert.Equal(t, "Org1MSP", asset.UpdatedByMSP)
+
+	require.NoError(t, set("batch", ""))
+	assert.Equal(t, map[string]string{"serial": "SN-1"}, readCommitted(t, ledger, "asset1").Metadata)
+
+	assert.Error(t, set("", "x"))
+	assert.Error(t, set("bad key", "x"))
+
+	_, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+		return contract.SetAssetMetadata(ctx, "asset1", "serial", "SN-2")
+	})
+	assert.ErrorIs(t, err, ErrNotOwner)
+
+	ledger.Seed(Asset{ID: "asset2", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300, Locked: true})
+	_, err = ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+		return contract.SetAssetMetadata(ctx, "asset2", "serial", "SN-2")
+	})
+	assert.ErrorIs(t, err, ErrAssetLocked)
+}
+
+// Test FindDuplicateMetadataValues over duplicate and unique values
+func TestFindDuplicateMetadataValues(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Owner: "John", Metadata: map[string]string{"serial": "SN-1", "batch": "B1"}},
+		Asset{ID: "asset2", Owner: "John", Metadata: map[string]string{"serial": "SN-2", "batch": "B1"}},
+		Asset{ID: "asset3", Owner: "Jane", Metadata: map[string]string{"serial": "SN-1"}},
+		Asset{ID: "asset4", Owner: "Jane", Metadata: map[string]string{"serial": "SN-3"}},
+		Asset{ID: "asset5", Owner: "Jane"},
+		Asset{ID: "asset6", Owner: "Max", Metadata: map[string]string{"serial": "SN-1", "batch": "B2"}},
+	)
+
+	find := func(key string) ([]*DuplicateMetadataGroup, error) {
+		var groups []*DuplicateMetadataGroup
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			groups, err = contract.FindDuplicateMetadataValues(ctx, key)
+			return err
+		})
+		return groups, err
+	}
+
+	groups, err := find("serial")
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, DuplicateMetadataGroup{Value: "SN-1", AssetIDs: []string{"asset1", "asset3", "asset6"}}, *groups[0])
+
+	groups, err = find("batch")
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, []string{"asset1", "asset2"}, groups[0].AssetIDs)
+
+	groups, err = find("color")
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+
+	_, err = find("not a key")
+	assert.Error(t, err)
+}
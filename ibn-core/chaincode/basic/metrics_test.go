@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test the MethodMetric event emitted by the transaction hooks
+func TestMethodMetrics(t *testing.T) {
+	contract := AssetContract{}
+	admin := AdminContract{}
+
+	setup := func(t *testing.T, enabled bool) *Ledger {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+		_, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return admin.SetMethodMetrics(ctx, enabled)
+		})
+		require.NoError(t, err)
+		return ledger
+	}
+
+	// invoke runs fn between the hooks the way contractapi does
+	invoke := func(t *testing.T, ledger *Ledger, function string, fn func(ctx *metricsContext) error) *LedgerStub {
+		stub := ledger.BeginTx("")
+		stub.Function = function
+		ctx := new(metricsContext)
+		ctx.SetStub(stub)
+		ctx.SetClientIdentity(defaultIdentity)
+
+		startMethodMetric(ctx)
+		require.NoError(t, fn(ctx))
+		require.NoError(t, emitMethodMetric(ctx))
+		require.NoError(t, ledger.Commit(stub))
+		return stub
+	}
+
+	t.Run("Metric Emitted When Enabled", func(t *testing.T) {
+		ledger := setup(t, true)
+		stub := invoke(t, ledger, "asset:ReadAsset", func(ctx *metricsContext) error {
+			_, err := contract.ReadAsset(ctx, "asset1")
+			return err
+		})
+
+		require.Len(t, stub.Events, 1)
+		event := stub.LastEvent()
+		assert.Equal(t, "MethodMetric", event.EventName)
+		// Every peer must endorse the same payload, so no timing is included
+		assert.Equal(t, map[string]interface{}{"method": "ReadAsset", "success": true}, eventData(t, event))
+	})
+
+	t.Run("No Metric When Disabled", func(t *testing.T) {
+		ledger := setup(t, false)
+		stub := invoke(t, ledger, "ReadAsset", func(ctx *metricsContext) error {
+			_, err := contract.ReadAsset(ctx, "asset1")
+			return err
+		})
+		assert.Empty(t, stub.Events)
+	})
+
+	t.Run("Metric Carried By Method Event", func(t *testing.T) {
+		ledger := setup(t, true)
+		stub := invoke(t, ledger, "UpdateAsset", func(ctx *metricsContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "red", 5, "John", 300)
+		})
+		require.Len(t, stub.Events, 1)
+		event := stub.LastEvent()
+		assert.Equal(t, "AssetUpdated", event.EventName)
+
+		var envelope EventEnvelope
+		require.NoError(t, json.Unmarshal(event.Payload, &envelope))
+		assert.Equal(t, &MethodMetric{Method: "UpdateAsset", Success: true}, envelope.Metric)
+	})
+
+	t.Run("No Metric In Envelope When Disabled", func(t *testing.T) {
+		ledger := setup(t, false)
+		stub := invoke(t, ledger, "UpdateAsset", func(ctx *metricsContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "red", 5, "John", 300)
+		})
+		var envelope EventEnvelope
+		require.NoError(t, json.Unmarshal(stub.LastEvent().Payload, &envelope))
+		assert.Nil(t, envelope.Metric)
+	})
+
+	t.Run("Only Admins May Toggle", func(t *testing.T) {
+		ledger := NewLedger()
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return admin.SetMethodMetrics(ctx, true)
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// maxMigrationPageSize bounds how many assets a single MigrateAllAssets call
+// rewrites, keeping each transaction's write set small
+const maxMigrationPageSize = 500
+
+// MigrationResult reports the progress of one MigrateAllAssets call
+type MigrationResult struct {
+	Scanned  int    `json:"Scanned"`
+	Migrated int    `json:"Migrated"`
+	Bookmark string `json:"Bookmark"`
+	Done     bool   `json:"Done"`
+}
+
+// storedAssetFieldNames returns the JSON field names that every marshaled
+// Asset contains, i.e. all fields except those tagged omitempty
+func storedAssetFieldNames() []string {
+	t := reflect.TypeOf(Asset{})
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("json"), ",")
+		if len(tag) > 1 && tag[1] == "omitempty" {
+			continue
+		}
+		names = append(names, tag[0])
+	}
+	return names
+}
+
+// migrateAsset upgrades a stored asset to the current schema. Fields missing
+// from records written by older chaincode versions are populated with their
+// defaults. It reports whether the stored JSON needs to be rewritten.
+func migrateAsset(assetJSON []byte) (*Asset, bool, error) {
+	var stored map[string]json.RawMessage
+	if err := json.Unmarshal(assetJSON, &stored); err != nil {
+		return nil, false, err
+	}
+
+	var asset Asset
+	if err := json.Unmarshal(assetJSON, &asset); err != nil {
+		return nil, false, err
+	}
+
+	changed := false
+	for _, field := range storedAssetFieldNames() {
+		if _, ok := stored[field]; !ok {
+			changed = true
+		}
+	}
+	if asset.CreatedAt.IsZero() && !asset.UpdatedAt.IsZero() {
+		asset.CreatedAt = asset.UpdatedAt
+		changed = true
+	}
+	return &asset, changed, nil
+}
+
+// MigrateAllAssets migrates one page of assets to the current schema and returns
+// the bookmark of the next page, so operators can migrate the whole ledger
+// incrementally over several transactions until the result is Done.
+// It is allowed in maintenance mode, which is where migrations usually run, and
+// emits no events so that consumers are not flooded by data maintenance.
+func (a *AdminContract) MigrateAllAssets(ctx contractapi.TransactionContextInterface, pageSize int32, bookmark string) (*MigrationResult, error) {
+	logf(ctx, "===== START: MigrateAllAssets - Page Size: %d, Bookmark: %s =====", pageSize, bookmark)
+
+	if err := requireAdmin(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+	ctx = withoutEvents(ctx)
+
+	if pageSize <= 0 || pageSize > maxMigrationPageSize {
+		logf(ctx, "ERROR: Invalid page size %d", pageSize)
+		return nil, fmt.Errorf("page size must be between 1 and %d", maxMigrationPageSize)
+	}
+
+	resultsIterator, metadata, err := ctx.GetStub().GetStateByRangeWithPagination("", "", pageSize, bookmark)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get state by range: %v", err)
+		return nil, fmt.Errorf("failed to get state by range: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	result := &MigrationResult{Bookmark: metadata.GetBookmark(), Done: pageDone(metadata, pageSize)}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate results: %v", err)
+			return nil, fmt.Errorf("failed to iterate results: %w", err)
+		}
+		result.Scanned++
+
+		asset, changed, err := migrateAsset(queryResponse.Value)
+		if err != nil {
+			logf(ctx, "WARNING: Failed to unmarshal asset %s, skipping: %v", queryResponse.Key, err)
+			continue
+		}
+		if !changed {
+			continue
+		}
+
+		assetJSON, err := json.Marshal(asset)
+		if err != nil {
+			logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+			return nil, fmt.Errorf("failed to marshal asset: %w", err)
+		}
+
+		err = ctx.GetStub().PutState(queryResponse.Key, assetJSON)
+		if err != nil {
+			logf(ctx, "ERROR: Failed to write migrated asset %s: %v", queryResponse.Key, err)
+			return nil, fmt.Errorf("failed to write migrated asset %s: %w", queryResponse.Key, err)
+		}
+		result.Migrated++
+	}
+
+	logf(ctx, "INFO: Migrated %d of %d scanned assets", result.Migrated, result.Scanned)
+	logln(ctx, "===== END: MigrateAllAssets =====")
+	return result, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test MigrateAllAssets over pre- and post-migration records
+func TestMigrateAllAssets(t *testing.T) {
+	admin := AdminContract{}
+
+	newLedger := func() *Ledger {
+		ledger := NewLedger()
+		// Records written by a chaincode version without the newer fields
+		ledger.SeedRaw("asset1", []byte(`{"ID":"asset1","Color":"blue","Size":5,"Owner":"Tomoko","AppraisedValue":300,"UpdatedAt":"2023-05-01T00:00:00Z"}`))
+		ledger.SeedRaw("asset3", []byte(`{"ID":"asset3","Color":"green","Size":10,"Owner":"Jin Soo","AppraisedValue":500}`))
+		ledger.Seed(
+			Asset{ID: "asset2", Color: "red", Size: 5, Owner: "Brad", AppraisedValue: 400},
+			Asset{ID: "asset4", Color: "yellow", Size: 10, Owner: "Max", AppraisedValue: 600},
+		)
+		return ledger
+	}
+
+	migrate := func(ledger *Ledger, pageSize int32, bookmark string) (*MigrationResult, *LedgerStub, error) {
+		var result *MigrationResult
+		stub, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) (err error) {
+			result, err = admin.MigrateAllAssets(ctx, pageSize, bookmark)
+			return err
+		})
+		return result, stub, err
+	}
+
+	t.Run("Single Page", func(t *testing.T) {
+		ledger := newLedger()
+		untouched := ledger.Get("asset2")
+
+		result, stub, err := migrate(ledger, 10, "")
+		require.NoError(t, err)
+		assert.Equal(t, MigrationResult{Scanned: 4, Migrated: 2, Bookmark: "", Done: true}, *result)
+		assert.Empty(t, stub.Events)
+
+		var stored map[string]interface{}
+		require.NoError(t, json.Unmarshal(ledger.Get("asset1"), &stored))
+		for _, field := range storedAssetFieldNames() {
+			assert.Contains(t, stored, field)
+		}
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "Tomoko", asset.Owner)
+		assert.Equal(t, asset.UpdatedAt, asset.CreatedAt)
+		assert.Equal(t, untouched, ledger.Get("asset2"))
+	})
+
+	t.Run("Incremental Pages", func(t *testing.T) {
+		ledger := newLedger()
+
+		first, _, err := migrate(ledger, 2, "")
+		require.NoError(t, err)
+		assert.Equal(t, 2, first.Scanned)
+		assert.Equal(t, 1, first.Migrated)
+		require.NotEmpty(t, first.Bookmark)
+
+		second, _, err := migrate(ledger, 2, first.Bookmark)
+		require.NoError(t, err)
+		assert.Equal(t, 2, second.Scanned)
+		assert.Equal(t, 1, second.Migrated)
+		assert.Empty(t, second.Bookmark)
+
+		// Running again finds nothing left to migrate
+		again, _, err := migrate(ledger, 10, "")
+		require.NoError(t, err)
+		assert.Equal(t, 0, again.Migrated)
+	})
+
+	t.Run("Invalid Page Size", func(t *testing.T) {
+		_, _, err := migrate(newLedger(), 0, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("Requires Admin", func(t *testing.T) {
+		ledger := newLedger()
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			_, err := admin.MigrateAllAssets(ctx, 10, "")
+			return err
+		})
+		assert.Error(t, err)
+	})
+}
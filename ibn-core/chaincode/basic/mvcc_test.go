@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readCommitted(t *testing.T, ledger *Ledger, id string) Asset {
+	var asset Asset
+	require.NoError(t, json.Unmarshal(ledger.Get(id), &asset))
+	return asset
+}
+
+// Test concurrent UpdateAsset calls on the same asset
+func TestUpdateAssetMVCCConflict(t *testing.T) {
+	contract := AssetContract{}
+
+	t.Run("Concurrent Updates Conflict", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+
+		// Both transactions are endorsed against the same committed version
+		tx1 := ledger.BeginTx("tx-a")
+		tx2 := ledger.BeginTx("tx-b")
+		ctx1 := &MockTransactionContext{stub: tx1}
+		ctx2 := &MockTransactionContext{stub: tx2}
+
+		require.NoError(t, contract.UpdateAsset(ctx1, "asset1", "red", 10, "John", 600))
+		require.NoError(t, contract.UpdateAsset(ctx2, "asset1", "green", 10, "John", 700))
+
+		assert.NoError(t, ledger.Commit(tx1))
+		err := ledger.Commit(tx2)
+		assert.True(t, errors.Is(err, errMVCCReadConflict))
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "red", asset.Color)
+		assert.Equal(t, 600, asset.AppraisedValue)
+	})
+
+	t.Run("Sequential Updates Succeed", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "red", 10, "John", 600)
+		})
+		require.NoError(t, err)
+		_, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "green", 10, "John", 700)
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "green", readCommitted(t, ledger, "asset1").Color)
+	})
+
+	t.Run("Update Conflicts With Concurrent Transfer", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500})
+
+		transferTx := ledger.BeginTx("")
+		updateTx := ledger.BeginTx("")
+		require.NoError(t, contract.TransferAsset(&MockTransactionContext{stub: transferTx, identity: ownerIdentity("John")}, "asset1", "Jane"))
+		require.NoError(t, contract.UpdateAsset(&MockTransactionContext{stub: updateTx}, "asset1", "red", 10, "John", 500))
+
+		require.NoError(t, ledger.Commit(transferTx))
+		assert.True(t, errors.Is(ledger.Commit(updateTx), errMVCCReadConflict))
+
+		// The stale update must not have reverted the transfer
+		assert.Equal(t, "Jane", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Updates To Different Assets Do Not Conflict", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(
+			Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500},
+			Asset{ID: "asset2", Color: "red", Size: 20, Owner: "Jane", AppraisedValue: 600},
+		)
+
+		tx1 := ledger.BeginTx("")
+		tx2 := ledger.BeginTx("")
+		require.NoError(t, contract.UpdateAsset(&MockTransactionContext{stub: tx1}, "asset1", "white", 10, "John", 500))
+		require.NoError(t, contract.UpdateAsset(&MockTransactionContext{stub: tx2}, "asset2", "black", 20, "Jane", 600))
+
+		assert.NoError(t, ledger.Commit(tx1))
+		assert.NoError(t, ledger.Commit(tx2))
+	})
+
+	t.Run("Concurrent Creates Of Same ID Conflict", func(t *testing.T) {
+		ledger := NewLedger()
+
+		tx1 := ledger.BeginTx("")
+		tx2 := ledger.BeginTx("")
+		require.NoError(t, contract.CreateAsset(&MockTransactionContext{stub: tx1}, "asset1", "blue", 10, "John", 500))
+		require.NoError(t, contract.CreateAsset(&MockTransactionContext{stub: tx2}, "asset1", "red", 10, "Jane", 500))
+
+		assert.NoError(t, ledger.Commit(tx1))
+		assert.True(t, errors.Is(ledger.Commit(tx2), errMVCCReadConflict))
+		assert.Equal(t, "John", readCommitted(t, ledger, "asset1").Owner)
+	})
+}
+
+// Test compare-and-set updates with UpdateAssetWithVersion
+func TestUpdateAssetWithVersion(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+		return contract.CreateAsset(ctx, "asset1", "blue", 10, "John", 500)
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, readCommitted(t, ledger, "asset1").Version)
+
+	update := func(color string, expectedVersion int) error {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAssetWithVersion(ctx, "asset1", color, 10, "John", 500, expectedVersion)
+		})
+		return err
+	}
+
+	t.Run("Matching Version", func(t *testing.T) {
+		require.NoError(t, update("red", 1))
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "red", asset.Color)
+		assert.Equal(t, 2, asset.Version)
+	})
+
+	t.Run("Stale Version Rejected", func(t *testing.T) {
+		err := update("green", 1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "version conflict")
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "red", asset.Color)
+		assert.Equal(t, 2, asset.Version)
+	})
+
+	t.Run("Unchecked Writes Bump Version", func(t *testing.T) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset1", "white", 10, "John", 500)
+		})
+		require.NoError(t, err)
+		_, err = ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.TransferAsset(ctx, "asset1", "Jane")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 4, readCommitted(t, ledger, "asset1").Version)
+		assert.Error(t, update("black", 2))
+	})
+
+	t.Run("Negative Version", func(t *testing.T) {
+		assert.Error(t, update("black", -1))
+	})
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// ownerIndexObjectType is the composite key namespace of the owner index
+// (owner~id~<owner>~<id>), which lets assets be found by owner on LevelDB
+const ownerIndexObjectType = "owner~id"
+
+// putOwnerIndex adds the owner index entry of an asset
+func putOwnerIndex(ctx contractapi.TransactionContextInterface, owner string, id string) error {
+	indexKey, err := ctx.GetStub().CreateCompositeKey(ownerIndexObjectType, []string{owner, id})
+	if err != nil {
+		return fmt.Errorf("failed to create owner index key: %w", err)
+	}
+	// The index entry carries no data; a null byte marks it as present
+	if err := ctx.GetStub().PutState(indexKey, []byte{0x00}); err != nil {
+		return fmt.Errorf("failed to write owner index entry: %w", err)
+	}
+	return nil
+}
+
+// deleteOwnerIndex removes the owner index entry of an asset
+func deleteOwnerIndex(ctx contractapi.TransactionContextInterface, owner string, id string) error {
+	indexKey, err := ctx.GetStub().CreateCompositeKey(ownerIndexObjectType, []string{owner, id})
+	if err != nil {
+		return fmt.Errorf("failed to create owner index key: %w", err)
+	}
+	if err := ctx.GetStub().DelState(indexKey); err != nil {
+		return fmt.Errorf("failed to delete owner index entry: %w", err)
+	}
+	return nil
+}
+
+// moveOwnerIndex replaces the owner index entry of an asset whose owner changed
+func moveOwnerIndex(ctx contractapi.TransactionContextInterface, oldOwner string, newOwner string, id string) error {
+	if oldOwner == newOwner {
+		return nil
+	}
+	if err := deleteOwnerIndex(ctx, oldOwner, id); err != nil {
+		return err
+	}
+	return putOwnerIndex(ctx, newOwner, id)
+}
+
+// QueryAssetsByOwnerIndexed returns the assets of an owner through the owner
+// index. Unlike QueryAssetsByOwner it does not need CouchDB.
+func (s *AssetContract) QueryAssetsByOwnerIndexed(ctx contractapi.TransactionContextInterface, owner string) ([]*Asset, error) {
+	logf(ctx, "===== START: QueryAssetsByOwnerIndexed - Owner: %s =====", owner)
+
+	if err := validateOwner(owner); err != nil {
+		logf(ctx, "ERROR: Invalid owner: %v", err)
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(ownerIndexObjectType, []string{owner})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read owner index: %v", err)
+		return nil, fmt.Errorf("failed to read owner index: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	assets := []*Asset{}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate owner index: %v", err)
+			return nil, fmt.Errorf("failed to iterate owner index: %w", err)
+		}
+
+		_, keyParts, err := ctx.GetStub().SplitCompositeKey(queryResponse.Key)
+		if err != nil || len(keyParts) != 2 {
+			logf(ctx, "WARNING: Malformed owner index key, skipping: %q", queryResponse.Key)
+			continue
+		}
+
+		asset, err := s.ReadAsset(ctx, keyParts[1])
+		if errors.Is(err, ErrAssetNotFound) {
+			logf(ctx, "WARNING: Indexed asset %s no longer exists, skipping", keyParts[1])
+			continue
+		}
+		if err != nil {
+			logf(ctx, "ERROR: %v", err)
+			return nil, err
+		}
+		assets = append(assets, asset)
+	}
+
+	logf(ctx, "INFO: Found %d indexed assets for owner %s", len(assets), owner)
+	logln(ctx, "===== END: QueryAssetsByOwnerIndexed =====")
+	return assets, nil
+}
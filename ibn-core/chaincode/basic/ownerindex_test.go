@@ -0,0 +1,154 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test that the owner~id index follows the owner of an asset
+func TestOwnerIndex(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+
+	invoke := func(identity *MockClientIdentity, fn func(ctx *MockTransactionContext) error) error {
+		_, err := ledger.Invoke(identity, fn)
+		return err
+	}
+	indexed := func(owner, id string) bool {
+		return ledger.Get(createCompositeKey(ownerIndexObjectType, []string{owner, id})) != nil
+	}
+	byOwner := func(owner string) []string {
+		var assets []*Asset
+		require.NoError(t, invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.QueryAssetsByOwnerIndexed(ctx, owner)
+			return err
+		}))
+		ids := []string{}
+		for _, asset := range assets {
+			ids = append(ids, asset.ID)
+		}
+		return ids
+	}
+
+	require.NoError(t, invoke(nil, func(ctx *MockTransactionContext) error {
+		if err := contract.CreateAsset(ctx, "asset1", "blue", 5, "John", 300); err != nil {
+			return err
+		}
+		return contract.CreateAsset(ctx, "asset2", "red", 5, "John", 400)
+	}))
+
+	t.Run("Create Writes Index", func(t *testing.T) {
+		assert.True(t, indexed("John", "asset1"))
+		assert.Equal(t, []string{"asset1", "asset2"}, byOwner("John"))
+		assert.Empty(t, byOwner("Jane"))
+	})
+
+	t.Run("Transfer Moves Index", func(t *testing.T) {
+		require.NoError(t, invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.TransferAsset(ctx, "asset1", "Jane")
+		}))
+		assert.False(t, indexed("John", "asset1"))
+		assert.True(t, indexed("Jane", "asset1"))
+		assert.Equal(t, []string{"asset2"}, byOwner("John"))
+		assert.Equal(t, []string{"asset1"}, byOwner("Jane"))
+	})
+
+	t.Run("Update Cannot Move Index", func(t *testing.T) {
+		err := invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset2", "red", 5, "Max", 400)
+		})
+		assert.Error(t, err)
+		assert.True(t, indexed("John", "asset2"))
+		assert.False(t, indexed("Max", "asset2"))
+	})
+
+	t.Run("Update Keeps Index", func(t *testing.T) {
+		stub, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, "asset2", "green", 5, "John", 450)
+		})
+		require.NoError(t, err)
+		_, written := stub.Written(createCompositeKey(ownerIndexObjectType, []string{"John", "asset2"}))
+		assert.False(t, written)
+		assert.True(t, indexed("John", "asset2"))
+	})
+
+	t.Run("Delete Clears Index", func(t *testing.T) {
+		require.NoError(t, invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.DeleteAsset(ctx, "asset2")
+		}))
+		assert.False(t, indexed("John", "asset2"))
+		assert.Empty(t, byOwner("John"))
+	})
+
+	t.Run("Invalid Owner", func(t *testing.T) {
+		err := invoke(nil, func(ctx *MockTransactionContext) error {
+			_, err := contract.QueryAssetsByOwnerIndexed(ctx, "")
+			return err
+		})
+		assert.Error(t, err)
+	})
+}
+
+// Test that RebuildOwnerIndex removes stale entries and recreates missing ones
+func TestRebuildOwnerIndex(t *testing.T) {
+	admin := AdminContract{}
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300},
+		Asset{ID: "asset2", Color: "red", Size: 5, Owner: "Jane", AppraisedValue: 300},
+	)
+	indexKey := func(owner string, id string) string {
+		return createCompositeKey(ownerIndexObjectType, []string{owner, id})
+	}
+	// asset1 is indexed correctly, asset2 not at all, and two entries point
+	// at a previous owner and at an asset that is gone
+	ledger.SeedRaw(indexKey("John", "asset1"), []byte{0x00})
+	ledger.SeedRaw(indexKey("Max", "asset1"), []byte{0x00})
+	ledger.SeedRaw(indexKey("John", "ghost"), []byte{0x00})
+
+	t.Run("Requires Admin", func(t *testing.T) {
+		_, err := ledger.Invoke(defaultIdentity, func(ctx *MockTransactionContext) error {
+			_, err := admin.RebuildOwnerIndex(ctx)
+			return err
+		})
+		require.Error(t, err)
+		assert.NotNil(t, ledger.Get(indexKey("John", "ghost")))
+	})
+
+	t.Run("Rebuild", func(t *testing.T) {
+		var result *OwnerIndexRebuild
+		stub, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) (err error) {
+			result, err = admin.RebuildOwnerIndex(ctx)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &OwnerIndexRebuild{Removed: 2, Created: 1, Indexed: 2}, result)
+		assert.Equal(t, "OwnerIndexRebuilt", stub.LastEvent().EventName)
+
+		assert.Nil(t, ledger.Get(indexKey("Max", "asset1")))
+		assert.Nil(t, ledger.Get(indexKey("John", "ghost")))
+		assert.NotNil(t, ledger.Get(indexKey("John", "asset1")))
+		assert.NotNil(t, ledger.Get(indexKey("Jane", "asset2")))
+
+		var assets []*Asset
+		_, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.QueryAssetsByOwnerIndexed(ctx, "Max")
+			return err
+		})
+		require.NoError(t, err)
+		assert.Empty(t, assets)
+	})
+
+	t.Run("Rebuild Is Idempotent", func(t *testing.T) {
+		var result *OwnerIndexRebuild
+		_, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) (err error) {
+			result, err = admin.RebuildOwnerIndex(ctx)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &OwnerIndexRebuild{Removed: 0, Created: 0, Indexed: 2}, result)
+	})
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// PagedAssets is one page of assets returned by every paginated asset query
+type PagedAssets struct {
+	Records []*Asset `json:"Records"`
+	// Bookmark continues the query on the next call
+	Bookmark string `json:"Bookmark"`
+	// FetchedCount is the number of records the peer fetched for this page,
+	// which may include records skipped because they are not assets
+	FetchedCount int32 `json:"FetchedCount"`
+	// Done reports that there is no further page
+	Done bool `json:"Done"`
+}
+
+// pageDone reports whether a query page requested with pageSize is the last
+// one. Every paginated function derives its Done flag here. CouchDB keeps
+// returning a bookmark until a page comes back empty, so a page that fetched
+// fewer records than requested is the last one as well.
+func pageDone(metadata *peer.QueryResponseMetadata, pageSize int32) bool {
+	return metadata.GetBookmark() == "" || metadata.GetFetchedRecordsCount() < pageSize
+}
+
+// collectPagedAssets decodes the assets of a paginated query iterator into a
+// PagedAssets and closes the iterator
+func collectPagedAssets(ctx contractapi.TransactionContextInterface, resultsIterator shim.StateQueryIteratorInterface, metadata *peer.QueryResponseMetadata, pageSize int32) (*PagedAssets, error) {
+	assets, err := collectAssets(ctx, resultsIterator)
+	if err != nil {
+		return nil, err
+	}
+	return &PagedAssets{
+		Records:      assets,
+		Bookmark:     metadata.GetBookmark(),
+		FetchedCount: metadata.GetFetchedRecordsCount(),
+		Done:         pageDone(metadata, pageSize),
+	}, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test the Done flag derived from the returned bookmark and fetched count
+func TestPageDone(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		bookmark string
+		fetched  int32
+		done     bool
+	}{
+		{"Full Page With Bookmark", "g1AAAAA", 2, false},
+		{"Short Final Page With Bookmark", "g1AAAAA", 1, true},
+		{"Empty Page With Bookmark", "g1AAAAA", 0, true},
+		{"No Bookmark", "", 2, true},
+	} {
+		metadata := &peer.QueryResponseMetadata{FetchedRecordsCount: tt.fetched, Bookmark: tt.bookmark}
+		assert.Equal(t, tt.done, pageDone(metadata, 2), tt.name)
+	}
+
+	t.Run("Collected Page", func(t *testing.T) {
+		for _, tt := range []struct {
+			bookmark string
+			pageSize int32
+			done     bool
+		}{
+			{"", 1, true},
+			{"asset2", 1, false},
+			{"asset2", 2, true},
+		} {
+			iterator := &sliceIterator{kvs: []*queryresult.KV{
+				{Key: "asset1", Value: []byte(`{"ID":"asset1","Owner":"John"}`)},
+			}}
+			page, err := collectPagedAssets(&MockTransactionContext{stub: new(MockStub)}, iterator, &peer.QueryResponseMetadata{FetchedRecordsCount: 1, Bookmark: tt.bookmark}, tt.pageSize)
+			require.NoError(t, err)
+			assert.Equal(t, tt.done, page.Done, tt.bookmark)
+			assert.Equal(t, tt.bookmark, page.Bookmark)
+			assert.Equal(t, int32(1), page.FetchedCount)
+			require.Len(t, page.Records, 1)
+			assert.True(t, iterator.Closed)
+		}
+	})
+}
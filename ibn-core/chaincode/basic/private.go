@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// appraisalCollection is the private data collection holding appraised values.
+// Its membership is defined in collections_config.json.
+const appraisalCollection = "appraisalCollection"
+
+// AssetPrivateDetails is the part of an asset kept in the appraisal collection
+type AssetPrivateDetails struct {
+	ID             string `json:"ID"`
+	AppraisedValue int    `json:"AppraisedValue"`
+}
+
+// transientPropertiesKey is the transient map entry holding the private
+// properties of CreateAssetPrivate
+const transientPropertiesKey = "asset_properties"
+
+// assetPrivateProperties is the JSON object CreateAssetPrivate expects in the
+// "asset_properties" entry of the transient map
+type assetPrivateProperties struct {
+	AppraisedValue *int `json:"AppraisedValue"`
+}
+
+// CreateAssetPrivate creates an asset like CreateAsset, but stores its appraised
+// value in the appraisal collection instead of the world state. The value is
+// passed as {"AppraisedValue": n} in the "asset_properties" entry of the
+// transient map, which unlike the arguments is not recorded in the block. The
+// public asset is written with an appraised value of zero.
+func (s *AssetContract) CreateAssetPrivate(ctx contractapi.TransactionContextInterface, id string, color string, size int, owner string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: CreateAssetPrivate - ID: %s =====", id)
+
+	transientMap, err := ctx.GetStub().GetTransient()
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read transient data: %v", err)
+		return fmt.Errorf("failed to read transient data: %w", err)
+	}
+	propertiesJSON, ok := transientMap[transientPropertiesKey]
+	if !ok || len(propertiesJSON) == 0 {
+		logf(ctx, "ERROR: Transient key %q is missing", transientPropertiesKey)
+		return fmt.Errorf("the transient map must contain the private properties under key %q: %w", transientPropertiesKey, ErrInvalidInput)
+	}
+	var properties assetPrivateProperties
+	if err := json.Unmarshal(propertiesJSON, &properties); err != nil || properties.AppraisedValue == nil {
+		logf(ctx, "ERROR: Invalid transient properties: %s", propertiesJSON)
+		return fmt.Errorf("transient %q entry must be a JSON object with an AppraisedValue: %w", transientPropertiesKey, ErrInvalidInput)
+	}
+
+	if err := s.createAssetPrivate(ctx, id, color, size, owner, *properties.AppraisedValue); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	logln(ctx, "===== END: CreateAssetPrivate =====")
+	return nil
+}
+
+// createAssetPrivate writes the public asset and its private details for
+// CreateAssetPrivate and CreateAssetFromTransient
+func (s *AssetContract) createAssetPrivate(ctx contractapi.TransactionContextInterface, id string, color string, size int, owner string, appraisedValue int) error {
+	// The public part gets its own event below, without the appraised value
+	if err := s.createAsset(withoutEvents(ctx), id, color, size, owner, appraisedValue, time.Time{}, true); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := putAssetPrivateDetails(ctx, AssetPrivateDetails{ID: id, AppraisedValue: appraisedValue}); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	err = emitEvent(ctx, "AssetCreated", map[string]interface{}{
+		"assetID":    id,
+		"owner":      owner,
+		"collection": appraisalCollection,
+		"createdBy":  clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Successfully created private asset %s", id)
+	return nil
+}
+
+// transientAssetKey is the transient map entry holding the asset JSON of
+// CreateAssetFromTransient
+const transientAssetKey = "asset"
+
+// CreateAssetFromTransient creates an asset from the JSON object passed in the
+// "asset" entry of the transient map. Transient data is not recorded in the
+// block, and the appraised value goes to the appraisal collection like in
+// CreateAssetPrivate, so the value never appears in the transaction.
+func (s *AssetContract) CreateAssetFromTransient(ctx contractapi.TransactionContextInterface) error {
+	logln(ctx, "===== START: CreateAssetFromTransient =====")
+
+	transientMap, err := ctx.GetStub().GetTransient()
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read transient data: %v", err)
+		return fmt.Errorf("failed to read transient data: %w", err)
+	}
+	assetJSON, ok := transientMap[transientAssetKey]
+	if !ok || len(assetJSON) == 0 {
+		logf(ctx, "ERROR: Transient key %q is missing", transientAssetKey)
+		return fmt.Errorf("the transient map must contain the asset JSON under key %q: %w", transientAssetKey, ErrInvalidInput)
+	}
+
+	if err := validateAssetJSON(assetJSON); err != nil {
+		logf(ctx, "ERROR: Invalid transient asset: %v", err)
+		return fmt.Errorf("transient %q entry is not a valid asset JSON object: %w", transientAssetKey, err)
+	}
+	var input Asset
+	if err := json.Unmarshal(assetJSON, &input); err != nil {
+		logf(ctx, "ERROR: Invalid transient asset: %v", err)
+		return fmt.Errorf("transient %q entry is not a valid asset JSON object: %v: %w", transientAssetKey, err, ErrInvalidInput)
+	}
+
+	if err := s.createAssetPrivate(ctx, normalizeAssetID(input.ID), input.Color, input.Size, input.Owner, input.AppraisedValue); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	logln(ctx, "===== END: CreateAssetFromTransient =====")
+	return nil
+}
+
+// ReadAssetPrivateDetails returns the private details of an asset. Only
+// organizations that are members of the appraisal collection can read them;
+// other callers get an ErrPrivateDataUnavailable error.
+func (s *AssetContract) ReadAssetPrivateDetails(ctx contractapi.TransactionContextInterface, id string) (*AssetPrivateDetails, error) {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: ReadAssetPrivateDetails - ID: %s =====", id)
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return nil, err
+	}
+
+	mspID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client MSP ID: %v", err)
+		mspID = "unknown"
+	}
+
+	detailsJSON, err := ctx.GetStub().GetPrivateData(appraisalCollection, id)
+	if err != nil {
+		// The peer refuses reads from organizations outside the collection policy
+		logf(ctx, "ERROR: Failed to read private details of asset %s for %s: %v", id, mspID, err)
+		return nil, fmt.Errorf("organization %s cannot read collection %s: %v: %w", mspID, appraisalCollection, err, ErrPrivateDataUnavailable)
+	}
+	if detailsJSON == nil {
+		// Peers of non-member organizations hold no private data at all, so an
+		// existing asset without details is reported as unavailable
+		exists, err := s.AssetExists(ctx, id)
+		if err != nil {
+			logf(ctx, "ERROR: Failed to check asset existence: %v", err)
+			return nil, fmt.Errorf("failed to check asset existence: %w", err)
+		}
+		if !exists {
+			logf(ctx, "ERROR: Asset %s does not exist", id)
+			return nil, fmt.Errorf("the asset %s does not exist: %w", id, ErrAssetNotFound)
+		}
+		logf(ctx, "ERROR: No private details of asset %s available to %s", id, mspID)
+		return nil, fmt.Errorf("private details of asset %s are not available to organization %s: %w", id, mspID, ErrPrivateDataUnavailable)
+	}
+
+	var details AssetPrivateDetails
+	if err := json.Unmarshal(detailsJSON, &details); err != nil {
+		logf(ctx, "ERROR: Failed to unmarshal private details: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal private details: %w", err)
+	}
+
+	logln(ctx, "===== END: ReadAssetPrivateDetails =====")
+	return &details, nil
+}
+
+// PrivateHashVerification is the result of VerifyAssetPrivateHash
+type PrivateHashVerification struct {
+	Match      bool   `json:"Match"`
+	ActualHash string `json:"ActualHash"`
+}
+
+// VerifyAssetPrivateHash compares a hex-encoded SHA-256 hash of an asset's
+// private details with the hash recorded on the channel. Every organization
+// can verify the hash, including those that cannot read the details.
+func (s *AssetContract) VerifyAssetPrivateHash(ctx contractapi.TransactionContextInterface, id string, expectedHashHex string) (*PrivateHashVerification, error) {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: VerifyAssetPrivateHash - ID: %s =====", id)
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return nil, err
+	}
+	expectedHash, err := hex.DecodeString(expectedHashHex)
+	if err != nil || len(expectedHash) != sha256.Size {
+		logf(ctx, "ERROR: Invalid expected hash: %q", expectedHashHex)
+		return nil, fmt.Errorf("expected hash must be a hex-encoded SHA-256 hash: %w", ErrInvalidInput)
+	}
+
+	actualHash, err := ctx.GetStub().GetPrivateDataHash(appraisalCollection, id)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read private data hash: %v", err)
+		return nil, fmt.Errorf("failed to read private data hash from collection %s: %w", appraisalCollection, err)
+	}
+	if actualHash == nil {
+		logf(ctx, "ERROR: No private data hash for asset %s", id)
+		return nil, fmt.Errorf("no private details recorded for asset %s: %w", id, ErrAssetNotFound)
+	}
+
+	result := &PrivateHashVerification{
+		Match:      bytes.Equal(expectedHash, actualHash),
+		ActualHash: hex.EncodeToString(actualHash),
+	}
+
+	logf(ctx, "INFO: Private hash of asset %s matches: %t", id, result.Match)
+	logln(ctx, "===== END: VerifyAssetPrivateHash =====")
+	return result, nil
+}
+
+// putAssetPrivateDetails writes the private details of an asset to the
+// appraisal collection
+func putAssetPrivateDetails(ctx contractapi.TransactionContextInterface, details AssetPrivateDetails) error {
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to marshal private details: %w", err)
+	}
+	if err := ctx.GetStub().PutPrivateData(appraisalCollection, details.ID, detailsJSON); err != nil {
+		return fmt.Errorf("failed to put private details to collection %s: %w", appraisalCollection, err)
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test keeping the appraised value in the appraisal collection
+func TestAssetPrivateDetails(t *testing.T) {
+	contract := AssetContract{}
+
+	t.Run("Create Splits Public And Private Data", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		ctx := &MockTransactionContext{stub: stub}
+
+		var public Asset
+		var private AssetPrivateDetails
+		stub.On("GetTransient").Return(map[string][]byte{"asset_properties": []byte(`{"AppraisedValue":500}`)}, nil).Once()
+		stub.On("GetState", "asset1").Return(nil, nil).Once()
+		stub.On("PutState", "asset1", mock.AnythingOfType("[]uint8")).Run(func(args mock.Arguments) {
+			require.NoError(t, json.Unmarshal(args.Get(1).([]byte), &public))
+		}).Return(nil).Once()
+		stub.On("PutPrivateData", appraisalCollection, "asset1", mock.AnythingOfType("[]uint8")).Run(func(args mock.Arguments) {
+			require.NoError(t, json.Unmarshal(args.Get(2).([]byte), &private))
+		}).Return(nil).Once()
+		var event map[string]interface{}
+		stub.On("SetEvent", "AssetCreated", mock.AnythingOfType("[]uint8")).Run(func(args mock.Arguments) {
+			var envelope EventEnvelope
+			require.NoError(t, json.Unmarshal(args.Get(1).([]byte), &envelope))
+			event = envelope.Data.(map[string]interface{})
+		}).Return(nil).Once()
+		stub.expectOwnerIndexPut("John", "asset1")
+		stub.expectEventLog()
+		stub.expectChangeLog()
+
+		require.NoError(t, contract.CreateAssetPrivate(ctx, "asset1", "blue", 10, "John"))
+		assert.Equal(t, 0, public.AppraisedValue)
+		assert.Equal(t, "John", public.Owner)
+		assert.Equal(t, AssetPrivateDetails{ID: "asset1", AppraisedValue: 500}, private)
+		assert.NotContains(t, event, "appraisedValue")
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Create Rejects Invalid Value", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetTransient").Return(map[string][]byte{"asset_properties": []byte(`{"AppraisedValue":-1}`)}, nil).Once()
+
+		err := contract.CreateAssetPrivate(ctx, "asset1", "blue", 10, "John")
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Create Under Minimum Value Limit", func(t *testing.T) {
+		// The limit applies to the private value, not to the zero public copy
+		newStub := func(value string) *MockStub {
+			stub := new(MockStub)
+			configKey := createCompositeKey(configObjectType, []string{configName})
+			stub.On("GetState", configKey).Return([]byte(`{"Limits":{"MinAppraisedValue":100}}`), nil).Maybe()
+			stub.On("GetTransient").Return(map[string][]byte{"asset_properties": []byte(`{"AppraisedValue":` + value + `}`)}, nil).Once()
+			return stub
+		}
+
+		stub := newStub("500")
+		var public Asset
+		stub.On("GetState", "asset1").Return(nil, nil).Once()
+		stub.On("PutState", "asset1", mock.AnythingOfType("[]uint8")).Run(func(args mock.Arguments) {
+			require.NoError(t, json.Unmarshal(args.Get(1).([]byte), &public))
+		}).Return(nil).Once()
+		stub.On("PutPrivateData", appraisalCollection, "asset1", mock.AnythingOfType("[]uint8")).Return(nil).Once()
+		stub.On("SetEvent", "AssetCreated", mock.AnythingOfType("[]uint8")).Return(nil).Once()
+		stub.expectOwnerIndexPut("John", "asset1")
+		stub.expectEventLog()
+		stub.expectChangeLog()
+		require.NoError(t, contract.CreateAssetPrivate(&MockTransactionContext{stub: stub}, "asset1", "blue", 10, "John"))
+		assert.Equal(t, 0, public.AppraisedValue)
+		stub.AssertExpectations(t)
+
+		stub = newStub("50")
+		err := contract.CreateAssetPrivate(&MockTransactionContext{stub: stub}, "asset1", "blue", 10, "John")
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Create Requires Transient Value", func(t *testing.T) {
+		for name, transient := range map[string]map[string][]byte{
+			"Missing Key":   {},
+			"Missing Value": {"asset_properties": []byte(`{}`)},
+			"Malformed":     {"asset_properties": []byte(`{"AppraisedValue":`)},
+		} {
+			stub := new(MockStub)
+			ctx := &MockTransactionContext{stub: stub}
+			stub.On("GetTransient").Return(transient, nil).Once()
+
+			err := contract.CreateAssetPrivate(ctx, "asset1", "blue", 10, "John")
+			assert.True(t, errors.Is(err, ErrInvalidInput), name)
+			assert.Contains(t, err.Error(), `"asset_properties"`, name)
+			stub.AssertExpectations(t)
+		}
+	})
+
+	t.Run("Member Reads Details", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		detailsJSON, _ := json.Marshal(AssetPrivateDetails{ID: "asset1", AppraisedValue: 500})
+		stub.On("GetPrivateData", appraisalCollection, "asset1").Return(detailsJSON, nil).Once()
+
+		details, err := contract.ReadAssetPrivateDetails(ctx, "asset1")
+		require.NoError(t, err)
+		assert.Equal(t, 500, details.AppraisedValue)
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Read Denied By Collection Policy", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub, identity: &MockClientIdentity{ID: "x509::CN=User1@org2.example.com", MSPID: "Org2MSP"}}
+		stub.On("GetPrivateData", appraisalCollection, "asset1").
+			Return(nil, errors.New("tx creator does not have read access permission on privatedata")).Once()
+
+		_, err := contract.ReadAssetPrivateDetails(ctx, "asset1")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrPrivateDataUnavailable))
+		assert.Contains(t, err.Error(), "Org2MSP")
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Non-Member Peer Has No Details", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		assetJSON, _ := json.Marshal(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John"})
+		stub.On("GetPrivateData", appraisalCollection, "asset1").Return(nil, nil).Once()
+		stub.On("GetState", "asset1").Return(assetJSON, nil).Once()
+
+		_, err := contract.ReadAssetPrivateDetails(ctx, "asset1")
+		assert.True(t, errors.Is(err, ErrPrivateDataUnavailable))
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Asset Does Not Exist", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetPrivateData", appraisalCollection, "asset2").Return(nil, nil).Once()
+		stub.On("GetState", "asset2").Return(nil, nil).Once()
+
+		_, err := contract.ReadAssetPrivateDetails(ctx, "asset2")
+		assert.True(t, errors.Is(err, ErrAssetNotFound))
+		stub.AssertExpectations(t)
+	})
+}
+
+// Test creating an asset from the transient map
+func TestCreateAssetFromTransient(t *testing.T) {
+	contract := AssetContract{}
+
+	t.Run("Valid Transient Asset", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetTransient").Return(map[string][]byte{
+			"asset": []byte(`{"ID":"asset1","Color":"blue","Size":10,"Owner":"John","AppraisedValue":500}`),
+		}, nil).Once()
+
+		var private AssetPrivateDetails
+		stub.On("GetState", "asset1").Return(nil, nil).Once()
+		stub.On("PutState", "asset1", mock.AnythingOfType("[]uint8")).Return(nil).Once()
+		stub.On("PutPrivateData", appraisalCollection, "asset1", mock.AnythingOfType("[]uint8")).Run(func(args mock.Arguments) {
+			require.NoError(t, json.Unmarshal(args.Get(2).([]byte), &private))
+		}).Return(nil).Once()
+		stub.On("SetEvent", "AssetCreated", mock.AnythingOfType("[]uint8")).Return(nil).Once()
+		stub.expectOwnerIndexPut("John", "asset1")
+		stub.expectEventLog()
+		stub.expectChangeLog()
+
+		require.NoError(t, contract.CreateAssetFromTransient(ctx))
+		assert.Equal(t, 500, private.AppraisedValue)
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Missing Key", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetTransient").Return(map[string][]byte{"other": []byte("{}")}, nil).Once()
+
+		err := contract.CreateAssetFromTransient(ctx)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Contains(t, err.Error(), `key "asset"`)
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Malformed JSON", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetTransient").Return(map[string][]byte{"asset": []byte(`{"ID":`)}, nil).Once()
+
+		err := contract.CreateAssetFromTransient(ctx)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Contains(t, err.Error(), "not a valid asset JSON")
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Asset", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetTransient").Return(map[string][]byte{
+			"asset": []byte(`{"ID":"asset1","Color":"","Size":10,"Owner":"John","AppraisedValue":500}`),
+		}, nil).Once()
+
+		err := contract.CreateAssetFromTransient(ctx)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		stub.AssertExpectations(t)
+	})
+}
+
+// Test attesting private details against the on-chain hash
+func TestVerifyAssetPrivateHash(t *testing.T) {
+	contract := AssetContract{}
+	detailsJSON, _ := json.Marshal(AssetPrivateDetails{ID: "asset1", AppraisedValue: 500})
+	onChain := sha256.Sum256(detailsJSON)
+
+	verify := func(expected string) (*PrivateHashVerification, error) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetPrivateDataHash", appraisalCollection, "asset1").Return(onChain[:], nil).Maybe()
+		return contract.VerifyAssetPrivateHash(ctx, "asset1", expected)
+	}
+
+	t.Run("Matching Hash", func(t *testing.T) {
+		result, err := verify(hex.EncodeToString(onChain[:]))
+		require.NoError(t, err)
+		assert.True(t, result.Match)
+		assert.Equal(t, hex.EncodeToString(onChain[:]), result.ActualHash)
+	})
+
+	t.Run("Hash Is Case Insensitive", func(t *testing.T) {
+		result, err := verify(strings.ToUpper(hex.EncodeToString(onChain[:])))
+		require.NoError(t, err)
+		assert.True(t, result.Match)
+	})
+
+	t.Run("Different Details", func(t *testing.T) {
+		otherJSON, _ := json.Marshal(AssetPrivateDetails{ID: "asset1", AppraisedValue: 600})
+		other := sha256.Sum256(otherJSON)
+		result, err := verify(hex.EncodeToString(other[:]))
+		require.NoError(t, err)
+		assert.False(t, result.Match)
+		assert.Equal(t, hex.EncodeToString(onChain[:]), result.ActualHash)
+	})
+
+	t.Run("Malformed Expected Hash", func(t *testing.T) {
+		for _, expected := range []string{"", "not-hex", "abcd"} {
+			_, err := verify(expected)
+			assert.True(t, errors.Is(err, ErrInvalidInput), expected)
+		}
+	})
+
+	t.Run("No Private Data", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		stub.On("GetPrivateDataHash", appraisalCollection, "asset2").Return(nil, nil).Once()
+
+		_, err := contract.VerifyAssetPrivateHash(ctx, "asset2", hex.EncodeToString(onChain[:]))
+		assert.True(t, errors.Is(err, ErrAssetNotFound))
+		stub.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// ProposeTransfer records a pending transfer of an asset to proposedOwner, who
+// completes it with AcceptTransfer or declines it with RejectTransfer. Only
+// the owner may propose, and the asset keeps its owner until acceptance.
+func (s *AssetContract) ProposeTransfer(ctx contractapi.TransactionContextInterface, id string, proposedOwner string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: ProposeTransfer - ID: %s, Proposed Owner: %s =====", id, proposedOwner)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+	if err := validateOwner(proposedOwner); err != nil {
+		logf(ctx, "ERROR: Invalid proposed owner: %v", err)
+		return err
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read asset %s: %v", id, err)
+		return err
+	}
+	if !clientActsAs(ctx, asset.Owner) {
+		logf(ctx, "ERROR: Caller may not propose a transfer of asset %s owned by %s", id, asset.Owner)
+		return fmt.Errorf("only the owner may propose a transfer of asset %s", id)
+	}
+	if err := checkNotLocked(asset); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if asset.PendingOwner != "" {
+		logf(ctx, "ERROR: Asset %s already has a pending transfer to %s", id, asset.PendingOwner)
+		return fmt.Errorf("asset %s already has a pending transfer to %s", id, asset.PendingOwner)
+	}
+	if asset.EscrowOwner != "" {
+		logf(ctx, "ERROR: Asset %s is in escrow for %s", id, asset.EscrowOwner)
+		return fmt.Errorf("asset %s is in escrow for %s", id, asset.EscrowOwner)
+	}
+	if asset.Owner == proposedOwner {
+		logf(ctx, "ERROR: Asset %s is already owned by %s", id, proposedOwner)
+		return fmt.Errorf("asset %s is already owned by %s", id, proposedOwner)
+	}
+	if err := checkRecipientAllowed(asset, proposedOwner); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	asset.PendingOwner = proposedOwner
+	asset.TransferProposedAt = now
+	stampUpdate(ctx, asset, now, clientID)
+
+	if err := s.putEscrowedAsset(ctx, asset, "propose"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "TransferProposed", map[string]interface{}{
+		"assetID":       id,
+		"owner":         asset.Owner,
+		"proposedOwner": proposedOwner,
+		"proposedBy":    clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Transfer of asset %s to %s proposed", id, proposedOwner)
+	logln(ctx, "===== END: ProposeTransfer =====")
+	return nil
+}
+
+// acceptProposedTransfer completes the pending transfer of an asset. It is
+// called by AcceptTransfer, which also serves escrowed assets.
+func (s *AssetContract) acceptProposedTransfer(ctx contractapi.TransactionContextInterface, asset *Asset) error {
+	if !clientActsAs(ctx, asset.PendingOwner) {
+		logf(ctx, "ERROR: Caller may not accept asset %s on behalf of %s", asset.ID, asset.PendingOwner)
+		return fmt.Errorf("only %s may accept asset %s", asset.PendingOwner, asset.ID)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	oldOwner := asset.Owner
+	asset.Owner = asset.PendingOwner
+	asset.Owners = nil
+	clearPendingTransfer(asset)
+	stampUpdate(ctx, asset, now, clientID)
+
+	if err := s.putEscrowedAsset(ctx, asset, "transfer"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if err := moveOwnerIndex(ctx, oldOwner, asset.Owner, asset.ID); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "TransferAccepted", map[string]interface{}{
+		"assetID":      asset.ID,
+		"oldOwner":     oldOwner,
+		"newOwner":     asset.Owner,
+		"acceptedBy":   clientID,
+		"settledPrice": asset.AppraisedValue,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Proposed transfer of asset %s accepted by %s", asset.ID, asset.Owner)
+	return nil
+}
+
+// RejectTransfer declines the pending transfer of an asset, which stays with
+// its owner. The proposed owner may reject it and the owner may withdraw it.
+func (s *AssetContract) RejectTransfer(ctx contractapi.TransactionContextInterface, id string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: RejectTransfer - ID: %s =====", id)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read asset %s: %v", id, err)
+		return err
+	}
+	if asset.PendingOwner == "" {
+		logf(ctx, "ERROR: Asset %s has no pending transfer", id)
+		return fmt.Errorf("asset %s has no pending transfer", id)
+	}
+	if !clientActsAs(ctx, asset.PendingOwner) && !clientActsAs(ctx, asset.Owner) {
+		logf(ctx, "ERROR: Caller may not reject the transfer of asset %s", id)
+		return fmt.Errorf("only %s or %s may reject the transfer of asset %s", asset.PendingOwner, asset.Owner, id)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	proposedOwner := asset.PendingOwner
+	clearPendingTransfer(asset)
+	stampUpdate(ctx, asset, now, clientID)
+
+	if err := s.putEscrowedAsset(ctx, asset, "reject"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "TransferRejected", map[string]interface{}{
+		"assetID":       id,
+		"owner":         asset.Owner,
+		"proposedOwner": proposedOwner,
+		"rejectedBy":    clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Transfer of asset %s to %s rejected", id, proposedOwner)
+	logln(ctx, "===== END: RejectTransfer =====")
+	return nil
+}
+
+// clearPendingTransfer removes any proposed transfer from the asset
+func clearPendingTransfer(asset *Asset) {
+	asset.PendingOwner = ""
+	asset.TransferProposedAt = time.Time{}
+}
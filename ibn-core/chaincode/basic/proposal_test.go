@@ -0,0 +1,139 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test the propose/accept/reject transfer workflow
+func TestProposeTransfer(t *testing.T) {
+	contract := AssetContract{}
+
+	setup := func(t *testing.T) *Ledger {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+		stub, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.ProposeTransfer(ctx, "asset1", "Jane")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "TransferProposed", stub.LastEvent().EventName)
+		return ledger
+	}
+
+	t.Run("Propose Records Pending Transfer", func(t *testing.T) {
+		ledger := setup(t)
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "John", asset.Owner)
+		assert.Equal(t, "Jane", asset.PendingOwner)
+		assert.False(t, asset.TransferProposedAt.IsZero())
+	})
+
+	t.Run("Propose Then Accept", func(t *testing.T) {
+		ledger := setup(t)
+
+		stub, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.AcceptTransfer(ctx, "asset1")
+		})
+		require.NoError(t, err)
+		event := stub.LastEvent()
+		assert.Equal(t, "TransferAccepted", event.EventName)
+		payload := eventData(t, event)
+		assert.Equal(t, "John", payload["oldOwner"])
+		assert.Equal(t, "Jane", payload["newOwner"])
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "Jane", asset.Owner)
+		assert.Empty(t, asset.PendingOwner)
+		assert.True(t, asset.TransferProposedAt.IsZero())
+		assert.NotNil(t, ledger.Get(createCompositeKey(ownerIndexObjectType, []string{"Jane", "asset1"})))
+	})
+
+	t.Run("Only Proposed Owner Accepts", func(t *testing.T) {
+		ledger := setup(t)
+
+		for _, identity := range []*MockClientIdentity{ownerIdentity("John"), ownerIdentity("Max")} {
+			_, err := ledger.Invoke(identity, func(ctx *MockTransactionContext) error {
+				return contract.AcceptTransfer(ctx, "asset1")
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "only Jane may accept")
+		}
+		assert.Equal(t, "John", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Propose Then Reject", func(t *testing.T) {
+		ledger := setup(t)
+
+		stub, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.RejectTransfer(ctx, "asset1")
+		})
+		require.NoError(t, err)
+		event := stub.LastEvent()
+		assert.Equal(t, "TransferRejected", event.EventName)
+		assert.Equal(t, "Jane", eventData(t, event)["proposedOwner"])
+
+		asset := readCommitted(t, ledger, "asset1")
+		assert.Equal(t, "John", asset.Owner)
+		assert.Empty(t, asset.PendingOwner)
+
+		_, err = ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.AcceptTransfer(ctx, "asset1")
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Owner Withdraws Proposal", func(t *testing.T) {
+		ledger := setup(t)
+
+		_, err := ledger.Invoke(ownerIdentity("Max"), func(ctx *MockTransactionContext) error {
+			return contract.RejectTransfer(ctx, "asset1")
+		})
+		assert.Error(t, err)
+
+		_, err = ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.RejectTransfer(ctx, "asset1")
+		})
+		require.NoError(t, err)
+		assert.Empty(t, readCommitted(t, ledger, "asset1").PendingOwner)
+	})
+
+	t.Run("Only Owner Proposes", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+
+		_, err := ledger.Invoke(ownerIdentity("Max"), func(ctx *MockTransactionContext) error {
+			return contract.ProposeTransfer(ctx, "asset1", "Max")
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only the owner may propose")
+	})
+
+	t.Run("Locked Asset Cannot Be Proposed", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300, Locked: true})
+
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.ProposeTransfer(ctx, "asset1", "Jane")
+		})
+		assert.ErrorIs(t, err, ErrAssetLocked)
+		assert.Empty(t, readCommitted(t, ledger, "asset1").PendingOwner)
+	})
+
+	t.Run("Pending Transfer Blocks Other Transfers", func(t *testing.T) {
+		ledger := setup(t)
+
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.ProposeTransfer(ctx, "asset1", "Max")
+		})
+		assert.Error(t, err)
+
+		_, err = ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.TransferAsset(ctx, "asset1", "Max")
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pending transfer to Jane")
+	})
+}
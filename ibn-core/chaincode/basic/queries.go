@@ -0,0 +1,619 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// levelDBQueryError is the text of the error LevelDB peers return for every
+// rich query, e.g. "ExecuteQuery not supported for leveldb"
+const levelDBQueryError = "not supported for leveldb"
+
+// queryError wraps an error from executing a rich query. The opaque LevelDB
+// rejection becomes an ErrRichQueryUnsupported error naming alternative, the
+// function that answers the same question without a rich query.
+func queryError(err error, alternative string) error {
+	if strings.Contains(err.Error(), levelDBQueryError) {
+		return fmt.Errorf("%w; use %s instead: %v", ErrRichQueryUnsupported, alternative, err)
+	}
+	return fmt.Errorf("failed to execute query: %w", err)
+}
+
+// assetFieldNames returns the JSON field names of Asset in declaration order
+func assetFieldNames() []string {
+	t := reflect.TypeOf(Asset{})
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// parseFieldList splits a comma-separated list of Asset field names,
+// rejecting unknown and duplicate names
+func parseFieldList(fieldsCSV string) ([]string, error) {
+	known := make(map[string]bool)
+	for _, name := range assetFieldNames() {
+		known[name] = true
+	}
+
+	var fields []string
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(fieldsCSV, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !known[field] {
+			return nil, fmt.Errorf("unknown asset field %q", field)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("field %q is listed more than once", field)
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("at least one field must be requested")
+	}
+	return fields, nil
+}
+
+// GetAssetsByOwnerFields returns the assets of an owner projected to the requested
+// comma-separated fields. The projection is done by CouchDB through the fields
+// clause so only the requested data leaves the state database.
+func (s *AssetContract) GetAssetsByOwnerFields(ctx contractapi.TransactionContextInterface, owner string, fieldsCSV string) ([]map[string]interface{}, error) {
+	logf(ctx, "===== START: GetAssetsByOwnerFields - Owner: %s, Fields: %s =====", owner, fieldsCSV)
+
+	if err := validateOwner(owner); err != nil {
+		logf(ctx, "ERROR: Invalid owner: %v", err)
+		return nil, err
+	}
+	fields, err := parseFieldList(fieldsCSV)
+	if err != nil {
+		logf(ctx, "ERROR: Invalid field list: %v", err)
+		return nil, err
+	}
+
+	query, err := json.Marshal(map[string]interface{}{
+		"selector": map[string]interface{}{"Owner": owner},
+		"fields":   fields,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to build query: %v", err)
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(string(query))
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return nil, queryError(err, "QueryAssetsByOwnerIndexed")
+	}
+	defer resultsIterator.Close()
+
+	// Projected documents hold only the requested fields, so they are decoded
+	// as maps instead of through collectAssets
+	results := []map[string]interface{}{}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate query results: %v", err)
+			return nil, fmt.Errorf("failed to iterate query results: %w", err)
+		}
+
+		var projected map[string]interface{}
+		err = json.Unmarshal(queryResponse.Value, &projected)
+		if err != nil {
+			logf(ctx, "WARNING: Failed to unmarshal asset, skipping: %v", err)
+			continue
+		}
+		results = append(results, projected)
+	}
+
+	logf(ctx, "INFO: Found %d assets for owner %s", len(results), owner)
+	logln(ctx, "===== END: GetAssetsByOwnerFields =====")
+	return results, nil
+}
+
+// unvaluedAssetsQuery selects assets whose appraised value is zero or missing.
+// Requiring an ID keeps non-asset documents such as event log entries out.
+const unvaluedAssetsQuery = `{"selector":{"ID":{"$exists":true},"$or":[{"AppraisedValue":0},{"AppraisedValue":{"$exists":false}}]}}`
+
+// GetUnvaluedAssets returns assets with a missing or zero appraised value,
+// e.g. assets awaiting re-appraisal after a transfer that reset their value
+func (s *AssetContract) GetUnvaluedAssets(ctx contractapi.TransactionContextInterface) ([]*Asset, error) {
+	logln(ctx, "===== START: GetUnvaluedAssets =====")
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(unvaluedAssetsQuery)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return nil, queryError(err, "GetAllAssets")
+	}
+	assets, err := collectAssets(ctx, resultsIterator)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Found %d unvalued assets", len(assets))
+	logln(ctx, "===== END: GetUnvaluedAssets =====")
+	return assets, nil
+}
+
+// CountUnvaluedAssets returns the number of assets with a missing or zero appraised value
+func (s *AssetContract) CountUnvaluedAssets(ctx contractapi.TransactionContextInterface) (int, error) {
+	logln(ctx, "===== START: CountUnvaluedAssets =====")
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(unvaluedAssetsQuery)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return 0, queryError(err, "GetAllAssets")
+	}
+	defer resultsIterator.Close()
+
+	count := 0
+	for resultsIterator.HasNext() {
+		if _, err := resultsIterator.Next(); err != nil {
+			logf(ctx, "ERROR: Failed to iterate query results: %v", err)
+			return 0, fmt.Errorf("failed to iterate query results: %w", err)
+		}
+		count++
+	}
+
+	logf(ctx, "INFO: Counted %d unvalued assets", count)
+	logln(ctx, "===== END: CountUnvaluedAssets =====")
+	return count, nil
+}
+
+// GetOwnerAssetsSortedByValue returns the assets of an owner ordered by appraised value.
+// CouchDB can only sort on indexed fields; the query relies on the compound
+// Owner + AppraisedValue index in META-INF/statedb/couchdb/indexes/indexOwnerValue.json,
+// and sorts on both fields in the same direction as that index requires.
+func (s *AssetContract) GetOwnerAssetsSortedByValue(ctx contractapi.TransactionContextInterface, owner string, desc bool) ([]*Asset, error) {
+	logf(ctx, "===== START: GetOwnerAssetsSortedByValue - Owner: %s, Desc: %t =====", owner, desc)
+
+	if err := validateOwner(owner); err != nil {
+		logf(ctx, "ERROR: Invalid owner: %v", err)
+		return nil, err
+	}
+
+	direction := "asc"
+	if desc {
+		direction = "desc"
+	}
+	query, err := json.Marshal(map[string]interface{}{
+		"selector":  map[string]interface{}{"Owner": owner},
+		"sort":      []map[string]string{{"Owner": direction}, {"AppraisedValue": direction}},
+		"use_index": []string{"_design/indexOwnerValueDoc", "indexOwnerValue"},
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to build query: %v", err)
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(string(query))
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return nil, queryError(err, "QueryAssetsByOwnerIndexed")
+	}
+	assets, err := collectAssets(ctx, resultsIterator)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Found %d assets for owner %s", len(assets), owner)
+	logln(ctx, "===== END: GetOwnerAssetsSortedByValue =====")
+	return assets, nil
+}
+
+// GetAssetsByOrgCreatedInRange returns the assets created by members of mspID
+// with a CreatedAt in [startUnix, endUnix), sorted by creation time
+func (s *AssetContract) GetAssetsByOrgCreatedInRange(ctx contractapi.TransactionContextInterface, mspID string, startUnix int64, endUnix int64) ([]*Asset, error) {
+	logf(ctx, "===== START: GetAssetsByOrgCreatedInRange - MSP: %s, Start: %d, End: %d =====", mspID, startUnix, endUnix)
+
+	if strings.TrimSpace(mspID) == "" {
+		logln(ctx, "ERROR: MSP ID is empty")
+		return nil, fmt.Errorf("MSP ID cannot be empty")
+	}
+	if startUnix < 0 || endUnix <= startUnix {
+		logf(ctx, "ERROR: Invalid window [%d, %d)", startUnix, endUnix)
+		return nil, fmt.Errorf("invalid time window: start must be non-negative and before end")
+	}
+
+	query, err := json.Marshal(map[string]interface{}{
+		"selector": map[string]interface{}{"CreatorOrg": mspID},
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to build query: %v", err)
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(string(query))
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return nil, queryError(err, "GetAllAssets")
+	}
+	created, err := collectAssets(ctx, resultsIterator)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	start := time.Unix(startUnix, 0)
+	end := time.Unix(endUnix, 0)
+	assets := []*Asset{}
+	for _, asset := range created {
+		// CreatedAt is compared here rather than in the selector because stored
+		// timestamps may carry different zone offsets and so do not sort as strings
+		if asset.CreatedAt.Before(start) || !asset.CreatedAt.Before(end) {
+			continue
+		}
+		assets = append(assets, asset)
+	}
+
+	sort.SliceStable(assets, func(i, j int) bool {
+		return assets[i].CreatedAt.Before(assets[j].CreatedAt)
+	})
+
+	logf(ctx, "INFO: Found %d assets created by %s in window", len(assets), mspID)
+	logln(ctx, "===== END: GetAssetsByOrgCreatedInRange =====")
+	return assets, nil
+}
+
+// QueryAssetsByCreatedRange returns the assets created within the Unix seconds
+// fromUnix and toUnix, both inclusive, sorted by creation time.
+//
+// CreatedAt is stored in UTC as RFC 3339 with only the significant fractional
+// digits, so "10.5Z" sorts before "10Z" as a string. The selector bounds are
+// therefore chosen on whole seconds: anything greater than the second before
+// fromUnix and up to "toUnix Z" holds exactly the timestamps whose second lies
+// in the range. Timestamps written with another zone offset are rechecked in Go.
+func (s *AssetContract) QueryAssetsByCreatedRange(ctx contractapi.TransactionContextInterface, fromUnix int64, toUnix int64) ([]*Asset, error) {
+	logf(ctx, "===== START: QueryAssetsByCreatedRange - From: %d, To: %d =====", fromUnix, toUnix)
+
+	if fromUnix < 0 || toUnix < fromUnix {
+		logf(ctx, "ERROR: Invalid range [%d, %d]", fromUnix, toUnix)
+		return nil, fmt.Errorf("invalid time range: from must be non-negative and not after to: %w", ErrInvalidInput)
+	}
+
+	query, err := json.Marshal(map[string]interface{}{
+		"selector": map[string]interface{}{
+			"CreatedAt": map[string]string{
+				"$gt":  time.Unix(fromUnix-1, 0).UTC().Format(time.RFC3339),
+				"$lte": time.Unix(toUnix, 0).UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to build query: %v", err)
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(string(query))
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return nil, queryError(err, "GetAllAssets")
+	}
+
+	found, err := collectAssets(ctx, resultsIterator)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	assets := []*Asset{}
+	for _, asset := range found {
+		if created := asset.CreatedAt.Unix(); created < fromUnix || created > toUnix {
+			continue
+		}
+		assets = append(assets, asset)
+	}
+	sort.SliceStable(assets, func(i, j int) bool {
+		return assets[i].CreatedAt.Before(assets[j].CreatedAt)
+	})
+
+	logf(ctx, "INFO: Found %d assets created in range", len(assets))
+	logln(ctx, "===== END: QueryAssetsByCreatedRange =====")
+	return assets, nil
+}
+
+// OwnerDelta is the set of an owner's assets changed after a cursor together
+// with the cursor to pass to the next call
+type OwnerDelta struct {
+	Assets []*Asset `json:"Assets"`
+	Cursor int64    `json:"Cursor"`
+}
+
+// GetOwnerAssetsSince returns the assets of owner last updated strictly after
+// sinceUnixNano, sorted by UpdatedAt ascending. The returned cursor is the
+// latest UpdatedAt in nanoseconds, or sinceUnixNano when nothing changed; a
+// cursor in whole seconds would skip changes committed later in the same second.
+func (s *AssetContract) GetOwnerAssetsSince(ctx contractapi.TransactionContextInterface, owner string, sinceUnixNano int64) (*OwnerDelta, error) {
+	logf(ctx, "===== START: GetOwnerAssetsSince - Owner: %s, Since: %d =====", owner, sinceUnixNano)
+
+	if err := validateOwner(owner); err != nil {
+		logf(ctx, "ERROR: Invalid owner: %v", err)
+		return nil, err
+	}
+	if sinceUnixNano < 0 {
+		logf(ctx, "ERROR: Invalid cursor %d", sinceUnixNano)
+		return nil, fmt.Errorf("cursor cannot be negative")
+	}
+
+	assets, err := s.QueryAssetsByOwner(ctx, owner)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get assets for owner %s: %v", owner, err)
+		return nil, err
+	}
+
+	// UpdatedAt is compared here rather than in the selector for the same reason
+	// as in GetAssetsByOrgCreatedInRange
+	delta := &OwnerDelta{Assets: []*Asset{}, Cursor: sinceUnixNano}
+	for _, asset := range assets {
+		updated := asset.UpdatedAt.UnixNano()
+		if updated <= sinceUnixNano {
+			continue
+		}
+		delta.Assets = append(delta.Assets, asset)
+		if updated > delta.Cursor {
+			delta.Cursor = updated
+		}
+	}
+
+	sort.SliceStable(delta.Assets, func(i, j int) bool {
+		return delta.Assets[i].UpdatedAt.Before(delta.Assets[j].UpdatedAt)
+	})
+
+	logf(ctx, "INFO: Found %d assets of %s changed since %d", len(delta.Assets), owner, sinceUnixNano)
+	logln(ctx, "===== END: GetOwnerAssetsSince =====")
+	return delta, nil
+}
+
+// GetAssetsByColorSorted returns one page of assets of a color ordered by
+// appraised value. Like GetOwnerAssetsSortedByValue it relies on a compound
+// index, Color + AppraisedValue in META-INF/statedb/couchdb/indexes/indexColorValue.json.
+func (s *AssetContract) GetAssetsByColorSorted(ctx contractapi.TransactionContextInterface, color string, desc bool, pageSize int32, bookmark string) (*PagedAssets, error) {
+	logf(ctx, "===== START: GetAssetsByColorSorted - Color: %s, Desc: %t, Page Size: %d =====", color, desc, pageSize)
+
+	if color == "" || len(color) > 32 {
+		logf(ctx, "ERROR: Invalid color %q", color)
+		return nil, fmt.Errorf("color must be between 1 and 32 characters")
+	}
+	if pageSize <= 0 || pageSize > maxSearchPageSize {
+		logf(ctx, "ERROR: Invalid page size %d", pageSize)
+		return nil, fmt.Errorf("page size must be between 1 and %d", maxSearchPageSize)
+	}
+
+	direction := "asc"
+	if desc {
+		direction = "desc"
+	}
+	query, err := json.Marshal(map[string]interface{}{
+		"selector":  map[string]interface{}{"Color": color},
+		"sort":      []map[string]string{{"Color": direction}, {"AppraisedValue": direction}},
+		"use_index": []string{"_design/indexColorValueDoc", "indexColorValue"},
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to build query: %v", err)
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	resultsIterator, metadata, err := ctx.GetStub().GetQueryResultWithPagination(string(query), pageSize, bookmark)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return nil, queryError(err, "GetAllAssetsSorted")
+	}
+
+	result, err := collectPagedAssets(ctx, resultsIterator, metadata, pageSize)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Found %d %s assets", len(result.Records), color)
+	logln(ctx, "===== END: GetAssetsByColorSorted =====")
+	return result, nil
+}
+
+// maxQueryLength bounds the size of a caller-supplied rich query
+const maxQueryLength = 8 * 1024
+
+// QueryAssets runs a caller-supplied CouchDB query, such as
+// {"selector":{"Color":"blue","Size":{"$gte":10}}}, and returns the matching
+// assets. Documents that are not assets, e.g. the contract config, are skipped.
+func (s *AssetContract) QueryAssets(ctx contractapi.TransactionContextInterface, queryString string) ([]*Asset, error) {
+	logf(ctx, "===== START: QueryAssets - Query: %s =====", queryString)
+
+	if len(queryString) > maxQueryLength {
+		logf(ctx, "ERROR: Query of %d bytes is too large", len(queryString))
+		return nil, fmt.Errorf("query cannot exceed %d bytes", maxQueryLength)
+	}
+	var query map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(queryString), &query); err != nil {
+		logf(ctx, "ERROR: Invalid query: %v", err)
+		return nil, fmt.Errorf("query must be a JSON object: %w", err)
+	}
+	if _, ok := query["selector"]; !ok {
+		logln(ctx, "ERROR: Query has no selector")
+		return nil, fmt.Errorf("query must contain a selector")
+	}
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(queryString)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return nil, queryError(err, "GetAllAssets")
+	}
+	assets, err := collectAssets(ctx, resultsIterator)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Query matched %d assets", len(assets))
+	logln(ctx, "===== END: QueryAssets =====")
+	return assets, nil
+}
+
+// maxMSPIDLength bounds the MSP ID accepted by QueryAssetsByMSP
+const maxMSPIDLength = 128
+
+// QueryAssetsByMSP returns the assets last written by members of the given MSP
+func (s *AssetContract) QueryAssetsByMSP(ctx contractapi.TransactionContextInterface, mspID string) ([]*Asset, error) {
+	logf(ctx, "===== START: QueryAssetsByMSP - MSP: %s =====", mspID)
+
+	if strings.TrimSpace(mspID) == "" {
+		logln(ctx, "ERROR: MSP ID is empty")
+		return nil, fmt.Errorf("MSP ID cannot be empty")
+	}
+	if len(mspID) > maxMSPIDLength {
+		logf(ctx, "ERROR: MSP ID of %d characters is too long", len(mspID))
+		return nil, fmt.Errorf("MSP ID cannot exceed %d characters", maxMSPIDLength)
+	}
+
+	query, err := json.Marshal(map[string]interface{}{
+		"selector": map[string]interface{}{"UpdatedByMSP": mspID},
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to build query: %v", err)
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(string(query))
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return nil, queryError(err, "GetAllAssets")
+	}
+	assets, err := collectAssets(ctx, resultsIterator)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Found %d assets for MSP %s", len(assets), mspID)
+	logln(ctx, "===== END: QueryAssetsByMSP =====")
+	return assets, nil
+}
+
+// QueryAssetsByValueRange returns the assets whose appraised value lies in
+// [minValue, maxValue]
+func (s *AssetContract) QueryAssetsByValueRange(ctx contractapi.TransactionContextInterface, minValue int, maxValue int) ([]*Asset, error) {
+	logf(ctx, "===== START: QueryAssetsByValueRange - Min: %d, Max: %d =====", minValue, maxValue)
+
+	limits, err := getValidationLimits(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+	if minValue < 0 || maxValue > limits.MaxAppraisedValue {
+		logf(ctx, "ERROR: Value range %d..%d is out of bounds", minValue, maxValue)
+		return nil, fmt.Errorf("values must be between 0 and %d: %w", limits.MaxAppraisedValue, ErrInvalidInput)
+	}
+	if minValue > maxValue {
+		logf(ctx, "ERROR: Minimum %d exceeds maximum %d", minValue, maxValue)
+		return nil, fmt.Errorf("minimum value cannot exceed maximum value: %w", ErrInvalidInput)
+	}
+
+	query, err := json.Marshal(map[string]interface{}{
+		"selector": map[string]interface{}{
+			"AppraisedValue": map[string]int{"$gte": minValue, "$lte": maxValue},
+		},
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to build query: %v", err)
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(string(query))
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return nil, queryError(err, "GetAllAssets")
+	}
+
+	assets, err := collectAssets(ctx, resultsIterator)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Found %d assets valued %d..%d", len(assets), minValue, maxValue)
+	logln(ctx, "===== END: QueryAssetsByValueRange =====")
+	return assets, nil
+}
+
+// collectAssets decodes the assets of a query iterator and closes it, also
+// when iteration fails. Records that are not assets are skipped.
+func collectAssets(ctx contractapi.TransactionContextInterface, resultsIterator shim.StateQueryIteratorInterface) ([]*Asset, error) {
+	defer resultsIterator.Close()
+
+	assets := []*Asset{}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate query results: %w", err)
+		}
+
+		var asset Asset
+		err = json.Unmarshal(queryResponse.Value, &asset)
+		if err != nil || asset.ID == "" {
+			logf(ctx, "WARNING: Skipping non-asset record %s", queryResponse.Key)
+			continue
+		}
+		assets = append(assets, &asset)
+	}
+	return assets, nil
+}
+
+// assetSortFields maps the fields GetAllAssetsSorted can sort on to their
+// ascending order
+var assetSortFields = map[string]func(a, b *Asset) bool{
+	"ID":             func(a, b *Asset) bool { return a.ID < b.ID },
+	"Owner":          func(a, b *Asset) bool { return a.Owner < b.Owner },
+	"AppraisedValue": func(a, b *Asset) bool { return a.AppraisedValue < b.AppraisedValue },
+	"CreatedAt":      func(a, b *Asset) bool { return a.CreatedAt.Before(b.CreatedAt) },
+}
+
+// GetAllAssetsSorted returns all assets like GetAllAssets, sorted on sortField,
+// one of ID, Owner, AppraisedValue and CreatedAt. Assets with equal values keep
+// ascending ID order in both directions.
+func (s *AssetContract) GetAllAssetsSorted(ctx contractapi.TransactionContextInterface, sortField string, descending bool) ([]*Asset, error) {
+	logf(ctx, "===== START: GetAllAssetsSorted - Field: %s, Descending: %t =====", sortField, descending)
+
+	less, ok := assetSortFields[sortField]
+	if !ok {
+		logf(ctx, "ERROR: Cannot sort on field %q", sortField)
+		return nil, fmt.Errorf("cannot sort on field %q, must be one of ID, Owner, AppraisedValue, CreatedAt: %w", sortField, ErrInvalidInput)
+	}
+
+	assets, err := s.GetAllAssets(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+	if assets == nil {
+		assets = []*Asset{}
+	}
+
+	// The range scan returns assets in ID order, which the stable sort keeps for ties
+	sort.SliceStable(assets, func(i, j int) bool {
+		if descending {
+			return less(assets[j], assets[i])
+		}
+		return less(assets[i], assets[j])
+	})
+
+	logf(ctx, "INFO: Sorted %d assets by %s", len(assets), sortField)
+	logln(ctx, "===== END: GetAllAssetsSorted =====")
+	return assets, nil
+}
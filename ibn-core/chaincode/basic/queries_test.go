@@ -0,0 +1,678 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test GetAssetsByOwnerFields
+func TestGetAssetsByOwnerFields(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500},
+		Asset{ID: "asset2", Color: "red", Size: 20, Owner: "Jane", AppraisedValue: 600},
+		Asset{ID: "asset3", Color: "green", Size: 30, Owner: "John", AppraisedValue: 700},
+	)
+
+	t.Run("Only Requested Fields Returned", func(t *testing.T) {
+		var results []map[string]interface{}
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			results, err = contract.GetAssetsByOwnerFields(ctx, "John", "ID, AppraisedValue")
+			return err
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		for _, result := range results {
+			assert.Len(t, result, 2)
+			assert.Contains(t, result, "ID")
+			assert.Contains(t, result, "AppraisedValue")
+		}
+		assert.Equal(t, "asset1", results[0]["ID"])
+		assert.Equal(t, float64(500), results[0]["AppraisedValue"])
+	})
+
+	t.Run("Unknown Field", func(t *testing.T) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			_, err := contract.GetAssetsByOwnerFields(ctx, "John", "ID,Price")
+			return err
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown asset field")
+	})
+
+	t.Run("Empty Field List", func(t *testing.T) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			_, err := contract.GetAssetsByOwnerFields(ctx, "John", " , ")
+			return err
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid Owner", func(t *testing.T) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			_, err := contract.GetAssetsByOwnerFields(ctx, "", "ID")
+			return err
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "owner cannot be empty")
+	})
+}
+
+// Test GetUnvaluedAssets and CountUnvaluedAssets
+func TestUnvaluedAssets(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 0},
+		Asset{ID: "asset2", Color: "red", Size: 20, Owner: "Jane", AppraisedValue: 600},
+		Asset{ID: "asset3", Color: "green", Size: 30, Owner: "John", AppraisedValue: 0},
+		Asset{ID: "asset4", Color: "white", Size: 40, Owner: "Max", AppraisedValue: 1},
+	)
+
+	t.Run("List Unvalued Assets", func(t *testing.T) {
+		var assets []*Asset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.GetUnvaluedAssets(ctx)
+			return err
+		})
+		require.NoError(t, err)
+		require.Len(t, assets, 2)
+		assert.Equal(t, "asset1", assets[0].ID)
+		assert.Equal(t, "asset3", assets[1].ID)
+	})
+
+	t.Run("Count Unvalued Assets", func(t *testing.T) {
+		var count int
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			count, err = contract.CountUnvaluedAssets(ctx)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+}
+
+// Test GetOwnerAssetsSortedByValue
+func TestGetOwnerAssetsSortedByValue(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 700},
+		Asset{ID: "asset2", Color: "red", Size: 20, Owner: "Jane", AppraisedValue: 100},
+		Asset{ID: "asset3", Color: "green", Size: 30, Owner: "John", AppraisedValue: 300},
+		Asset{ID: "asset4", Color: "white", Size: 40, Owner: "John", AppraisedValue: 500},
+	)
+
+	sortedIDs := func(t *testing.T, desc bool) []string {
+		var assets []*Asset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.GetOwnerAssetsSortedByValue(ctx, "John", desc)
+			return err
+		})
+		require.NoError(t, err)
+		var ids []string
+		for _, asset := range assets {
+			ids = append(ids, asset.ID)
+		}
+		return ids
+	}
+
+	t.Run("Ascending", func(t *testing.T) {
+		assert.Equal(t, []string{"asset3", "asset4", "asset1"}, sortedIDs(t, false))
+	})
+
+	t.Run("Descending", func(t *testing.T) {
+		assert.Equal(t, []string{"asset1", "asset4", "asset3"}, sortedIDs(t, true))
+	})
+
+	t.Run("Invalid Owner", func(t *testing.T) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			_, err := contract.GetOwnerAssetsSortedByValue(ctx, "", false)
+			return err
+		})
+		assert.Error(t, err)
+	})
+}
+
+// Test GetAssetsByOrgCreatedInRange
+func TestGetAssetsByOrgCreatedInRange(t *testing.T) {
+	contract := AssetContract{}
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 12, 0, 0, 0, time.UTC) }
+
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Owner: "John", CreatorOrg: "Org1MSP", CreatedAt: day(20)},
+		Asset{ID: "asset2", Owner: "John", CreatorOrg: "Org1MSP", CreatedAt: day(5)},
+		Asset{ID: "asset3", Owner: "Jane", CreatorOrg: "Org2MSP", CreatedAt: day(10)},
+		Asset{ID: "asset4", Owner: "Jane", CreatorOrg: "Org1MSP", CreatedAt: time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)},
+		Asset{ID: "asset5", Owner: "Jane", CreatorOrg: "Org1MSP", CreatedAt: day(12).In(time.FixedZone("UTC+2", 2*60*60))},
+	)
+
+	query := func(mspID string, start, end time.Time) ([]*Asset, error) {
+		var assets []*Asset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.GetAssetsByOrgCreatedInRange(ctx, mspID, start.Unix(), end.Unix())
+			return err
+		})
+		return assets, err
+	}
+
+	t.Run("Filters By Org And Window Sorted By CreatedAt", func(t *testing.T) {
+		assets, err := query("Org1MSP", day(1), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		var ids []string
+		for _, asset := range assets {
+			ids = append(ids, asset.ID)
+		}
+		assert.Equal(t, []string{"asset2", "asset5", "asset1"}, ids)
+	})
+
+	t.Run("Window End Is Exclusive", func(t *testing.T) {
+		assets, err := query("Org1MSP", day(1), day(20))
+		require.NoError(t, err)
+		assert.Len(t, assets, 2)
+	})
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		_, err := query(" ", day(1), day(2))
+		assert.Error(t, err)
+		_, err = query("Org1MSP", day(2), day(1))
+		assert.Error(t, err)
+	})
+
+	t.Run("CreateAsset Records Creator Org", func(t *testing.T) {
+		org2 := &MockClientIdentity{
+			ID:         "x509::CN=User1@org2.example.com",
+			MSPID:      "Org2MSP",
+			Attributes: map[string]string{writerAttribute: "true"},
+		}
+		_, err := ledger.Invoke(org2, func(ctx *MockTransactionContext) error {
+			return contract.CreateAsset(ctx, "asset6", "blue", 5, "Jane", 300)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Org2MSP", readCommitted(t, ledger, "asset6").CreatorOrg)
+	})
+}
+
+// Test GetOwnerAssetsSince as a change-data-capture cursor
+func TestGetOwnerAssetsSince(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.clock = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	since := func(owner string, cursor int64) (*OwnerDelta, error) {
+		var delta *OwnerDelta
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			delta, err = contract.GetOwnerAssetsSince(ctx, owner, cursor)
+			return err
+		})
+		return delta, err
+	}
+	update := func(id string, value int) {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.UpdateAsset(ctx, id, "blue", 5, "John", value)
+		})
+		require.NoError(t, err)
+	}
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 100, UpdatedAt: base.Add(3 * time.Hour)},
+		Asset{ID: "asset2", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 200, UpdatedAt: base.Add(1 * time.Hour)},
+		Asset{ID: "asset3", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300, UpdatedAt: base.Add(2 * time.Hour)},
+		Asset{ID: "asset4", Color: "blue", Size: 5, Owner: "Jane", AppraisedValue: 400, UpdatedAt: base.Add(4 * time.Hour)},
+	)
+
+	delta, err := since("John", base.Add(time.Hour).UnixNano())
+	require.NoError(t, err)
+	require.Len(t, delta.Assets, 2)
+	assert.Equal(t, "asset3", delta.Assets[0].ID)
+	assert.Equal(t, "asset1", delta.Assets[1].ID)
+	assert.Equal(t, base.Add(3*time.Hour).UnixNano(), delta.Cursor)
+
+	t.Run("Cursor Advances Past Returned Changes", func(t *testing.T) {
+		unchanged, err := since("John", delta.Cursor)
+		require.NoError(t, err)
+		assert.Empty(t, unchanged.Assets)
+		assert.Equal(t, delta.Cursor, unchanged.Cursor)
+
+		update("asset2", 250)
+		next, err := since("John", delta.Cursor)
+		require.NoError(t, err)
+		require.Len(t, next.Assets, 1)
+		assert.Equal(t, "asset2", next.Assets[0].ID)
+		assert.Greater(t, next.Cursor, delta.Cursor)
+	})
+
+	t.Run("Change Later In The Same Second", func(t *testing.T) {
+		ledger.Seed(Asset{ID: "asset5", Color: "blue", Size: 5, Owner: "Max", AppraisedValue: 500, UpdatedAt: base})
+		first, err := since("Max", 0)
+		require.NoError(t, err)
+		require.Len(t, first.Assets, 1)
+
+		ledger.Seed(Asset{ID: "asset6", Color: "blue", Size: 5, Owner: "Max", AppraisedValue: 600, UpdatedAt: base.Add(500 * time.Millisecond)})
+		next, err := since("Max", first.Cursor)
+		require.NoError(t, err)
+		require.Len(t, next.Assets, 1)
+		assert.Equal(t, "asset6", next.Assets[0].ID)
+	})
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		_, err := since("", 0)
+		assert.Error(t, err)
+		_, err = since("John", -1)
+		assert.Error(t, err)
+	})
+}
+
+// Test GetAssetsByColorSorted ordering and paging within a color group
+func TestGetAssetsByColorSorted(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 500},
+		Asset{ID: "asset2", Color: "red", Size: 5, Owner: "John", AppraisedValue: 100},
+		Asset{ID: "asset3", Color: "blue", Size: 5, Owner: "Jane", AppraisedValue: 300},
+		Asset{ID: "asset4", Color: "blue", Size: 5, Owner: "Jane", AppraisedValue: 900},
+		Asset{ID: "asset5", Color: "blue", Size: 5, Owner: "Max", AppraisedValue: 700},
+	)
+
+	page := func(color string, desc bool, pageSize int32, bookmark string) (*PagedAssets, error) {
+		var result *PagedAssets
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			result, err = contract.GetAssetsByColorSorted(ctx, color, desc, pageSize, bookmark)
+			return err
+		})
+		return result, err
+	}
+	values := func(result *PagedAssets) []int {
+		var values []int
+		for _, asset := range result.Records {
+			values = append(values, asset.AppraisedValue)
+		}
+		return values
+	}
+
+	t.Run("Descending Pages", func(t *testing.T) {
+		first, err := page("blue", true, 3, "")
+		require.NoError(t, err)
+		assert.Equal(t, []int{900, 700, 500}, values(first))
+
+		second, err := page("blue", true, 3, first.Bookmark)
+		require.NoError(t, err)
+		assert.Equal(t, []int{300}, values(second))
+	})
+
+	t.Run("Ascending", func(t *testing.T) {
+		result, err := page("blue", false, 10, "")
+		require.NoError(t, err)
+		assert.Equal(t, []int{300, 500, 700, 900}, values(result))
+	})
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		_, err := page("", false, 10, "")
+		assert.Error(t, err)
+		_, err = page("blue", false, 0, "")
+		assert.Error(t, err)
+	})
+}
+
+// Test QueryAssets with caller-supplied selectors
+func TestQueryAssets(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300},
+		Asset{ID: "asset2", Color: "blue", Size: 15, Owner: "Jane", AppraisedValue: 400},
+		Asset{ID: "asset3", Color: "red", Size: 15, Owner: "Jane", AppraisedValue: 500},
+		Asset{ID: "asset4", Color: "blue", Size: 20, Owner: "Max", AppraisedValue: 600},
+	)
+
+	query := func(queryString string) ([]*Asset, error) {
+		var assets []*Asset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.QueryAssets(ctx, queryString)
+			return err
+		})
+		return assets, err
+	}
+
+	t.Run("Color And Size", func(t *testing.T) {
+		assets, err := query(`{"selector":{"Color":"blue","Size":{"$gte":10}}}`)
+		require.NoError(t, err)
+		require.Len(t, assets, 2)
+		assert.Equal(t, "asset2", assets[0].ID)
+		assert.Equal(t, "asset4", assets[1].ID)
+	})
+
+	t.Run("Malformed JSON", func(t *testing.T) {
+		_, err := query(`{"selector":{"Color":"blue"}`)
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing Selector", func(t *testing.T) {
+		_, err := query(`{"Color":"blue"}`)
+		assert.Error(t, err)
+	})
+
+	t.Run("Query Too Large", func(t *testing.T) {
+		_, err := query(`{"selector":{"Color":"` + strings.Repeat("x", maxQueryLength) + `"}}`)
+		assert.Error(t, err)
+	})
+
+	t.Run("LevelDB Does Not Support Rich Queries", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		leveldbErr := errors.New("ExecuteQuery not supported for leveldb")
+		stub.On("GetQueryResult", `{"selector":{}}`).Return(nil, leveldbErr).Once()
+
+		_, err := contract.QueryAssets(ctx, `{"selector":{}}`)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrRichQueryUnsupported))
+		assert.Contains(t, err.Error(), "GetAllAssets")
+	})
+}
+
+// Test that every rich query reports ErrRichQueryUnsupported on LevelDB
+func TestRichQueriesOnLevelDB(t *testing.T) {
+	contract := AssetContract{}
+	queries := map[string]func(ctx *MockTransactionContext) error{
+		"GetUnvaluedAssets": func(ctx *MockTransactionContext) error {
+			_, err := contract.GetUnvaluedAssets(ctx)
+			return err
+		},
+		"CountUnvaluedAssets": func(ctx *MockTransactionContext) error {
+			_, err := contract.CountUnvaluedAssets(ctx)
+			return err
+		},
+		"GetAssetsByOrgCreatedInRange": func(ctx *MockTransactionContext) error {
+			_, err := contract.GetAssetsByOrgCreatedInRange(ctx, "Org1MSP", 0, 100)
+			return err
+		},
+		"QueryAssetsByMSP": func(ctx *MockTransactionContext) error {
+			_, err := contract.QueryAssetsByMSP(ctx, "Org1MSP")
+			return err
+		},
+		"QueryAssetsByValueRange": func(ctx *MockTransactionContext) error {
+			_, err := contract.QueryAssetsByValueRange(ctx, 0, 100)
+			return err
+		},
+		"CountAssetsByOwner": func(ctx *MockTransactionContext) error {
+			_, err := contract.CountAssetsByOwner(ctx, "John")
+			return err
+		},
+	}
+	for name, query := range queries {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		stub.On("GetQueryResult", mock.AnythingOfType("string")).Return(nil, errors.New("ExecuteQuery not supported for leveldb")).Once()
+
+		err := query(&MockTransactionContext{stub: stub})
+		assert.True(t, errors.Is(err, ErrRichQueryUnsupported), name)
+		stub.AssertExpectations(t)
+	}
+}
+
+// Test GetAssetsByIDRange boundaries
+func TestGetAssetsByIDRange(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	for _, id := range []string{"building.45", "vehicle.1", "vehicle.123", "vehicle.9", "vessel.7"} {
+		ledger.Seed(Asset{ID: id, Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300})
+	}
+	ledger.SeedRaw("vehicle.5", []byte("not json"))
+
+	byRange := func(startKey, endKey string) ([]string, error) {
+		var assets []*Asset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.GetAssetsByIDRange(ctx, startKey, endKey)
+			return err
+		})
+		var ids []string
+		for _, asset := range assets {
+			ids = append(ids, asset.ID)
+		}
+		return ids, err
+	}
+
+	t.Run("Start Inclusive End Exclusive", func(t *testing.T) {
+		ids, err := byRange("vehicle.1", "vehicle.9")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"vehicle.1", "vehicle.123"}, ids)
+	})
+
+	t.Run("Prefix Range Skips Unmarshalable Records", func(t *testing.T) {
+		ids, err := byRange("vehicle.", "vehicle/")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"vehicle.1", "vehicle.123", "vehicle.9"}, ids)
+	})
+
+	t.Run("Invalid Ranges", func(t *testing.T) {
+		_, err := byRange("", "")
+		assert.Error(t, err)
+		_, err = byRange("vessel", "building")
+		assert.Error(t, err)
+	})
+}
+
+// Test QueryAssetsByMSP
+func TestQueryAssetsByMSP(t *testing.T) {
+	contract := AssetContract{}
+
+	t.Run("Iterates Query Results", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		org1, _ := json.Marshal(Asset{ID: "asset1", Owner: "John", UpdatedByMSP: "Org1MSP"})
+		org2, _ := json.Marshal(Asset{ID: "asset2", Owner: "Jane", UpdatedByMSP: "Org2MSP"})
+		iterator := &sliceIterator{kvs: []*queryresult.KV{
+			{Key: "asset1", Value: org1},
+			{Key: "broken", Value: []byte("not json")},
+			{Key: "asset2", Value: org2},
+		}}
+		stub.On("GetQueryResult", `{"selector":{"UpdatedByMSP":"Org1MSP"}}`).Return(iterator, nil).Once()
+
+		assets, err := contract.QueryAssetsByMSP(ctx, "Org1MSP")
+		require.NoError(t, err)
+		require.Len(t, assets, 2)
+		assert.Equal(t, "Org1MSP", assets[0].UpdatedByMSP)
+		assert.Equal(t, "Org2MSP", assets[1].UpdatedByMSP)
+		assert.True(t, iterator.Closed)
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Selects On UpdatedByMSP", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(
+			Asset{ID: "asset1", Owner: "John", CreatorOrg: "Org1MSP", UpdatedByMSP: "Org1MSP"},
+			Asset{ID: "asset2", Owner: "Jane", CreatorOrg: "Org1MSP", UpdatedByMSP: "Org2MSP"},
+		)
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			assets, err := contract.QueryAssetsByMSP(ctx, "Org2MSP")
+			if err == nil {
+				require.Len(t, assets, 1)
+				assert.Equal(t, "asset2", assets[0].ID)
+			}
+			return err
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Invalid MSP ID", func(t *testing.T) {
+		ctx := &MockTransactionContext{stub: new(MockStub)}
+		_, err := contract.QueryAssetsByMSP(ctx, " ")
+		assert.Error(t, err)
+		_, err = contract.QueryAssetsByMSP(ctx, strings.Repeat("M", maxMSPIDLength+1))
+		assert.Error(t, err)
+	})
+}
+
+// Test QueryAssetsByValueRange
+func TestQueryAssetsByCreatedRange(t *testing.T) {
+	contract := AssetContract{}
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)
+
+	t.Run("Returns Assets In Range", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		later, _ := json.Marshal(Asset{ID: "asset1", Owner: "John", CreatedAt: time.Date(2024, 3, 20, 8, 0, 0, 0, time.UTC)})
+		earlier, _ := json.Marshal(Asset{ID: "asset2", Owner: "Jane", CreatedAt: time.Date(2024, 3, 2, 8, 0, 0, 0, time.UTC)})
+		iterator := &sliceIterator{kvs: []*queryresult.KV{
+			{Key: "asset1", Value: later},
+			{Key: "asset2", Value: earlier},
+		}}
+		stub.On("GetQueryResult", `{"selector":{"CreatedAt":{"$gt":"2024-02-29T23:59:59Z","$lte":"2024-03-31T23:59:59Z"}}}`).Return(iterator, nil).Once()
+
+		assets, err := contract.QueryAssetsByCreatedRange(ctx, from.Unix(), to.Unix())
+		require.NoError(t, err)
+		require.Len(t, assets, 2)
+		assert.Equal(t, "asset2", assets[0].ID)
+		assert.Equal(t, "asset1", assets[1].ID)
+		assert.True(t, iterator.Closed)
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Selector Bounds Handle Fractional Seconds", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(
+			Asset{ID: "before", Owner: "John", CreatedAt: from.Add(-500 * time.Millisecond)},
+			Asset{ID: "first", Owner: "John", CreatedAt: from.Add(300 * time.Millisecond)},
+			Asset{ID: "last", Owner: "John", CreatedAt: to.Add(900 * time.Millisecond)},
+			Asset{ID: "after", Owner: "John", CreatedAt: to.Add(time.Second)},
+		)
+		var assets []*Asset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.QueryAssetsByCreatedRange(ctx, from.Unix(), to.Unix())
+			return err
+		})
+		require.NoError(t, err)
+		var ids []string
+		for _, asset := range assets {
+			ids = append(ids, asset.ID)
+		}
+		assert.Equal(t, []string{"first", "last"}, ids)
+	})
+
+	t.Run("From After To", func(t *testing.T) {
+		stub := new(MockStub)
+		ctx := &MockTransactionContext{stub: stub}
+		_, err := contract.QueryAssetsByCreatedRange(ctx, to.Unix(), from.Unix())
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		stub.AssertNotCalled(t, "GetQueryResult", mock.Anything)
+	})
+}
+
+func TestQueryAssetsByValueRange(t *testing.T) {
+	contract := AssetContract{}
+
+	t.Run("Returns Assets In Band", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		ctx := &MockTransactionContext{stub: stub}
+		low, _ := json.Marshal(Asset{ID: "asset1", Owner: "John", AppraisedValue: 300})
+		high, _ := json.Marshal(Asset{ID: "asset2", Owner: "Jane", AppraisedValue: 500})
+		iterator := &sliceIterator{kvs: []*queryresult.KV{
+			{Key: "asset1", Value: low},
+			{Key: "asset2", Value: high},
+		}}
+		stub.On("GetQueryResult", `{"selector":{"AppraisedValue":{"$gte":300,"$lte":500}}}`).Return(iterator, nil).Once()
+
+		assets, err := contract.QueryAssetsByValueRange(ctx, 300, 500)
+		require.NoError(t, err)
+		require.Len(t, assets, 2)
+		assert.Equal(t, 300, assets[0].AppraisedValue)
+		assert.Equal(t, 500, assets[1].AppraisedValue)
+		assert.True(t, iterator.Closed)
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Selector Matches Band", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(
+			Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 100},
+			Asset{ID: "asset2", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300},
+			Asset{ID: "asset3", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 500},
+			Asset{ID: "asset4", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 700},
+		)
+		var assets []*Asset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.QueryAssetsByValueRange(ctx, 300, 500)
+			return err
+		})
+		require.NoError(t, err)
+		ids := []string{}
+		for _, asset := range assets {
+			ids = append(ids, asset.ID)
+		}
+		assert.ElementsMatch(t, []string{"asset2", "asset3"}, ids)
+	})
+
+	t.Run("Invalid Range", func(t *testing.T) {
+		stub := new(MockStub)
+		stub.expectDefaultConfig()
+		ctx := &MockTransactionContext{stub: stub}
+		for _, bounds := range [][2]int{{500, 300}, {-1, 100}, {0, maxAppraisedValue + 1}} {
+			_, err := contract.QueryAssetsByValueRange(ctx, bounds[0], bounds[1])
+			assert.True(t, errors.Is(err, ErrInvalidInput), bounds)
+		}
+	})
+}
+
+// Test GetAllAssetsSorted
+func TestGetAllAssetsSorted(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 500},
+		Asset{ID: "asset2", Color: "red", Size: 5, Owner: "Jane", AppraisedValue: 100},
+		Asset{ID: "asset3", Color: "green", Size: 5, Owner: "Max", AppraisedValue: 900},
+		Asset{ID: "asset4", Color: "white", Size: 5, Owner: "Bob", AppraisedValue: 500},
+	)
+
+	sorted := func(field string, descending bool) ([]string, error) {
+		var assets []*Asset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.GetAllAssetsSorted(ctx, field, descending)
+			return err
+		})
+		ids := []string{}
+		for _, asset := range assets {
+			ids = append(ids, asset.ID)
+		}
+		return ids, err
+	}
+
+	t.Run("AppraisedValue Ascending", func(t *testing.T) {
+		ids, err := sorted("AppraisedValue", false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"asset2", "asset1", "asset4", "asset3"}, ids)
+	})
+
+	t.Run("AppraisedValue Descending", func(t *testing.T) {
+		ids, err := sorted("AppraisedValue", true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"asset3", "asset1", "asset4", "asset2"}, ids)
+	})
+
+	t.Run("Owner", func(t *testing.T) {
+		ids, err := sorted("Owner", false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"asset4", "asset2", "asset1", "asset3"}, ids)
+	})
+
+	t.Run("Unknown Field", func(t *testing.T) {
+		_, err := sorted("Color", false)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Contains(t, err.Error(), "cannot sort on field")
+	})
+}
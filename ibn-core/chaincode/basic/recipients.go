@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// maxAllowedRecipients bounds the size of an asset's recipient whitelist
+const maxAllowedRecipients = 100
+
+// checkRecipientAllowed rejects newOwner unless the asset's recipient whitelist
+// is empty or contains it
+func checkRecipientAllowed(asset *Asset, newOwner string) error {
+	if len(asset.AllowedRecipients) == 0 {
+		return nil
+	}
+	for _, recipient := range asset.AllowedRecipients {
+		if recipient == newOwner {
+			return nil
+		}
+	}
+	return fmt.Errorf("asset %s may only be transferred to its allowed recipients, %s is not one of them", asset.ID, newOwner)
+}
+
+// SetAllowedRecipients restricts the owners an asset may be transferred to.
+// recipientsJSON is a JSON array of owners; an empty array lifts the restriction.
+// Only the current owner or an admin may change the list.
+func (s *AssetContract) SetAllowedRecipients(ctx contractapi.TransactionContextInterface, id string, recipientsJSON string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: SetAllowedRecipients - ID: %s, Recipients: %s =====", id, recipientsJSON)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+
+	var recipients []string
+	if err := json.Unmarshal([]byte(recipientsJSON), &recipients); err != nil {
+		logf(ctx, "ERROR: Invalid recipients: %v", err)
+		return fmt.Errorf("recipients must be a JSON array of owners: %w", err)
+	}
+	if len(recipients) > maxAllowedRecipients {
+		logf(ctx, "ERROR: %d recipients exceed the limit", len(recipients))
+		return fmt.Errorf("at most %d allowed recipients may be set", maxAllowedRecipients)
+	}
+	seen := make(map[string]bool, len(recipients))
+	unique := []string{}
+	for _, recipient := range recipients {
+		if err := validateOwner(recipient); err != nil {
+			logf(ctx, "ERROR: Invalid recipient: %v", err)
+			return err
+		}
+		if !seen[recipient] {
+			seen[recipient] = true
+			unique = append(unique, recipient)
+		}
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if !clientActsAs(ctx, asset.Owner) && requireAdmin(ctx) != nil {
+		logf(ctx, "ERROR: Caller is neither the owner of asset %s nor an admin", id)
+		return fmt.Errorf("only the owner of asset %s or an admin may set its allowed recipients", id)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	asset.AllowedRecipients = unique
+	stampUpdate(ctx, asset, now, clientID)
+
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+		return fmt.Errorf("failed to marshal asset: %w", err)
+	}
+
+	err = ctx.GetStub().PutState(id, assetJSON)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to update allowed recipients: %v", err)
+		return fmt.Errorf("failed to update allowed recipients: %w", err)
+	}
+	if err := recordChange(ctx, id, "recipientsChange"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "AllowedRecipientsChanged", map[string]interface{}{
+		"assetID":    id,
+		"recipients": unique,
+		"updatedBy":  clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Asset %s now has %d allowed recipients", id, len(unique))
+	logln(ctx, "===== END: SetAllowedRecipients =====")
+	return nil
+}
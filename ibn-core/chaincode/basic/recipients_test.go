@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test transfers restricted by an allowed recipients list
+func TestAllowedRecipients(t *testing.T) {
+	contract := AssetContract{}
+
+	setup := func(t *testing.T, recipientsJSON string) *Ledger {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "Org1MSP", AppraisedValue: 900000})
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.SetAllowedRecipients(ctx, "asset1", recipientsJSON)
+		})
+		require.NoError(t, err)
+		return ledger
+	}
+	transfer := func(ledger *Ledger, newOwner string) error {
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.TransferAsset(ctx, "asset1", newOwner)
+		})
+		return err
+	}
+
+	t.Run("Allowed Recipient", func(t *testing.T) {
+		ledger := setup(t, `["Org2MSP", "Org3MSP", "Org2MSP"]`)
+		assert.Equal(t, []string{"Org2MSP", "Org3MSP"}, readCommitted(t, ledger, "asset1").AllowedRecipients)
+
+		require.NoError(t, transfer(ledger, "Org3MSP"))
+		assert.Equal(t, "Org3MSP", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Disallowed Recipient", func(t *testing.T) {
+		ledger := setup(t, `["Org2MSP"]`)
+
+		err := transfer(ledger, "Org4MSP")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "allowed recipients")
+		assert.Equal(t, "Org1MSP", readCommitted(t, ledger, "asset1").Owner)
+
+		_, err = ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.EscrowAssetWithDeadline(ctx, "asset1", "Org4MSP", ledger.clock.Unix()+3600)
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Empty List Is Unrestricted", func(t *testing.T) {
+		ledger := setup(t, `["Org2MSP"]`)
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.SetAllowedRecipients(ctx, "asset1", `[]`)
+		})
+		require.NoError(t, err)
+		assert.Empty(t, readCommitted(t, ledger, "asset1").AllowedRecipients)
+
+		require.NoError(t, transfer(ledger, "Org4MSP"))
+	})
+
+	t.Run("Only Owner Or Admin May Set", func(t *testing.T) {
+		ledger := setup(t, `[]`)
+		stranger := &MockClientIdentity{ID: "x509::CN=User1@org5.example.com", MSPID: "Org5MSP"}
+
+		_, err := ledger.Invoke(stranger, func(ctx *MockTransactionContext) error {
+			return contract.SetAllowedRecipients(ctx, "asset1", `["Org5MSP"]`)
+		})
+		assert.Error(t, err)
+
+		_, err = ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return contract.SetAllowedRecipients(ctx, "asset1", `["Org2MSP"]`)
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Invalid Recipients", func(t *testing.T) {
+		ledger := setup(t, `[]`)
+		for _, recipientsJSON := range []string{`"Org2MSP"`, `[""]`, `not json`} {
+			_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+				return contract.SetAllowedRecipients(ctx, "asset1", recipientsJSON)
+			})
+			assert.Error(t, err, recipientsJSON)
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// ownerRegistryObjectType is the composite key namespace of the owner registry
+// (owner~<ownerID>), which keeps registered owners out of asset range scans
+const ownerRegistryObjectType = "owner"
+
+// maxDisplayNameLength bounds the display name of a registered owner
+const maxDisplayNameLength = 256
+
+// RegisteredOwner is an entry of the owner registry
+type RegisteredOwner struct {
+	ID           string `json:"ID"`
+	DisplayName  string `json:"DisplayName"`
+	RegisteredBy string `json:"RegisteredBy"`
+	RegisteredAt int64  `json:"RegisteredAt"`
+}
+
+// getRegisteredOwner returns the registry entry of ownerID, or nil if the
+// owner is not registered
+func getRegisteredOwner(ctx contractapi.TransactionContextInterface, ownerID string) (*RegisteredOwner, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(ownerRegistryObjectType, []string{ownerID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create owner registry key: %w", err)
+	}
+	entryJSON, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read owner registry: %w", err)
+	}
+	if entryJSON == nil {
+		return nil, nil
+	}
+
+	var entry RegisteredOwner
+	if err := json.Unmarshal(entryJSON, &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal registered owner: %w", err)
+	}
+	return &entry, nil
+}
+
+// checkOwnerRegistered returns an ErrOwnerNotRegistered error if registered
+// owners are required and ownerID is not one of them
+func checkOwnerRegistered(ctx contractapi.TransactionContextInterface, ownerID string) error {
+	config, err := getConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if !config.RequireRegisteredOwners {
+		return nil
+	}
+
+	entry, err := getRegisteredOwner(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return fmt.Errorf("cannot transfer to %s: %w", ownerID, ErrOwnerNotRegistered)
+	}
+	return nil
+}
+
+// RegisterOwner adds ownerID to the owner registry, or changes its display
+// name if it is already registered. Owners may register themselves; admins may
+// register anyone.
+func (s *AssetContract) RegisterOwner(ctx contractapi.TransactionContextInterface, ownerID string, displayName string) error {
+	logf(ctx, "===== START: RegisterOwner - Owner: %s =====", ownerID)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateOwner(ownerID); err != nil {
+		logf(ctx, "ERROR: Invalid owner: %v", err)
+		return err
+	}
+	if strings.TrimSpace(displayName) == "" {
+		logln(ctx, "ERROR: Display name is empty")
+		return fmt.Errorf("display name cannot be empty: %w", ErrInvalidInput)
+	}
+	if len(displayName) > maxDisplayNameLength {
+		logf(ctx, "ERROR: Display name of %d characters is too long", len(displayName))
+		return fmt.Errorf("display name cannot exceed %d characters: %w", maxDisplayNameLength, ErrInvalidInput)
+	}
+	if !clientActsAs(ctx, ownerID) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not register owner %s", ownerID)
+			return fmt.Errorf("only %s itself or an admin may register owner %s", ownerID, ownerID)
+		}
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	entryJSON, err := json.Marshal(RegisteredOwner{
+		ID:           ownerID,
+		DisplayName:  displayName,
+		RegisteredBy: clientID,
+		RegisteredAt: now.Unix(),
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to marshal registered owner: %v", err)
+		return fmt.Errorf("failed to marshal registered owner: %w", err)
+	}
+
+	key, err := ctx.GetStub().CreateCompositeKey(ownerRegistryObjectType, []string{ownerID})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to create owner registry key: %v", err)
+		return fmt.Errorf("failed to create owner registry key: %w", err)
+	}
+	err = ctx.GetStub().PutState(key, entryJSON)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to register owner: %v", err)
+		return fmt.Errorf("failed to register owner %s: %w", ownerID, err)
+	}
+
+	err = emitEvent(ctx, "OwnerRegistered", map[string]interface{}{
+		"ownerID":      ownerID,
+		"displayName":  displayName,
+		"registeredBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Owner %s registered", ownerID)
+	logln(ctx, "===== END: RegisterOwner =====")
+	return nil
+}
+
+// IsOwnerRegistered reports whether ownerID is in the owner registry
+func (s *AssetContract) IsOwnerRegistered(ctx contractapi.TransactionContextInterface, ownerID string) (bool, error) {
+	if err := validateOwner(ownerID); err != nil {
+		logf(ctx, "ERROR: Invalid owner: %v", err)
+		return false, err
+	}
+
+	entry, err := getRegisteredOwner(ctx, ownerID)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return false, err
+	}
+	return entry != nil, nil
+}
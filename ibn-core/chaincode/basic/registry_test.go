@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test the owner registry and the registered owner check of transfers
+func TestOwnerRegistry(t *testing.T) {
+	contract := AssetContract{}
+	admin := AdminContract{}
+
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300},
+		Asset{ID: "asset2", Color: "red", Size: 5, Owner: "John", AppraisedValue: 300},
+	)
+	isRegistered := func(ownerID string) bool {
+		var registered bool
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			registered, err = contract.IsOwnerRegistered(ctx, ownerID)
+			return err
+		})
+		require.NoError(t, err)
+		return registered
+	}
+	transfer := func(id, newOwner string) error {
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.TransferAsset(ctx, id, newOwner)
+		})
+		return err
+	}
+
+	t.Run("Register Owner", func(t *testing.T) {
+		assert.False(t, isRegistered("Jane"))
+
+		stub, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.RegisterOwner(ctx, "Jane", "Jane Doe")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "OwnerRegistered", stub.LastEvent().EventName)
+		assert.True(t, isRegistered("Jane"))
+	})
+
+	t.Run("Only Owner Or Admin May Register", func(t *testing.T) {
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.RegisterOwner(ctx, "Max", "Max Mustermann")
+		})
+		assert.Error(t, err)
+		assert.False(t, isRegistered("Max"))
+
+		_, err = ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return contract.RegisterOwner(ctx, "Max", "Max Mustermann")
+		})
+		require.NoError(t, err)
+		assert.True(t, isRegistered("Max"))
+	})
+
+	t.Run("Unregistered Owner Allowed While Flag Off", func(t *testing.T) {
+		require.NoError(t, transfer("asset2", "Jnae"))
+		assert.Equal(t, "Jnae", readCommitted(t, ledger, "asset2").Owner)
+	})
+
+	_, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+		return admin.SetRequireRegisteredOwners(ctx, true)
+	})
+	require.NoError(t, err)
+
+	t.Run("Unregistered Owner Rejected", func(t *testing.T) {
+		err := transfer("asset1", "Jnae")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrOwnerNotRegistered))
+		assert.Equal(t, "John", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Registered Owner Accepted", func(t *testing.T) {
+		require.NoError(t, transfer("asset1", "Jane"))
+		assert.Equal(t, "Jane", readCommitted(t, ledger, "asset1").Owner)
+	})
+
+	t.Run("Registry Does Not Show Up As Asset", func(t *testing.T) {
+		var assets []*Asset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.GetAllAssets(ctx)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Len(t, assets, 2)
+	})
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// SoftDeleteAsset marks an asset as deleted without removing it from the world state.
+// The asset is kept until it is purged once its retention window has elapsed.
+// The mark only makes the asset a candidate for PurgeDeletedAssets: until then
+// it is still returned by ReadAsset and the queries, with Deleted set, and can
+// be changed like any other asset. The owner or an admin may soft delete it.
+func (s *AssetContract) SoftDeleteAsset(ctx contractapi.TransactionContextInterface, id string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: SoftDeleteAsset - ID: %s =====", id)
+
+	if err := requireAttribute(ctx, writerAttribute, "true"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if !clientActsAs(ctx, asset.Owner) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not delete asset %s owned by %s", id, asset.Owner)
+			return fmt.Errorf("only the owner or an admin may delete asset %s: %w", id, ErrNotOwner)
+		}
+	}
+	if err := checkNotLocked(asset); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	if asset.Deleted {
+		logf(ctx, "ERROR: Asset %s is already deleted", id)
+		return fmt.Errorf("asset %s is already deleted", id)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	asset.Deleted = true
+	asset.DeletedAt = now
+	stampUpdate(ctx, asset, now, clientID)
+
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+		return fmt.Errorf("failed to marshal asset: %w", err)
+	}
+
+	err = ctx.GetStub().PutState(id, assetJSON)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to soft delete asset %s: %v", id, err)
+		return fmt.Errorf("failed to soft delete asset %s: %w", id, err)
+	}
+	if err := recordChange(ctx, id, "softDelete"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "AssetSoftDeleted", map[string]interface{}{
+		"assetID":   id,
+		"owner":     asset.Owner,
+		"deletedBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Successfully soft deleted asset %s", id)
+	logf(ctx, "===== END: SoftDeleteAsset =====")
+	return nil
+}
+
+// RestoreAsset undoes a soft delete, returning the asset to the live state.
+// The owner or an admin may restore it.
+func (s *AssetContract) RestoreAsset(ctx contractapi.TransactionContextInterface, id string) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: RestoreAsset - ID: %s =====", id)
+
+	if err := requireAttribute(ctx, writerAttribute, "true"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if !clientActsAs(ctx, asset.Owner) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not restore asset %s owned by %s", id, asset.Owner)
+			return fmt.Errorf("only the owner or an admin may restore asset %s: %w", id, ErrNotOwner)
+		}
+	}
+	if !asset.Deleted {
+		logf(ctx, "ERROR: Asset %s is not deleted", id)
+		return fmt.Errorf("asset %s is not deleted", id)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	asset.Deleted = false
+	asset.DeletedAt = time.Time{}
+	stampUpdate(ctx, asset, now, clientID)
+
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+		return fmt.Errorf("failed to marshal asset: %w", err)
+	}
+
+	err = ctx.GetStub().PutState(id, assetJSON)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to restore asset %s: %v", id, err)
+		return fmt.Errorf("failed to restore asset %s: %w", id, err)
+	}
+	if err := recordChange(ctx, id, "restore"); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, "AssetRestored", map[string]interface{}{
+		"assetID":    id,
+		"owner":      asset.Owner,
+		"restoredBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Successfully restored asset %s", id)
+	logf(ctx, "===== END: RestoreAsset =====")
+	return nil
+}
+
+// GetDeletionCandidates returns soft-deleted assets whose DeletedAt is older than
+// the retention window and which may therefore be permanently purged
+func (s *AssetContract) GetDeletionCandidates(ctx contractapi.TransactionContextInterface, retentionSeconds int64) ([]*Asset, error) {
+	logf(ctx, "===== START: GetDeletionCandidates - Retention: %ds =====", retentionSeconds)
+
+	candidates, err := s.findDeletionCandidates(ctx, retentionSeconds)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Found %d assets past the retention window", len(candidates))
+	logln(ctx, "===== END: GetDeletionCandidates =====")
+	return candidates, nil
+}
+
+// findDeletionCandidates scans the world state for soft-deleted assets deleted
+// strictly before the start of the retention window
+func (s *AssetContract) findDeletionCandidates(ctx contractapi.TransactionContextInterface, retentionSeconds int64) ([]*Asset, error) {
+	if retentionSeconds < 0 {
+		return nil, fmt.Errorf("retention period cannot be negative")
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cutoff := now.Unix() - retentionSeconds
+
+	assets, err := s.GetAllAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []*Asset{}
+	for _, asset := range assets {
+		if asset.Deleted && asset.DeletedAt.Unix() < cutoff {
+			candidates = append(candidates, asset)
+		}
+	}
+	return candidates, nil
+}
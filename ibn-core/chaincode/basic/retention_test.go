@@ -0,0 +1,232 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var adminIdentity = &MockClientIdentity{
+	ID:         "x509::CN=Admin@org1.example.com",
+	MSPID:      "Org1MSP",
+	Attributes: map[string]string{"admin": "true"},
+}
+
+// Test soft delete retention and purge
+func TestDeletionRetention(t *testing.T) {
+	contract := AssetContract{}
+	admin := AdminContract{}
+
+	newLedger := func(t *testing.T) *Ledger {
+		ledger := NewLedger()
+		ledger.Seed(
+			Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500},
+			Asset{ID: "asset2", Color: "red", Size: 20, Owner: "Jane", AppraisedValue: 600},
+		)
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SoftDeleteAsset(ctx, "asset1")
+		})
+		require.NoError(t, err)
+		return ledger
+	}
+
+	t.Run("Soft Delete Marks Asset", func(t *testing.T) {
+		ledger := newLedger(t)
+		asset := readCommitted(t, ledger, "asset1")
+		assert.True(t, asset.Deleted)
+		assert.False(t, asset.DeletedAt.IsZero())
+		assert.False(t, readCommitted(t, ledger, "asset2").Deleted)
+	})
+
+	t.Run("Soft Delete Twice", func(t *testing.T) {
+		ledger := newLedger(t)
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SoftDeleteAsset(ctx, "asset1")
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already deleted")
+	})
+
+	t.Run("Only Owner Soft Deletes", func(t *testing.T) {
+		ledger := newLedger(t)
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SoftDeleteAsset(ctx, "asset2")
+		})
+		assert.True(t, errors.Is(err, ErrNotOwner))
+		assert.False(t, readCommitted(t, ledger, "asset2").Deleted)
+	})
+
+	t.Run("Soft Delete Requires Writer", func(t *testing.T) {
+		ledger := newLedger(t)
+		reader := &MockClientIdentity{ID: "x509::CN=Jane@org1.example.com", MSPID: "Org1MSP", Attributes: map[string]string{"owner": "Jane"}}
+		_, err := ledger.Invoke(reader, func(ctx *MockTransactionContext) error {
+			return contract.SoftDeleteAsset(ctx, "asset2")
+		})
+		assert.Error(t, err)
+		assert.False(t, readCommitted(t, ledger, "asset2").Deleted)
+	})
+
+	t.Run("Locked Asset Cannot Be Soft Deleted", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500, Locked: true})
+		_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.SoftDeleteAsset(ctx, "asset1")
+		})
+		assert.True(t, errors.Is(err, ErrAssetLocked))
+		assert.False(t, readCommitted(t, ledger, "asset1").Deleted)
+	})
+
+	t.Run("Soft Deleted Asset Stays Visible Until Purge", func(t *testing.T) {
+		ledger := newLedger(t)
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			asset, err := contract.ReadAsset(ctx, "asset1")
+			require.NoError(t, err)
+			assert.True(t, asset.Deleted)
+
+			assets, err := contract.GetAllAssets(ctx)
+			require.NoError(t, err)
+			assert.Len(t, assets, 2)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("Within Retention Not Eligible", func(t *testing.T) {
+		ledger := newLedger(t)
+		var candidates []*Asset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			candidates, err = contract.GetDeletionCandidates(ctx, 3600)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Empty(t, candidates)
+	})
+
+	t.Run("Past Retention Eligible", func(t *testing.T) {
+		ledger := newLedger(t)
+		ledger.clock = ledger.clock.Add(2 * time.Hour)
+		var candidates []*Asset
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			candidates, err = contract.GetDeletionCandidates(ctx, 3600)
+			return err
+		})
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, "asset1", candidates[0].ID)
+	})
+
+	t.Run("Negative Retention", func(t *testing.T) {
+		ledger := newLedger(t)
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			_, err := contract.GetDeletionCandidates(ctx, -1)
+			return err
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Purge Removes Only Eligible Assets", func(t *testing.T) {
+		ledger := newLedger(t)
+		ledger.clock = ledger.clock.Add(2 * time.Hour)
+		var purged []string
+		stub, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) (err error) {
+			purged, err = admin.PurgeDeletedAssets(ctx, 3600)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"asset1"}, purged)
+		assert.Nil(t, ledger.Get("asset1"))
+		assert.NotNil(t, ledger.Get("asset2"))
+		assert.Equal(t, "AssetsPurged", stub.LastEvent().EventName)
+	})
+
+	t.Run("Purge Is Capped At Batch Size", func(t *testing.T) {
+		ledger := NewLedger()
+		deletedAt := ledger.clock.Add(-2 * time.Hour)
+		for i := 0; i < maxBatchSize+3; i++ {
+			ledger.Seed(Asset{ID: fmt.Sprintf("gone%03d", i), Color: "blue", Size: 1, Owner: "John", Deleted: true, DeletedAt: deletedAt})
+		}
+
+		purge := func() ([]string, *LedgerStub) {
+			var purged []string
+			stub, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) (err error) {
+				purged, err = admin.PurgeDeletedAssets(ctx, 3600)
+				return err
+			})
+			require.NoError(t, err)
+			return purged, stub
+		}
+
+		purged, stub := purge()
+		assert.Len(t, purged, maxBatchSize)
+		assert.Equal(t, float64(3), eventData(t, stub.LastEvent())["remaining"])
+
+		purged, stub = purge()
+		assert.Len(t, purged, 3)
+		assert.Equal(t, float64(0), eventData(t, stub.LastEvent())["remaining"])
+	})
+
+	t.Run("Purge Requires Admin", func(t *testing.T) {
+		ledger := newLedger(t)
+		ledger.clock = ledger.clock.Add(2 * time.Hour)
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			_, err := admin.PurgeDeletedAssets(ctx, 3600)
+			return err
+		})
+		assert.Error(t, err)
+		assert.NotNil(t, ledger.Get("asset1"))
+	})
+}
+
+// Test RestoreAsset after a soft delete
+func TestRestoreAsset(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500},
+		Asset{ID: "asset2", Color: "red", Size: 20, Owner: "John", AppraisedValue: 600},
+	)
+	_, err := ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+		return contract.SoftDeleteAsset(ctx, "asset1")
+	})
+	require.NoError(t, err)
+
+	restore := func(id string) (*LedgerStub, error) {
+		return ledger.Invoke(ownerIdentity("John"), func(ctx *MockTransactionContext) error {
+			return contract.RestoreAsset(ctx, id)
+		})
+	}
+
+	t.Run("Only Owner Restores", func(t *testing.T) {
+		_, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.RestoreAsset(ctx, "asset1")
+		})
+		assert.True(t, errors.Is(err, ErrNotOwner))
+		assert.True(t, readCommitted(t, ledger, "asset1").Deleted)
+	})
+
+	t.Run("Restore Deleted Asset", func(t *testing.T) {
+		stub, err := restore("asset1")
+		require.NoError(t, err)
+		asset := readCommitted(t, ledger, "asset1")
+		assert.False(t, asset.Deleted)
+		assert.True(t, asset.DeletedAt.IsZero())
+		assert.Equal(t, stub.TxTime.Unix(), asset.UpdatedAt.Unix())
+		assert.Equal(t, ownerIdentity("John").ID, asset.UpdatedBy)
+		assert.Equal(t, "AssetRestored", stub.LastEvent().EventName)
+	})
+
+	t.Run("Restore Live Asset", func(t *testing.T) {
+		_, err := restore("asset2")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not deleted")
+	})
+
+	t.Run("Restore Missing Asset", func(t *testing.T) {
+		_, err := restore("missing")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// AssetSchema is the shape of an incoming asset payload, as accepted by the
+// batch and transient create functions. Every field is required and no other
+// field is allowed, so that keys the chaincode would ignore are not silently
+// dropped.
+type AssetSchema struct {
+	ID             *string `json:"ID"`
+	Color          *string `json:"Color"`
+	Size           *int    `json:"Size"`
+	Owner          *string `json:"Owner"`
+	AppraisedValue *int    `json:"AppraisedValue"`
+}
+
+// validateAssetJSON checks raw against AssetSchema, rejecting unknown fields,
+// fields of the wrong type and missing fields with an ErrInvalidInput error
+func validateAssetJSON(raw []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	var schema AssetSchema
+	if err := decoder.Decode(&schema); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return fmt.Errorf("asset field %s must be of type %s, not %s: %w", typeErr.Field, typeErr.Type, typeErr.Value, ErrInvalidInput)
+		}
+		return fmt.Errorf("invalid asset payload: %v: %w", err, ErrInvalidInput)
+	}
+	if decoder.More() {
+		return fmt.Errorf("invalid asset payload: unexpected data after the asset object: %w", ErrInvalidInput)
+	}
+
+	required := []struct {
+		name    string
+		present bool
+	}{
+		{"ID", schema.ID != nil},
+		{"Color", schema.Color != nil},
+		{"Size", schema.Size != nil},
+		{"Owner", schema.Owner != nil},
+		{"AppraisedValue", schema.AppraisedValue != nil},
+	}
+	for _, field := range required {
+		if !field.present {
+			return fmt.Errorf("asset field %s is required: %w", field.name, ErrInvalidInput)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test validateAssetJSON against AssetSchema
+func TestValidateAssetJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		errText string
+	}{
+		{"Valid", `{"ID":"asset1","Color":"blue","Size":5,"Owner":"John","AppraisedValue":300}`, ""},
+		{"Extra Field", `{"ID":"asset1","Color":"blue","Size":5,"Owner":"John","AppraisedValue":300,"Colour":"red"}`, `unknown field "Colour"`},
+		{"Wrong Type Size", `{"ID":"asset1","Color":"blue","Size":"5","Owner":"John","AppraisedValue":300}`, "asset field Size must be of type int, not string"},
+		{"Missing Field", `{"ID":"asset1","Color":"blue","Owner":"John","AppraisedValue":300}`, "asset field Size is required"},
+		{"Null Field", `{"ID":"asset1","Color":"blue","Size":5,"Owner":null,"AppraisedValue":300}`, "asset field Owner is required"},
+		{"Trailing Data", `{"ID":"asset1","Color":"blue","Size":5,"Owner":"John","AppraisedValue":300}{}`, "unexpected data"},
+		{"Not An Object", `["asset1"]`, "invalid asset payload"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAssetJSON([]byte(tt.payload))
+			if tt.errText == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidInput))
+			assert.Contains(t, err.Error(), tt.errText)
+		})
+	}
+}
+
+// Test that the batch create paths reject payloads that do not match the schema
+func TestBatchSchemaValidation(t *testing.T) {
+	contract := AssetContract{}
+
+	t.Run("All Or Nothing", func(t *testing.T) {
+		ledger := NewLedger()
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) error {
+			return contract.CreateAssetsBatch(ctx, `[
+				{"ID": "asset1", "Color": "blue", "Size": 5, "Owner": "John", "AppraisedValue": 300},
+				{"ID": "asset2", "Color": "red", "Size": 5, "Owner": "John", "AppraisedValue": 300, "Tags": ["x"]}
+			]`)
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Contains(t, err.Error(), "asset at index 1")
+		assert.Nil(t, ledger.Get("asset1"))
+	})
+
+	t.Run("Best Effort", func(t *testing.T) {
+		ledger := NewLedger()
+		var report *BatchReport
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			report, err = contract.BatchCreateAssetsBestEffort(ctx, `[
+				{"ID": "asset1", "Color": "blue", "Size": 5, "Owner": "John", "AppraisedValue": 300},
+				{"ID": "asset2", "Color": "red", "Size": "big", "Owner": "John", "AppraisedValue": 300},
+				{"Color": "red", "Size": 5, "Owner": "John", "AppraisedValue": 300}
+			]`)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"asset1"}, report.Created)
+		require.Len(t, report.Failed, 2)
+		assert.Equal(t, "asset2", report.Failed[0].ID)
+		assert.Contains(t, report.Failed[0].Reason, "asset field Size must be of type int")
+		assert.Equal(t, "#2", report.Failed[1].ID)
+		assert.Contains(t, report.Failed[1].Reason, "asset field ID is required")
+		assert.Nil(t, ledger.Get("asset2"))
+	})
+}
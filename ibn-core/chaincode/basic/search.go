@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+const (
+	// defaultSearchPageSize is used when SearchCriteria leaves PageSize unset
+	defaultSearchPageSize = 100
+	// maxSearchPageSize bounds the number of assets a single search returns
+	maxSearchPageSize = 1000
+)
+
+// searchSortFields lists the fields SearchAssets may sort on. CouchDB only
+// sorts on indexed fields, so each has an index in META-INF/statedb/couchdb/indexes.
+var searchSortFields = map[string]bool{
+	"ID": true, "Owner": true, "Color": true, "Size": true,
+	"AppraisedValue": true, "CreatedAt": true, "UpdatedAt": true,
+}
+
+// SearchCriteria filters, sorts and pages SearchAssets. Unset fields are
+// ignored; Status is "active" or "deleted" and refers to soft deletion.
+type SearchCriteria struct {
+	Owner     string `json:"owner"`
+	Color     string `json:"color"`
+	Status    string `json:"status"`
+	ValueMin  *int   `json:"valueMin"`
+	ValueMax  *int   `json:"valueMax"`
+	SizeMin   *int   `json:"sizeMin"`
+	SizeMax   *int   `json:"sizeMax"`
+	SortField string `json:"sortField"`
+	Direction string `json:"direction"`
+	PageSize  int32  `json:"pageSize"`
+	Bookmark  string `json:"bookmark"`
+}
+
+// buildSearchQuery translates criteria into a CouchDB query. Values are only
+// ever placed into the selector as JSON values, never spliced into the query text.
+func buildSearchQuery(criteria *SearchCriteria) (string, error) {
+	selector := map[string]interface{}{"ID": map[string]interface{}{"$exists": true}}
+
+	if criteria.Owner != "" {
+		if err := validateOwner(criteria.Owner); err != nil {
+			return "", err
+		}
+		selector["Owner"] = criteria.Owner
+	}
+	if criteria.Color != "" {
+		selector["Color"] = criteria.Color
+	}
+
+	switch criteria.Status {
+	case "":
+	case "active":
+		selector["Deleted"] = map[string]interface{}{"$ne": true}
+	case "deleted":
+		selector["Deleted"] = true
+	default:
+		return "", fmt.Errorf("unknown status %q, expected active or deleted", criteria.Status)
+	}
+
+	addRange := func(field string, min, max *int) error {
+		if min != nil && max != nil && *min > *max {
+			return fmt.Errorf("%s minimum %d is greater than maximum %d", field, *min, *max)
+		}
+		bounds := map[string]interface{}{}
+		if min != nil {
+			bounds["$gte"] = *min
+		}
+		if max != nil {
+			bounds["$lte"] = *max
+		}
+		if len(bounds) > 0 {
+			selector[field] = bounds
+		}
+		return nil
+	}
+	if err := addRange("AppraisedValue", criteria.ValueMin, criteria.ValueMax); err != nil {
+		return "", err
+	}
+	if err := addRange("Size", criteria.SizeMin, criteria.SizeMax); err != nil {
+		return "", err
+	}
+
+	query := map[string]interface{}{"selector": selector}
+	if criteria.SortField != "" {
+		if !searchSortFields[criteria.SortField] {
+			return "", fmt.Errorf("cannot sort on field %q", criteria.SortField)
+		}
+		direction := criteria.Direction
+		if direction == "" {
+			direction = "asc"
+		}
+		if direction != "asc" && direction != "desc" {
+			return "", fmt.Errorf("unknown sort direction %q, expected asc or desc", criteria.Direction)
+		}
+		query["sort"] = []map[string]string{{criteria.SortField: direction}}
+		// CouchDB only picks the sort field's index when the selector names it
+		if _, ok := selector[criteria.SortField]; !ok {
+			selector[criteria.SortField] = map[string]interface{}{"$exists": true}
+		}
+	} else if criteria.Direction != "" {
+		return "", fmt.Errorf("sort direction requires a sort field")
+	}
+
+	queryJSON, err := json.Marshal(query)
+	if err != nil {
+		return "", fmt.Errorf("failed to build query: %w", err)
+	}
+	return string(queryJSON), nil
+}
+
+// SearchAssets returns one page of assets matching the criteria given as a JSON
+// encoded SearchCriteria. Sorting requires a CouchDB index on the sort field.
+func (s *AssetContract) SearchAssets(ctx contractapi.TransactionContextInterface, criteriaJSON string) (*PagedAssets, error) {
+	logf(ctx, "===== START: SearchAssets - Criteria: %s =====", criteriaJSON)
+
+	var criteria SearchCriteria
+	if err := json.Unmarshal([]byte(criteriaJSON), &criteria); err != nil {
+		logf(ctx, "ERROR: Invalid search criteria: %v", err)
+		return nil, fmt.Errorf("invalid search criteria: %w", err)
+	}
+	if criteria.PageSize == 0 {
+		criteria.PageSize = defaultSearchPageSize
+	}
+	if criteria.PageSize < 0 || criteria.PageSize > maxSearchPageSize {
+		logf(ctx, "ERROR: Invalid page size %d", criteria.PageSize)
+		return nil, fmt.Errorf("page size must be between 1 and %d", maxSearchPageSize)
+	}
+
+	query, err := buildSearchQuery(&criteria)
+	if err != nil {
+		logf(ctx, "ERROR: Invalid search criteria: %v", err)
+		return nil, err
+	}
+
+	resultsIterator, metadata, err := ctx.GetStub().GetQueryResultWithPagination(query, criteria.PageSize, criteria.Bookmark)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return nil, queryError(err, "GetAllAssets")
+	}
+
+	result, err := collectPagedAssets(ctx, resultsIterator, metadata, criteria.PageSize)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	logf(ctx, "INFO: Found %d assets", len(result.Records))
+	logln(ctx, "===== END: SearchAssets =====")
+	return result, nil
+}
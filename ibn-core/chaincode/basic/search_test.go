@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test SearchAssets with several criteria combinations and paging
+func TestSearchAssets(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300},
+		Asset{ID: "asset2", Color: "red", Size: 10, Owner: "John", AppraisedValue: 500},
+		Asset{ID: "asset3", Color: "blue", Size: 15, Owner: "Jane", AppraisedValue: 700},
+		Asset{ID: "asset4", Color: "blue", Size: 20, Owner: "John", AppraisedValue: 900, Deleted: true},
+		Asset{ID: "asset5", Color: "green", Size: 25, Owner: "Jane", AppraisedValue: 100},
+	)
+
+	search := func(criteriaJSON string) (*PagedAssets, error) {
+		var result *PagedAssets
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			result, err = contract.SearchAssets(ctx, criteriaJSON)
+			return err
+		})
+		return result, err
+	}
+	ids := func(result *PagedAssets) []string {
+		var ids []string
+		for _, asset := range result.Records {
+			ids = append(ids, asset.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name     string
+		criteria string
+		expected []string
+	}{
+		{"No Criteria", `{}`, []string{"asset1", "asset2", "asset3", "asset4", "asset5"}},
+		{"Owner", `{"owner":"John"}`, []string{"asset1", "asset2", "asset4"}},
+		{"Owner And Color", `{"owner":"John","color":"blue"}`, []string{"asset1", "asset4"}},
+		{"Active Only", `{"owner":"John","status":"active"}`, []string{"asset1", "asset2"}},
+		{"Deleted Only", `{"status":"deleted"}`, []string{"asset4"}},
+		{"Value Range", `{"valueMin":300,"valueMax":700}`, []string{"asset1", "asset2", "asset3"}},
+		{"Zero Minimum Is Applied", `{"valueMin":0,"valueMax":100}`, []string{"asset5"}},
+		{"Size Range And Color", `{"color":"blue","sizeMin":10}`, []string{"asset3", "asset4"}},
+		{"Sorted Descending", `{"color":"blue","sortField":"AppraisedValue","direction":"desc"}`, []string{"asset4", "asset3", "asset1"}},
+		{"Sorted Default Ascending", `{"owner":"Jane","sortField":"Size"}`, []string{"asset3", "asset5"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := search(tt.criteria)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ids(result))
+			assert.Equal(t, int32(len(tt.expected)), result.FetchedCount)
+		})
+	}
+
+	t.Run("Paging", func(t *testing.T) {
+		first, err := search(`{"sortField":"AppraisedValue","pageSize":2}`)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"asset5", "asset1"}, ids(first))
+
+		second, err := search(`{"sortField":"AppraisedValue","pageSize":2,"bookmark":"` + first.Bookmark + `"}`)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"asset2", "asset3"}, ids(second))
+	})
+
+	invalid := map[string]string{
+		"Malformed JSON":         `not json`,
+		"Unknown Status":         `{"status":"archived"}`,
+		"Inverted Value Range":   `{"valueMin":10,"valueMax":5}`,
+		"Unsortable Field":       `{"sortField":"CreatedBy"}`,
+		"Unknown Direction":      `{"sortField":"Size","direction":"up"}`,
+		"Direction Without Sort": `{"direction":"desc"}`,
+		"Page Size Too Large":    `{"pageSize":5000}`,
+		"Owner Too Long":         `{"owner":"` + strings.Repeat("x", 129) + `"}`,
+	}
+	for name, criteria := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := search(criteria)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// Test that every field SearchAssets sorts on leads a shipped CouchDB index
+func TestSearchSortFieldsIndexed(t *testing.T) {
+	files, err := filepath.Glob("META-INF/statedb/couchdb/indexes/*.json")
+	require.NoError(t, err)
+
+	indexed := map[string]bool{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		var definition struct {
+			Index struct {
+				Fields []string `json:"fields"`
+			} `json:"index"`
+		}
+		require.NoError(t, json.Unmarshal(content, &definition), file)
+		require.NotEmpty(t, definition.Index.Fields, file)
+		indexed[definition.Index.Fields[0]] = true
+	}
+
+	for field := range searchSortFields {
+		assert.True(t, indexed[field], "no index leads with sort field %s", field)
+	}
+}
@@ -0,0 +1,401 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// maxOwnerCountEntries bounds the number of distinct owners GetOwnerCounts
+// returns so the response stays well within the gRPC message size limit
+const maxOwnerCountEntries = 10000
+
+// GetOwnerCounts returns the number of assets held by every owner, computed in
+// a single range scan over the world state
+func (s *AssetContract) GetOwnerCounts(ctx contractapi.TransactionContextInterface) (map[string]int, error) {
+	logln(ctx, "===== START: GetOwnerCounts =====")
+
+	resultsIterator, err := ctx.GetStub().GetStateByRange("", "")
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get state by range: %v", err)
+		return nil, fmt.Errorf("failed to get state by range: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	counts := make(map[string]int)
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate results: %v", err)
+			return nil, fmt.Errorf("failed to iterate results: %w", err)
+		}
+
+		var asset struct {
+			Owner string `json:"Owner"`
+		}
+		err = json.Unmarshal(queryResponse.Value, &asset)
+		if err != nil {
+			logf(ctx, "WARNING: Failed to unmarshal asset, skipping: %v", err)
+			continue
+		}
+
+		if _, seen := counts[asset.Owner]; !seen && len(counts) >= maxOwnerCountEntries {
+			logf(ctx, "ERROR: More than %d distinct owners", maxOwnerCountEntries)
+			return nil, fmt.Errorf("more than %d distinct owners, result too large", maxOwnerCountEntries)
+		}
+		counts[asset.Owner]++
+	}
+
+	logf(ctx, "INFO: Counted assets for %d owners", len(counts))
+	logln(ctx, "===== END: GetOwnerCounts =====")
+	return counts, nil
+}
+
+// maxDistributionValues caps how many values GetValueDistributionStats collects
+// into memory before giving up
+const maxDistributionValues = 100000
+
+// ValueDistribution summarizes the distribution of appraised values
+type ValueDistribution struct {
+	Count  int     `json:"Count"`
+	Min    int     `json:"Min"`
+	Max    int     `json:"Max"`
+	Mean   float64 `json:"Mean"`
+	Median float64 `json:"Median"`
+	Q1     float64 `json:"Q1"`
+	Q3     float64 `json:"Q3"`
+}
+
+// GetValueDistributionStats returns count, min, max, mean, median and quartiles of
+// the appraised values of all assets. Quartiles use linear interpolation between
+// the closest ranks. All fields are zero when there are no assets.
+func (s *AssetContract) GetValueDistributionStats(ctx contractapi.TransactionContextInterface) (*ValueDistribution, error) {
+	logln(ctx, "===== START: GetValueDistributionStats =====")
+
+	resultsIterator, err := ctx.GetStub().GetStateByRange("", "")
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get state by range: %v", err)
+		return nil, fmt.Errorf("failed to get state by range: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	var values []int
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate results: %v", err)
+			return nil, fmt.Errorf("failed to iterate results: %w", err)
+		}
+
+		var asset struct {
+			AppraisedValue int `json:"AppraisedValue"`
+		}
+		err = json.Unmarshal(queryResponse.Value, &asset)
+		if err != nil {
+			logf(ctx, "WARNING: Failed to unmarshal asset, skipping: %v", err)
+			continue
+		}
+
+		if len(values) >= maxDistributionValues {
+			logf(ctx, "ERROR: More than %d assets", maxDistributionValues)
+			return nil, fmt.Errorf("more than %d assets, too many to compute distribution", maxDistributionValues)
+		}
+		values = append(values, asset.AppraisedValue)
+	}
+
+	stats := &ValueDistribution{Count: len(values)}
+	if len(values) > 0 {
+		sort.Ints(values)
+		sum := 0
+		for _, value := range values {
+			sum += value
+		}
+		stats.Min = values[0]
+		stats.Max = values[len(values)-1]
+		stats.Mean = float64(sum) / float64(len(values))
+		stats.Q1 = quantile(values, 0.25)
+		stats.Median = quantile(values, 0.5)
+		stats.Q3 = quantile(values, 0.75)
+	}
+
+	logf(ctx, "INFO: Computed value distribution over %d assets", stats.Count)
+	logln(ctx, "===== END: GetValueDistributionStats =====")
+	return stats, nil
+}
+
+// quantile returns the q-th quantile of sorted, non-empty values, interpolating
+// linearly between the two closest ranks
+func quantile(sorted []int, q float64) float64 {
+	position := q * float64(len(sorted)-1)
+	lower := int(position)
+	if lower+1 >= len(sorted) {
+		return float64(sorted[lower])
+	}
+	fraction := position - float64(lower)
+	return float64(sorted[lower]) + fraction*float64(sorted[lower+1]-sorted[lower])
+}
+
+// CountAssetsInRange returns the number of assets whose IDs fall in the half-open
+// range [startID, endID) without unmarshaling them, so clients can size shards
+// before processing. An empty bound leaves that end of the range open.
+func (s *AssetContract) CountAssetsInRange(ctx contractapi.TransactionContextInterface, startID string, endID string) (int, error) {
+	startID = normalizeAssetID(startID)
+	endID = normalizeAssetID(endID)
+	logf(ctx, "===== START: CountAssetsInRange - Start: %s, End: %s =====", startID, endID)
+
+	if startID != "" && endID != "" && startID > endID {
+		logf(ctx, "ERROR: Start ID %s is after end ID %s", startID, endID)
+		return 0, fmt.Errorf("start ID %s must not be after end ID %s", startID, endID)
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByRange(startID, endID)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get state by range: %v", err)
+		return 0, fmt.Errorf("failed to get state by range: %w", err)
+	}
+
+	count, err := countResults(resultsIterator)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+
+	logf(ctx, "INFO: Counted %d assets in range", count)
+	logln(ctx, "===== END: CountAssetsInRange =====")
+	return count, nil
+}
+
+// CountAssets returns the number of assets in the world state
+func (s *AssetContract) CountAssets(ctx contractapi.TransactionContextInterface) (int, error) {
+	logln(ctx, "===== START: CountAssets =====")
+
+	// An open range covers all simple keys, which excludes composite-key records
+	resultsIterator, err := ctx.GetStub().GetStateByRange("", "")
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get state by range: %v", err)
+		return 0, fmt.Errorf("failed to get state by range: %w", err)
+	}
+
+	count, err := countResults(resultsIterator)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+
+	logf(ctx, "INFO: Counted %d assets", count)
+	logln(ctx, "===== END: CountAssets =====")
+	return count, nil
+}
+
+// CountAssetsByOwner returns the number of assets held by owner
+func (s *AssetContract) CountAssetsByOwner(ctx contractapi.TransactionContextInterface, owner string) (int, error) {
+	logf(ctx, "===== START: CountAssetsByOwner - Owner: %s =====", owner)
+
+	if err := validateOwner(owner); err != nil {
+		logf(ctx, "ERROR: Invalid owner: %v", err)
+		return 0, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(ownerQuery(owner))
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return 0, queryError(err, "QueryAssetsByOwnerIndexed")
+	}
+
+	count, err := countResults(resultsIterator)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return 0, err
+	}
+
+	logf(ctx, "INFO: Counted %d assets for owner %s", count, owner)
+	logln(ctx, "===== END: CountAssetsByOwner =====")
+	return count, nil
+}
+
+// OwnerPortfolio is the number and total appraised value of an owner's assets
+type OwnerPortfolio struct {
+	Owner      string `json:"Owner"`
+	Count      int    `json:"Count"`
+	TotalValue int    `json:"TotalValue"`
+}
+
+// GetOwnerPortfolioValue returns how many assets owner holds and the sum of
+// their appraised values. An owner without assets gets zero totals.
+func (s *AssetContract) GetOwnerPortfolioValue(ctx contractapi.TransactionContextInterface, owner string) (*OwnerPortfolio, error) {
+	logf(ctx, "===== START: GetOwnerPortfolioValue - Owner: %s =====", owner)
+
+	if err := validateOwner(owner); err != nil {
+		logf(ctx, "ERROR: Invalid owner: %v", err)
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(ownerQuery(owner))
+	if err != nil {
+		logf(ctx, "ERROR: Failed to execute query: %v", err)
+		return nil, queryError(err, "QueryAssetsByOwnerIndexed")
+	}
+	defer resultsIterator.Close()
+
+	portfolio := &OwnerPortfolio{Owner: owner}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate query results: %v", err)
+			return nil, fmt.Errorf("failed to iterate query results: %w", err)
+		}
+
+		var asset struct {
+			AppraisedValue int `json:"AppraisedValue"`
+		}
+		err = json.Unmarshal(queryResponse.Value, &asset)
+		if err != nil {
+			logf(ctx, "WARNING: Failed to unmarshal asset, skipping: %v", err)
+			continue
+		}
+		portfolio.Count++
+		portfolio.TotalValue += asset.AppraisedValue
+	}
+
+	logf(ctx, "INFO: Owner %s holds %d assets worth %d", owner, portfolio.Count, portfolio.TotalValue)
+	logln(ctx, "===== END: GetOwnerPortfolioValue =====")
+	return portfolio, nil
+}
+
+// countResults consumes an iterator without decoding its records and closes
+// it, also when iteration fails
+func countResults(resultsIterator shim.StateQueryIteratorInterface) (int, error) {
+	defer resultsIterator.Close()
+
+	count := 0
+	for resultsIterator.HasNext() {
+		if _, err := resultsIterator.Next(); err != nil {
+			return 0, fmt.Errorf("failed to iterate results: %w", err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+const (
+	// maxActivityWindowDays bounds the window GetCreationActivity reports on
+	maxActivityWindowDays = 366
+	// maxActivityCells bounds the number of owner/day counts GetCreationActivity returns
+	maxActivityCells = 10000
+)
+
+// GetCreationActivity returns the number of assets created per owner per UTC day
+// (YYYY-MM-DD) for assets with a CreatedAt in [startUnix, endUnix)
+func (s *AssetContract) GetCreationActivity(ctx contractapi.TransactionContextInterface, startUnix int64, endUnix int64) (map[string]map[string]int, error) {
+	logf(ctx, "===== START: GetCreationActivity - Start: %d, End: %d =====", startUnix, endUnix)
+
+	if startUnix < 0 || endUnix <= startUnix {
+		logf(ctx, "ERROR: Invalid window [%d, %d)", startUnix, endUnix)
+		return nil, fmt.Errorf("invalid time window: start must be non-negative and before end")
+	}
+	if endUnix-startUnix > maxActivityWindowDays*24*60*60 {
+		logf(ctx, "ERROR: Window [%d, %d) is too long", startUnix, endUnix)
+		return nil, fmt.Errorf("time window cannot exceed %d days", maxActivityWindowDays)
+	}
+
+	assets, err := s.GetAllAssets(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get assets: %v", err)
+		return nil, err
+	}
+
+	start := time.Unix(startUnix, 0)
+	end := time.Unix(endUnix, 0)
+	activity := make(map[string]map[string]int)
+	cells := 0
+	for _, asset := range assets {
+		if asset.CreatedAt.Before(start) || !asset.CreatedAt.Before(end) {
+			continue
+		}
+
+		days, ok := activity[asset.Owner]
+		if !ok {
+			days = make(map[string]int)
+			activity[asset.Owner] = days
+		}
+		day := asset.CreatedAt.UTC().Format("2006-01-02")
+		if _, seen := days[day]; !seen {
+			cells++
+			if cells > maxActivityCells {
+				logf(ctx, "ERROR: More than %d owner/day cells", maxActivityCells)
+				return nil, fmt.Errorf("more than %d owner/day counts, narrow the time window", maxActivityCells)
+			}
+		}
+		days[day]++
+	}
+
+	logf(ctx, "INFO: Counted creation activity of %d owners over %d owner/day cells", len(activity), cells)
+	logln(ctx, "===== END: GetCreationActivity =====")
+	return activity, nil
+}
+
+// AssetStatistics summarizes all assets of the world state
+type AssetStatistics struct {
+	Count        int            `json:"Count"`
+	TotalValue   int            `json:"TotalValue"`
+	AverageValue float64        `json:"AverageValue"`
+	MinSize      int            `json:"MinSize"`
+	MaxSize      int            `json:"MaxSize"`
+	ColorCounts  map[string]int `json:"ColorCounts"`
+}
+
+// GetAssetStatistics returns the asset count, the total and average appraised
+// value, the size range and the number of assets of each color, computed in a
+// single range scan. All numbers are zero when there are no assets.
+func (s *AssetContract) GetAssetStatistics(ctx contractapi.TransactionContextInterface) (*AssetStatistics, error) {
+	logln(ctx, "===== START: GetAssetStatistics =====")
+
+	resultsIterator, err := ctx.GetStub().GetStateByRange("", "")
+	if err != nil {
+		logf(ctx, "ERROR: Failed to get state by range: %v", err)
+		return nil, fmt.Errorf("failed to get state by range: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	stats := &AssetStatistics{ColorCounts: make(map[string]int)}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate results: %v", err)
+			return nil, fmt.Errorf("failed to iterate results: %w", err)
+		}
+
+		var asset struct {
+			Color          string `json:"Color"`
+			Size           int    `json:"Size"`
+			AppraisedValue int    `json:"AppraisedValue"`
+		}
+		err = json.Unmarshal(queryResponse.Value, &asset)
+		if err != nil {
+			logf(ctx, "WARNING: Failed to unmarshal asset, skipping: %v", err)
+			continue
+		}
+
+		if stats.Count == 0 || asset.Size < stats.MinSize {
+			stats.MinSize = asset.Size
+		}
+		if stats.Count == 0 || asset.Size > stats.MaxSize {
+			stats.MaxSize = asset.Size
+		}
+		stats.Count++
+		stats.TotalValue += asset.AppraisedValue
+		stats.ColorCounts[asset.Color]++
+	}
+
+	if stats.Count > 0 {
+		stats.AverageValue = float64(stats.TotalValue) / float64(stats.Count)
+	}
+
+	logf(ctx, "INFO: Computed statistics over %d assets", stats.Count)
+	logln(ctx, "===== END: GetAssetStatistics =====")
+	return stats, nil
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test GetOwnerCounts
+func TestGetOwnerCounts(t *testing.T) {
+	contract := AssetContract{}
+
+	t.Run("Counts Per Owner", func(t *testing.T) {
+		ledger := NewLedger()
+		ledger.Seed(
+			Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 500},
+			Asset{ID: "asset2", Color: "red", Size: 20, Owner: "Jane", AppraisedValue: 600},
+			Asset{ID: "asset3", Color: "green", Size: 30, Owner: "John", AppraisedValue: 700},
+			Asset{ID: "asset4", Color: "white", Size: 40, Owner: "John", AppraisedValue: 800},
+			Asset{ID: "asset5", Color: "black", Size: 50, Owner: "Max", AppraisedValue: 900},
+		)
+
+		var counts map[string]int
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			counts, err = contract.GetOwnerCounts(ctx)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"John": 3, "Jane": 1, "Max": 1}, counts)
+	})
+
+	t.Run("Empty Ledger", func(t *testing.T) {
+		ledger := NewLedger()
+		var counts map[string]int
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			counts, err = contract.GetOwnerCounts(ctx)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+	})
+}
+
+// Test GetValueDistributionStats
+func TestGetValueDistributionStats(t *testing.T) {
+	contract := AssetContract{}
+
+	distribution := func(t *testing.T, values ...int) *ValueDistribution {
+		ledger := NewLedger()
+		for i, value := range values {
+			ledger.Seed(Asset{ID: fmt.Sprintf("asset%d", i), Color: "blue", Size: 1, Owner: "John", AppraisedValue: value})
+		}
+		var stats *ValueDistribution
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			stats, err = contract.GetValueDistributionStats(ctx)
+			return err
+		})
+		require.NoError(t, err)
+		return stats
+	}
+
+	t.Run("Known Dataset", func(t *testing.T) {
+		stats := distribution(t, 700, 100, 500, 300, 900, 200, 800, 400)
+		assert.Equal(t, 8, stats.Count)
+		assert.Equal(t, 100, stats.Min)
+		assert.Equal(t, 900, stats.Max)
+		assert.Equal(t, 487.5, stats.Mean)
+		assert.Equal(t, 275.0, stats.Q1)
+		assert.Equal(t, 450.0, stats.Median)
+		assert.Equal(t, 725.0, stats.Q3)
+	})
+
+	t.Run("Odd Count", func(t *testing.T) {
+		stats := distribution(t, 10, 20, 30, 40, 50)
+		assert.Equal(t, 20.0, stats.Q1)
+		assert.Equal(t, 30.0, stats.Median)
+		assert.Equal(t, 40.0, stats.Q3)
+	})
+
+	t.Run("Single Element", func(t *testing.T) {
+		stats := distribution(t, 42)
+		assert.Equal(t, ValueDistribution{Count: 1, Min: 42, Max: 42, Mean: 42, Median: 42, Q1: 42, Q3: 42}, *stats)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		stats := distribution(t)
+		assert.Equal(t, ValueDistribution{}, *stats)
+	})
+}
+
+// Test CountAssetsInRange over a seeded keyspace
+func TestCountAssetsInRange(t *testing.T) {
+	contract := AssetContract{}
+
+	ledger := NewLedger()
+	for _, id := range []string{"a1", "a2", "a3", "b1", "b2", "c1"} {
+		ledger.Seed(Asset{ID: id, Color: "blue", Size: 5, Owner: "Tomoko", AppraisedValue: 300})
+	}
+
+	count := func(startID, endID string) (int, error) {
+		var n int
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			n, err = contract.CountAssetsInRange(ctx, startID, endID)
+			return err
+		})
+		return n, err
+	}
+
+	tests := []struct {
+		name     string
+		startID  string
+		endID    string
+		expected int
+	}{
+		{"Bounded Range Excludes End", "a1", "b1", 3},
+		{"Prefix Range", "b", "c", 2},
+		{"Open End", "b2", "", 2},
+		{"Open Start", "", "a3", 2},
+		{"Whole Keyspace", "", "", 6},
+		{"Empty Range", "d", "e", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := count(tt.startID, tt.endID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, n)
+		})
+	}
+
+	t.Run("Start After End", func(t *testing.T) {
+		_, err := count("c", "a")
+		assert.Error(t, err)
+	})
+}
+
+// Test GetCreationActivity buckets by owner and UTC day
+func TestGetCreationActivity(t *testing.T) {
+	contract := AssetContract{}
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, time.UTC) }
+
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Owner: "John", CreatedAt: at(1, 9)},
+		Asset{ID: "asset2", Owner: "John", CreatedAt: at(1, 23)},
+		Asset{ID: "asset3", Owner: "John", CreatedAt: at(2, 0)},
+		Asset{ID: "asset4", Owner: "Jane", CreatedAt: at(1, 12)},
+		// 01:00 on the 3rd in UTC, although the local date is the 2nd
+		Asset{ID: "asset5", Owner: "Jane", CreatedAt: at(3, 1).In(time.FixedZone("UTC-5", -5*60*60))},
+		Asset{ID: "asset6", Owner: "Jane", CreatedAt: at(10, 0)},
+	)
+
+	activity := func(start, end time.Time) (map[string]map[string]int, error) {
+		var result map[string]map[string]int
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			result, err = contract.GetCreationActivity(ctx, start.Unix(), end.Unix())
+			return err
+		})
+		return result, err
+	}
+
+	result, err := activity(at(1, 0), at(10, 0))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]int{
+		"John": {"2024-03-01": 2, "2024-03-02": 1},
+		"Jane": {"2024-03-01": 1, "2024-03-03": 1},
+	}, result)
+
+	t.Run("Invalid Window", func(t *testing.T) {
+		_, err := activity(at(2, 0), at(1, 0))
+		assert.Error(t, err)
+		_, err = activity(at(1, 0), at(1, 0).AddDate(2, 0, 0))
+		assert.Error(t, err)
+	})
+}
+
+// failingIterator yields its records and then fails instead of ending
+type failingIterator struct {
+	sliceIterator
+}
+
+func (it *failingIterator) HasNext() bool {
+	return true
+}
+
+func (it *failingIterator) Next() (*queryresult.KV, error) {
+	if it.sliceIterator.HasNext() {
+		return it.sliceIterator.Next()
+	}
+	return nil, errors.New("iterator failed")
+}
+
+// Test CountAssets and CountAssetsByOwner against mocked iterators
+func TestCountAssets(t *testing.T) {
+	contract := AssetContract{}
+	// Values are never decoded, so they need not be valid assets
+	records := func() []*queryresult.KV {
+		return []*queryresult.KV{
+			{Key: "asset1", Value: []byte("a")},
+			{Key: "asset2", Value: []byte("b")},
+			{Key: "asset3", Value: []byte("c")},
+		}
+	}
+
+	t.Run("CountAssets", func(t *testing.T) {
+		stub := new(MockStub)
+		iterator := &sliceIterator{kvs: records()}
+		stub.On("GetStateByRange", "", "").Return(iterator, nil).Once()
+
+		count, err := contract.CountAssets(&MockTransactionContext{stub: stub})
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.True(t, iterator.Closed)
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("CountAssetsByOwner", func(t *testing.T) {
+		stub := new(MockStub)
+		iterator := &sliceIterator{kvs: records()}
+		stub.On("GetQueryResult", ownerQuery("John")).Return(iterator, nil).Once()
+
+		count, err := contract.CountAssetsByOwner(&MockTransactionContext{stub: stub}, "John")
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.True(t, iterator.Closed)
+		stub.AssertExpectations(t)
+	})
+
+	t.Run("Iterator Closed On Error", func(t *testing.T) {
+		stub := new(MockStub)
+		iterator := &failingIterator{sliceIterator{kvs: records()}}
+		stub.On("GetStateByRange", "", "").Return(iterator, nil).Once()
+
+		_, err := contract.CountAssets(&MockTransactionContext{stub: stub})
+		assert.Error(t, err)
+		assert.True(t, iterator.Closed)
+	})
+
+	t.Run("Invalid Owner", func(t *testing.T) {
+		_, err := contract.CountAssetsByOwner(&MockTransactionContext{stub: new(MockStub)}, "")
+		assert.Error(t, err)
+	})
+}
+
+// Test GetAssetStatistics
+func TestGetAssetStatistics(t *testing.T) {
+	contract := AssetContract{}
+
+	statistics := func(t *testing.T, assets ...Asset) *AssetStatistics {
+		iterator := &sliceIterator{}
+		for _, asset := range assets {
+			assetJSON, _ := json.Marshal(asset)
+			iterator.kvs = append(iterator.kvs, &queryresult.KV{Key: asset.ID, Value: assetJSON})
+		}
+		stub := new(MockStub)
+		stub.On("GetStateByRange", "", "").Return(iterator, nil).Once()
+
+		stats, err := contract.GetAssetStatistics(&MockTransactionContext{stub: stub})
+		require.NoError(t, err)
+		assert.True(t, iterator.Closed)
+		stub.AssertExpectations(t)
+		return stats
+	}
+
+	t.Run("Three Assets", func(t *testing.T) {
+		stats := statistics(t,
+			Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 300},
+			Asset{ID: "asset2", Color: "red", Size: 5, Owner: "Jane", AppraisedValue: 400},
+			Asset{ID: "asset3", Color: "blue", Size: 20, Owner: "Max", AppraisedValue: 800},
+		)
+		assert.Equal(t, 3, stats.Count)
+		assert.Equal(t, 1500, stats.TotalValue)
+		assert.Equal(t, 500.0, stats.AverageValue)
+		assert.Equal(t, 5, stats.MinSize)
+		assert.Equal(t, 20, stats.MaxSize)
+		assert.Equal(t, map[string]int{"blue": 2, "red": 1}, stats.ColorCounts)
+	})
+
+	t.Run("No Assets", func(t *testing.T) {
+		stats := statistics(t)
+		assert.Equal(t, &AssetStatistics{ColorCounts: map[string]int{}}, stats)
+	})
+}
+
+// Test GetOwnerPortfolioValue
+func TestGetOwnerPortfolioValue(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 10, Owner: "John", AppraisedValue: 300},
+		Asset{ID: "asset2", Color: "red", Size: 5, Owner: "Jane", AppraisedValue: 400},
+		Asset{ID: "asset3", Color: "blue", Size: 20, Owner: "John", AppraisedValue: 800},
+	)
+	portfolio := func(owner string) (*OwnerPortfolio, error) {
+		var result *OwnerPortfolio
+		_, err := ledger.Invoke(nil, func(ctx *MockTransactionContext) (err error) {
+			result, err = contract.GetOwnerPortfolioValue(ctx, owner)
+			return err
+		})
+		return result, err
+	}
+
+	t.Run("Sums Owned Assets", func(t *testing.T) {
+		result, err := portfolio("John")
+		require.NoError(t, err)
+		assert.Equal(t, &OwnerPortfolio{Owner: "John", Count: 2, TotalValue: 1100}, result)
+	})
+
+	t.Run("Owner Without Assets", func(t *testing.T) {
+		result, err := portfolio("Max")
+		require.NoError(t, err)
+		assert.Equal(t, &OwnerPortfolio{Owner: "Max"}, result)
+	})
+
+	t.Run("Invalid Owner", func(t *testing.T) {
+		_, err := portfolio("")
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+	})
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// tagIndexObjectType is the composite key namespace of the tag index
+// (tag~id~<tag>~<id>), which lets assets be found by tag without rich queries
+const tagIndexObjectType = "tag~id"
+
+// maxAssetTags bounds the number of tags of an asset
+const maxAssetTags = 32
+
+// tagPattern restricts tags to short identifiers
+var tagPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+func validateTag(tag string) error {
+	if !tagPattern.MatchString(tag) {
+		return fmt.Errorf("tag %q must be 1 to 64 letters, digits, '_', '.' or '-': %w", tag, ErrInvalidInput)
+	}
+	return nil
+}
+
+// AddAssetTag attaches a tag to an asset and indexes it
+func (s *AssetContract) AddAssetTag(ctx contractapi.TransactionContextInterface, id string, tag string) error {
+	return s.changeAssetTag(ctx, id, tag, true)
+}
+
+// RemoveAssetTag detaches a tag from an asset and drops it from the index
+func (s *AssetContract) RemoveAssetTag(ctx contractapi.TransactionContextInterface, id string, tag string) error {
+	return s.changeAssetTag(ctx, id, tag, false)
+}
+
+func (s *AssetContract) changeAssetTag(ctx contractapi.TransactionContextInterface, id string, tag string, add bool) error {
+	id = normalizeAssetID(id)
+	logf(ctx, "===== START: ChangeAssetTag - ID: %s, Tag: %s, Add: %t =====", id, tag, add)
+
+	if err := requireWritable(ctx); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	if err := validateAssetID(id); err != nil {
+		logf(ctx, "ERROR: Invalid asset ID: %v", err)
+		return err
+	}
+	if err := validateTag(tag); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		logf(ctx, "ERROR: Asset %s does not exist: %v", id, err)
+		return err
+	}
+	if !clientActsAs(ctx, asset.Owner) {
+		if err := requireAdmin(ctx); err != nil {
+			logf(ctx, "ERROR: Caller may not change the tags of asset %s owned by %s", id, asset.Owner)
+			return fmt.Errorf("only the owner or an admin may change the tags of asset %s: %w", id, ErrNotOwner)
+		}
+	}
+	if err := checkNotLocked(asset); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	i := sort.SearchStrings(asset.Tags, tag)
+	tagged := i < len(asset.Tags) && asset.Tags[i] == tag
+	if add {
+		if tagged {
+			logf(ctx, "ERROR: Asset %s already has tag %s", id, tag)
+			return fmt.Errorf("asset %s already has tag %s", id, tag)
+		}
+		if len(asset.Tags) >= maxAssetTags {
+			logf(ctx, "ERROR: Asset %s already has %d tags", id, maxAssetTags)
+			return fmt.Errorf("asset %s cannot have more than %d tags", id, maxAssetTags)
+		}
+		asset.Tags = append(asset.Tags, "")
+		copy(asset.Tags[i+1:], asset.Tags[i:])
+		asset.Tags[i] = tag
+	} else {
+		if !tagged {
+			logf(ctx, "ERROR: Asset %s does not have tag %s", id, tag)
+			return fmt.Errorf("asset %s does not have tag %s", id, tag)
+		}
+		asset.Tags = append(asset.Tags[:i], asset.Tags[i+1:]...)
+	}
+
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		logf(ctx, "WARNING: Could not get client identity: %v", err)
+		clientID = "unknown"
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+	stampUpdate(ctx, asset, now, clientID)
+
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to marshal asset: %v", err)
+		return fmt.Errorf("failed to marshal asset: %w", err)
+	}
+
+	err = ctx.GetStub().PutState(id, assetJSON)
+	if err != nil {
+		logf(ctx, "ERROR: Failed to update asset tags: %v", err)
+		return fmt.Errorf("failed to update asset tags: %w", err)
+	}
+
+	indexKey, err := ctx.GetStub().CreateCompositeKey(tagIndexObjectType, []string{tag, id})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to create tag index key: %v", err)
+		return fmt.Errorf("failed to create tag index key: %w", err)
+	}
+	operation, eventName := "untag", "AssetTagRemoved"
+	if add {
+		operation, eventName = "tag", "AssetTagAdded"
+		// The index entry carries no data; a null byte marks it as present
+		err = ctx.GetStub().PutState(indexKey, []byte{0x00})
+	} else {
+		err = ctx.GetStub().DelState(indexKey)
+	}
+	if err != nil {
+		logf(ctx, "ERROR: Failed to update tag index: %v", err)
+		return fmt.Errorf("failed to update tag index: %w", err)
+	}
+	if err := recordChange(ctx, id, operation); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return err
+	}
+
+	err = emitEvent(ctx, eventName, map[string]interface{}{
+		"assetID":   id,
+		"tag":       tag,
+		"updatedBy": clientID,
+	})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to emit event: %v", err)
+		return err
+	}
+
+	logf(ctx, "INFO: Tag %s of asset %s updated", tag, id)
+	logln(ctx, "===== END: ChangeAssetTag =====")
+	return nil
+}
+
+// deleteTagIndex removes the tag index entries of an asset that is about to be
+// hard deleted
+func deleteTagIndex(ctx contractapi.TransactionContextInterface, asset *Asset) error {
+	for _, tag := range asset.Tags {
+		indexKey, err := ctx.GetStub().CreateCompositeKey(tagIndexObjectType, []string{tag, asset.ID})
+		if err != nil {
+			return fmt.Errorf("failed to create tag index key: %w", err)
+		}
+		if err := ctx.GetStub().DelState(indexKey); err != nil {
+			return fmt.Errorf("failed to delete tag index entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// QueryAssetsByTag returns the assets carrying a tag, looked up through the
+// tag index so that it also works on LevelDB
+func (s *AssetContract) QueryAssetsByTag(ctx contractapi.TransactionContextInterface, tag string) ([]*Asset, error) {
+	logf(ctx, "===== START: QueryAssetsByTag - Tag: %s =====", tag)
+
+	if err := validateTag(tag); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(tagIndexObjectType, []string{tag})
+	if err != nil {
+		logf(ctx, "ERROR: Failed to read tag index: %v", err)
+		return nil, fmt.Errorf("failed to read tag index: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	assets := []*Asset{}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			logf(ctx, "ERROR: Failed to iterate tag index: %v", err)
+			return nil, fmt.Errorf("failed to iterate tag index: %w", err)
+		}
+
+		_, keyParts, err := ctx.GetStub().SplitCompositeKey(queryResponse.Key)
+		if err != nil || len(keyParts) != 2 {
+			logf(ctx, "WARNING: Malformed tag index key, skipping: %q", queryResponse.Key)
+			continue
+		}
+
+		asset, err := s.ReadAsset(ctx, keyParts[1])
+		if errors.Is(err, ErrAssetNotFound) {
+			logf(ctx, "WARNING: Tagged asset %s no longer exists, skipping", keyParts[1])
+			continue
+		}
+		if err != nil {
+			logf(ctx, "ERROR: %v", err)
+			return nil, err
+		}
+		assets = append(assets, asset)
+	}
+
+	logf(ctx, "INFO: Found %d assets with tag %s", len(assets), tag)
+	logln(ctx, "===== END: QueryAssetsByTag =====")
+	return assets, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test tagging assets through the tag~id composite-key index
+func TestAssetTags(t *testing.T) {
+	contract := AssetContract{}
+	ledger := NewLedger()
+	ledger.Seed(
+		Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "John", AppraisedValue: 300},
+		Asset{ID: "asset2", Color: "red", Size: 5, Owner: "Jane", AppraisedValue: 400},
+		Asset{ID: "asset3", Color: "green", Size: 5, Owner: "Max", AppraisedValue: 500},
+	)
+
+	invoke := func(fn func(ctx *MockTransactionContext) error) error {
+		_, err := ledger.Invoke(nil, fn)
+		return err
+	}
+	byTag := func(tag string) []string {
+		var assets []*Asset
+		require.NoError(t, invoke(func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.QueryAssetsByTag(ctx, tag)
+			return err
+		}))
+		ids := []string{}
+		for _, asset := range assets {
+			ids = append(ids, asset.ID)
+		}
+		return ids
+	}
+	// An admin may tag the assets of every owner
+	addTag := func(id, tag string) error {
+		_, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return contract.AddAssetTag(ctx, id, tag)
+		})
+		return err
+	}
+	removeTag := func(id, tag string) error {
+		_, err := ledger.Invoke(adminIdentity, func(ctx *MockTransactionContext) error {
+			return contract.RemoveAssetTag(ctx, id, tag)
+		})
+		return err
+	}
+
+	t.Run("Add Tag", func(t *testing.T) {
+		require.NoError(t, addTag("asset1", "fragile"))
+		require.NoError(t, addTag("asset1", "export"))
+		require.NoError(t, addTag("asset3", "fragile"))
+		assert.Equal(t, []string{"export", "fragile"}, readCommitted(t, ledger, "asset1").Tags)
+
+		err := addTag("asset1", "fragile")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already has tag")
+	})
+
+	t.Run("Query By Tag", func(t *testing.T) {
+		assert.Equal(t, []string{"asset1", "asset3"}, byTag("fragile"))
+		assert.Equal(t, []string{"asset1"}, byTag("export"))
+		assert.Empty(t, byTag("unused"))
+	})
+
+	t.Run("Index Entries Are Not Assets", func(t *testing.T) {
+		var assets []*Asset
+		require.NoError(t, invoke(func(ctx *MockTransactionContext) (err error) {
+			assets, err = contract.GetAllAssets(ctx)
+			return err
+		}))
+		assert.Len(t, assets, 3)
+	})
+
+	t.Run("Remove Tag", func(t *testing.T) {
+		require.NoError(t, removeTag("asset1", "fragile"))
+		assert.Equal(t, []string{"asset3"}, byTag("fragile"))
+		assert.Equal(t, []string{"export"}, readCommitted(t, ledger, "asset1").Tags)
+
+		err := removeTag("asset2", "fragile")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not have tag")
+	})
+
+	t.Run("Requires Owner Or Admin", func(t *testing.T) {
+		_, err := ledger.Invoke(ownerIdentity("Jane"), func(ctx *MockTransactionContext) error {
+			return contract.AddAssetTag(ctx, "asset1", "stolen")
+		})
+		assert.ErrorIs(t, err, ErrNotOwner)
+		assert.Empty(t, byTag("stolen"))
+	})
+
+	t.Run("Delete Clears Index", func(t *testing.T) {
+		_, err := ledger.Invoke(ownerIdentity("Max"), func(ctx *MockTransactionContext) error {
+			return contract.DeleteAsset(ctx, "asset3")
+		})
+		require.NoError(t, err)
+		assert.Empty(t, byTag("fragile"))
+		assert.Nil(t, ledger.Get(createCompositeKey(tagIndexObjectType, []string{"fragile", "asset3"})))
+	})
+
+	t.Run("Invalid Tag", func(t *testing.T) {
+		assert.Error(t, addTag("asset1", "has space"))
+		assert.Error(t, addTag("asset1", ""))
+	})
+}